@@ -1,4 +1,4 @@
-// Package ac3 contains utilities to work with the AC-3 codec.
+// Package ac3 contains utilities to work with the AC-3 and E-AC-3 codecs.
 package ac3
 
 const (
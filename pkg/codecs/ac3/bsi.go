@@ -7,12 +7,40 @@ import (
 )
 
 // BSI is a Bit Stream Information.
+// When Bsid is 6, Timecod1 and Timecod2 contain the extended bit stream information
+// of the alternate bit stream syntax (xbsi1 and xbsi2).
 // Specification: ATSC, AC-3, Table 5.2
 type BSI struct {
-	Bsid  uint8
-	Bsmod uint8
-	Acmod uint8
-	LfeOn bool
+	Bsid       uint8
+	Bsmod      uint8
+	Acmod      uint8
+	Cmixlev    uint8
+	Surmixlev  uint8
+	Dsurmod    uint8
+	LfeOn      bool
+	Dialnorm   uint8
+	Compre     bool
+	Compr      uint8
+	Langcode   bool
+	Langcod    uint8
+	Audprodie  bool
+	Mixlevel   uint8
+	Roomtyp    uint8
+	Dialnorm2  uint8
+	Compr2e    bool
+	Compr2     uint8
+	Langcod2e  bool
+	Langcod2   uint8
+	Audprodi2e bool
+	Mixlevel2  uint8
+	Roomtyp2   uint8
+	Copyrightb bool
+	Origbs     bool
+	Timecod1e  bool
+	Timecod1   uint16
+	Timecod2e  bool
+	Timecod2   uint16
+	Addbsi     []byte // additional bit stream information, nil if not present
 }
 
 // Unmarshal decodes a BSI.
@@ -22,7 +50,7 @@ func (b *BSI) Unmarshal(buf []byte) error {
 	}
 
 	b.Bsid = buf[0] >> 3
-	if b.Bsid != 0x08 {
+	if b.Bsid > 0x08 {
 		return fmt.Errorf("invalid bsid")
 	}
 
@@ -34,23 +62,154 @@ func (b *BSI) Unmarshal(buf []byte) error {
 	tmp := bits.ReadBitsUnsafe(buf, &pos, 3)
 	b.Acmod = uint8(tmp)
 
+	b.Cmixlev = 0
+	b.Surmixlev = 0
+	b.Dsurmod = 0
+
 	if ((b.Acmod & 0x1) != 0) && (b.Acmod != 0x1) {
-		bits.ReadBitsUnsafe(buf, &pos, 2) // cmixlev
+		b.Cmixlev = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
 	}
 
 	if (b.Acmod & 0x4) != 0 {
-		bits.ReadBitsUnsafe(buf, &pos, 2) // surmixlev
+		b.Surmixlev = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
 	}
 
 	if b.Acmod == 0x2 {
-		bits.ReadBitsUnsafe(buf, &pos, 2) // dsurmod
+		b.Dsurmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
 	}
 
 	b.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
 
+	err := unmarshalBSIProgram(buf, &pos, &b.Dialnorm, &b.Compre, &b.Compr,
+		&b.Langcode, &b.Langcod, &b.Audprodie, &b.Mixlevel, &b.Roomtyp)
+	if err != nil {
+		return err
+	}
+
+	if b.Acmod == 0 {
+		err = unmarshalBSIProgram(buf, &pos, &b.Dialnorm2, &b.Compr2e, &b.Compr2,
+			&b.Langcod2e, &b.Langcod2, &b.Audprodi2e, &b.Mixlevel2, &b.Roomtyp2)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.Dialnorm2 = 0
+		b.Compr2e = false
+		b.Compr2 = 0
+		b.Langcod2e = false
+		b.Langcod2 = 0
+		b.Audprodi2e = false
+		b.Mixlevel2 = 0
+		b.Roomtyp2 = 0
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	b.Copyrightb = bits.ReadFlagUnsafe(buf, &pos)
+	b.Origbs = bits.ReadFlagUnsafe(buf, &pos)
+
+	b.Timecod1e, b.Timecod1, err = readOptionalUint16(buf, &pos, 14)
+	if err != nil {
+		return err
+	}
+
+	b.Timecod2e, b.Timecod2, err = readOptionalUint16(buf, &pos, 14)
+	if err != nil {
+		return err
+	}
+
+	addbsie, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if addbsie {
+		tmp, err = bits.ReadBits(buf, &pos, 6)
+		if err != nil {
+			return err
+		}
+		addbsil := int(tmp) + 1
+
+		err = bits.HasSpace(buf, pos, addbsil*8)
+		if err != nil {
+			return err
+		}
+
+		b.Addbsi = make([]byte, addbsil)
+		for i := range b.Addbsi {
+			b.Addbsi[i] = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+		}
+	} else {
+		b.Addbsi = nil
+	}
+
+	return nil
+}
+
+// unmarshalBSIProgram decodes the fields that are repeated for the second channel in 1+1 mode.
+func unmarshalBSIProgram(
+	buf []byte,
+	pos *int,
+	dialnorm *uint8,
+	compre *bool,
+	compr *uint8,
+	langcode *bool,
+	langcod *uint8,
+	audprodie *bool,
+	mixlevel *uint8,
+	roomtyp *uint8,
+) error {
+	tmp, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+	*dialnorm = uint8(tmp)
+
+	var v uint16
+
+	*compre, v, err = readOptionalUint16(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+	*compr = uint8(v)
+
+	*langcode, v, err = readOptionalUint16(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+	*langcod = uint8(v)
+
+	*audprodie, v, err = readOptionalUint16(buf, pos, 7)
+	if err != nil {
+		return err
+	}
+	*mixlevel = uint8(v >> 2)
+	*roomtyp = uint8(v & 0b11)
+
 	return nil
 }
 
+func readOptionalUint16(buf []byte, pos *int, n int) (bool, uint16, error) {
+	e, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if !e {
+		return false, 0, nil
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, n)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return true, uint16(tmp), nil
+}
+
 // ChannelCount returns the channel count.
 func (b BSI) ChannelCount() int {
 	var n int
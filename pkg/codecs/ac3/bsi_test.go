@@ -17,6 +17,38 @@ func TestBSIUnmarshal(t *testing.T) {
 	}
 }
 
+func TestBSIUnmarshalDualMono(t *testing.T) {
+	var bsi BSI
+	err := bsi.Unmarshal([]byte{
+		0x32, 0x0d, 0xd0, 0x21, 0x3a, 0x38, 0x3e, 0xaa,
+		0x46, 0x91, 0x59, 0xe1, 0x55, 0x5d, 0xe6, 0x00,
+	})
+	require.NoError(t, err)
+	require.Equal(t, BSI{
+		Bsid:       6,
+		Bsmod:      2,
+		Dialnorm:   27,
+		Compre:     true,
+		Compr:      0x40,
+		Langcode:   true,
+		Langcod:    0x09,
+		Audprodie:  true,
+		Mixlevel:   20,
+		Roomtyp:    1,
+		Dialnorm2:  24,
+		Audprodi2e: true,
+		Mixlevel2:  30,
+		Roomtyp2:   2,
+		Copyrightb: true,
+		Timecod1e:  true,
+		Timecod1:   0x1234,
+		Timecod2e:  true,
+		Timecod2:   0x0567,
+		Addbsi:     []byte{0xaa, 0xbb, 0xcc},
+	}, bsi)
+	require.Equal(t, 2, bsi.ChannelCount())
+}
+
 func FuzzBSIUnmarshal(f *testing.F) {
 	for _, ca := range ac3Cases {
 		f.Add(ca.enc[5:])
@@ -0,0 +1,63 @@
+package ac3
+
+import (
+	"fmt"
+)
+
+// crcUpdate computes a CRC-16 with polynomial 0x8005.
+// Specification: ATSC, AC-3, 7.10.1
+func crcUpdate(crc uint16, buf []byte) uint16 {
+	for _, b := range buf {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if (crc & 0x8000) != 0 {
+				crc = (crc << 1) ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// VerifyCRC checks the CRCs of an AC-3 or E-AC-3 syncframe.
+// In AC-3 syncframes, crc1 protects the first 5/8 of the frame and crc2 protects the whole frame.
+// In E-AC-3 syncframes, crc2 protects the whole frame.
+func VerifyCRC(frame []byte) error {
+	var frameSize int
+
+	if IsEAC3(frame) {
+		var bsi EAC3BSI
+		err := bsi.Unmarshal(frame)
+		if err != nil {
+			return err
+		}
+
+		frameSize = bsi.FrameSize()
+	} else {
+		var syncInfo SyncInfo
+		err := syncInfo.Unmarshal(frame)
+		if err != nil {
+			return err
+		}
+
+		frameSize = syncInfo.FrameSize()
+
+		if len(frame) < frameSize {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		words := frameSize / 2
+		frame58Size := ((words >> 1) + (words >> 3)) * 2
+
+		if crcUpdate(0, frame[2:frame58Size]) != 0 {
+			return fmt.Errorf("crc1 mismatch")
+		}
+	}
+
+	if crcUpdate(0, frame[2:frameSize]) != 0 {
+		return fmt.Errorf("crc2 mismatch")
+	}
+
+	return nil
+}
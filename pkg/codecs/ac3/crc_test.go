@@ -0,0 +1,52 @@
+package ac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCRC(t *testing.T) {
+	for _, ca := range ac3Cases {
+		t.Run(ca.name, func(t *testing.T) {
+			err := VerifyCRC(ca.enc)
+			require.NoError(t, err)
+
+			frame := append([]byte(nil), ca.enc...)
+			frame[10] ^= 0x01
+			err = VerifyCRC(frame)
+			require.EqualError(t, err, "crc1 mismatch")
+
+			frame = append([]byte(nil), ca.enc...)
+			frame[len(frame)-10] ^= 0x01
+			err = VerifyCRC(frame)
+			require.EqualError(t, err, "crc2 mismatch")
+		})
+	}
+
+	for _, ca := range eac3Cases {
+		t.Run(ca.name, func(t *testing.T) {
+			frame := append([]byte(nil), ca.enc...)
+			crc := crcUpdate(0, frame[2:len(frame)-2])
+			frame[len(frame)-2] = byte(crc >> 8)
+			frame[len(frame)-1] = byte(crc)
+
+			err := VerifyCRC(frame)
+			require.NoError(t, err)
+
+			frame[len(frame)-3] ^= 0x01
+			err = VerifyCRC(frame)
+			require.EqualError(t, err, "crc2 mismatch")
+		})
+	}
+}
+
+func FuzzVerifyCRC(f *testing.F) {
+	for _, ca := range ac3Cases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		VerifyCRC(b) //nolint:errcheck
+	})
+}
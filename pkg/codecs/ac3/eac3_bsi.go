@@ -0,0 +1,429 @@
+package ac3
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// EAC3StreamType is the type of an E-AC-3 substream.
+type EAC3StreamType uint8
+
+// stream types.
+const (
+	EAC3StreamTypeIndependent EAC3StreamType = 0
+	EAC3StreamTypeDependent   EAC3StreamType = 1
+	EAC3StreamTypeAC3Convert  EAC3StreamType = 2
+)
+
+// channel locations of the chanmap field.
+// Specification: ETSI TS 102 366, Table E.1.4
+const (
+	ChanmapL      = 1 << 15
+	ChanmapC      = 1 << 14
+	ChanmapR      = 1 << 13
+	ChanmapLs     = 1 << 12
+	ChanmapRs     = 1 << 11
+	ChanmapLcRc   = 1 << 10
+	ChanmapLrsRrs = 1 << 9
+	ChanmapCs     = 1 << 8
+	ChanmapTs     = 1 << 7
+	ChanmapLsdRsd = 1 << 6
+	ChanmapLwRw   = 1 << 5
+	ChanmapVhlVhr = 1 << 4
+	ChanmapVhc    = 1 << 3
+	ChanmapLtsRts = 1 << 2
+	ChanmapLFE2   = 1 << 1
+	ChanmapLFE    = 1 << 0
+)
+
+// channel locations that contain a pair of channels.
+const chanmapPairs = ChanmapLcRc | ChanmapLrsRrs | ChanmapLsdRsd | ChanmapLwRw | ChanmapVhlVhr | ChanmapLtsRts
+
+// ChanmapChannelCount returns the number of channels described by a chanmap.
+func ChanmapChannelCount(chanmap uint16) int {
+	n := 0
+	for i := 0; i < 16; i++ {
+		if (chanmap & (1 << i)) != 0 {
+			if (chanmapPairs & (1 << i)) != 0 {
+				n += 2
+			} else {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// IsEAC3 checks whether a syncframe is an E-AC-3 syncframe.
+// The bsid field is located at the same position in AC-3 and E-AC-3 syncframes
+// and its value allows to distinguish between the two.
+func IsEAC3(frame []byte) bool {
+	if len(frame) < 6 || frame[0] != 0x0B || frame[1] != 0x77 {
+		return false
+	}
+
+	bsid := frame[5] >> 3
+	return bsid > 10 && bsid <= 16
+}
+
+// EAC3BSI is the Bit Stream Information of an E-AC-3 syncframe.
+// Specification: ETSI TS 102 366, Annex E.1.2
+type EAC3BSI struct {
+	Strmtyp     EAC3StreamType
+	Substreamid uint8
+	Frmsiz      uint16
+	Fscod       uint8
+	Fscod2      uint8
+	Numblkscod  uint8
+	Acmod       uint8
+	LfeOn       bool
+	Bsid        uint8
+	Dialnorm    uint8
+	Chanmape    bool
+	Chanmap     uint16
+	Bsmod       uint8
+}
+
+// Unmarshal decodes an EAC3BSI from a syncframe.
+// Decoding stops after the informational metadata.
+func (b *EAC3BSI) Unmarshal(frame []byte) error {
+	if len(frame) < 6 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if frame[0] != 0x0B || frame[1] != 0x77 {
+		return fmt.Errorf("invalid sync word")
+	}
+
+	buf := frame[2:]
+	pos := 0
+
+	b.Strmtyp = EAC3StreamType(bits.ReadBitsUnsafe(buf, &pos, 2))
+	if b.Strmtyp > EAC3StreamTypeAC3Convert {
+		return fmt.Errorf("invalid strmtyp")
+	}
+
+	b.Substreamid = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	b.Frmsiz = uint16(bits.ReadBitsUnsafe(buf, &pos, 11))
+
+	b.Fscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	if b.Fscod == 3 {
+		b.Fscod2 = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+		if b.Fscod2 == 3 {
+			return fmt.Errorf("invalid fscod2")
+		}
+		b.Numblkscod = 3
+	} else {
+		b.Fscod2 = 0
+		b.Numblkscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	}
+
+	b.Acmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	b.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
+
+	b.Bsid = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	if b.Bsid <= 10 || b.Bsid > 16 {
+		return fmt.Errorf("invalid bsid")
+	}
+
+	if len(frame) < b.FrameSize() {
+		return fmt.Errorf("not enough bits")
+	}
+
+	buf = frame[2:b.FrameSize()]
+
+	tmp, err := bits.ReadBits(buf, &pos, 5)
+	if err != nil {
+		return err
+	}
+	b.Dialnorm = uint8(tmp)
+
+	err = skipOptional(buf, &pos, 8) // compr
+	if err != nil {
+		return err
+	}
+
+	if b.Acmod == 0 {
+		_, err = bits.ReadBits(buf, &pos, 5) // dialnorm2
+		if err != nil {
+			return err
+		}
+
+		err = skipOptional(buf, &pos, 8) // compr2
+		if err != nil {
+			return err
+		}
+	}
+
+	b.Chanmape = false
+	b.Chanmap = 0
+
+	if b.Strmtyp == EAC3StreamTypeDependent {
+		b.Chanmape, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if b.Chanmape {
+			tmp, err = bits.ReadBits(buf, &pos, 16)
+			if err != nil {
+				return err
+			}
+			b.Chanmap = uint16(tmp)
+		}
+	}
+
+	err = b.skipMixmdat(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	return b.unmarshalInfomdat(buf, &pos)
+}
+
+func skipOptional(buf []byte, pos *int, n int) error {
+	e, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if e {
+		_, err = bits.ReadBits(buf, pos, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *EAC3BSI) skipMixmdat(buf []byte, pos *int) error {
+	mixmdate, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !mixmdate {
+		return nil
+	}
+
+	n := 0
+	if b.Acmod > 0x2 {
+		n += 2 // dmixmod
+	}
+	if ((b.Acmod & 0x1) != 0) && (b.Acmod > 0x2) {
+		n += 6 // ltrtcmixlev, lorocmixlev
+	}
+	if (b.Acmod & 0x4) != 0 {
+		n += 6 // ltrtsurmixlev, lorosurmixlev
+	}
+
+	if n > 0 {
+		_, err = bits.ReadBits(buf, pos, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.LfeOn {
+		err = skipOptional(buf, pos, 5) // lfemixlevcod
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.Strmtyp != EAC3StreamTypeIndependent {
+		return nil
+	}
+
+	err = skipOptional(buf, pos, 6) // pgmscl
+	if err != nil {
+		return err
+	}
+
+	if b.Acmod == 0 {
+		err = skipOptional(buf, pos, 6) // pgmscl2
+		if err != nil {
+			return err
+		}
+	}
+
+	err = skipOptional(buf, pos, 6) // extpgmscl
+	if err != nil {
+		return err
+	}
+
+	mixdef, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	switch mixdef {
+	case 1:
+		_, err = bits.ReadBits(buf, pos, 5) // premixcmpsel, drcsrc, premixcmpscl
+		if err != nil {
+			return err
+		}
+
+	case 2:
+		_, err = bits.ReadBits(buf, pos, 12) // mixdata
+		if err != nil {
+			return err
+		}
+
+	case 3:
+		var mixdeflen uint64
+		mixdeflen, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, *pos, 8*(int(mixdeflen)+2))
+		if err != nil {
+			return err
+		}
+		*pos += 8 * (int(mixdeflen) + 2) // mixdata
+	}
+
+	if b.Acmod < 0x2 {
+		err = skipOptional(buf, pos, 14) // panmean, paninfo
+		if err != nil {
+			return err
+		}
+
+		if b.Acmod == 0 {
+			err = skipOptional(buf, pos, 14) // panmean2, paninfo2
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	frmmixcfginfoe, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if frmmixcfginfoe {
+		if b.Numblkscod == 0 {
+			_, err = bits.ReadBits(buf, pos, 5) // blkmixcfginfo
+			if err != nil {
+				return err
+			}
+		} else {
+			for i := 0; i < b.BlockCount(); i++ {
+				err = skipOptional(buf, pos, 5) // blkmixcfginfo
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *EAC3BSI) unmarshalInfomdat(buf []byte, pos *int) error {
+	infomdate, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !infomdate {
+		b.Bsmod = 0
+		return nil
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+	b.Bsmod = uint8(tmp)
+
+	return nil
+}
+
+// FrameSize returns the syncframe size.
+func (b EAC3BSI) FrameSize() int {
+	return (int(b.Frmsiz) + 1) * 2
+}
+
+// SampleRate returns the syncframe sample rate.
+func (b EAC3BSI) SampleRate() int {
+	if b.Fscod == 3 {
+		switch b.Fscod2 {
+		case 0:
+			return 24000
+		case 1:
+			return 22050
+		default:
+			return 16000
+		}
+	}
+
+	switch b.Fscod {
+	case 0:
+		return 48000
+	case 1:
+		return 44100
+	default:
+		return 32000
+	}
+}
+
+// BlockCount returns the number of audio blocks contained inside the syncframe.
+func (b EAC3BSI) BlockCount() int {
+	switch b.Numblkscod {
+	case 0:
+		return 1
+	case 1:
+		return 2
+	case 2:
+		return 3
+	default:
+		return 6
+	}
+}
+
+// SampleCount returns the number of samples contained inside the syncframe.
+func (b EAC3BSI) SampleCount() int {
+	return b.BlockCount() * 256
+}
+
+// ChannelMap returns the channel locations of the substream.
+// When chanmap is not present, it is derived from acmod and lfeon.
+func (b EAC3BSI) ChannelMap() uint16 {
+	if b.Chanmape {
+		return b.Chanmap
+	}
+
+	var m uint16
+	switch b.Acmod {
+	case 0b000, 0b010:
+		m = ChanmapL | ChanmapR
+	case 0b001:
+		m = ChanmapC
+	case 0b011:
+		m = ChanmapL | ChanmapC | ChanmapR
+	case 0b100:
+		m = ChanmapL | ChanmapR | ChanmapCs
+	case 0b101:
+		m = ChanmapL | ChanmapC | ChanmapR | ChanmapCs
+	case 0b110:
+		m = ChanmapL | ChanmapR | ChanmapLs | ChanmapRs
+	default:
+		m = ChanmapL | ChanmapC | ChanmapR | ChanmapLs | ChanmapRs
+	}
+
+	if b.LfeOn {
+		m |= ChanmapLFE
+	}
+
+	return m
+}
+
+// ChannelCount returns the channel count of the substream.
+func (b EAC3BSI) ChannelCount() int {
+	return ChanmapChannelCount(b.ChannelMap())
+}
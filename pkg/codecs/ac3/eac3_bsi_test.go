@@ -0,0 +1,115 @@
+package ac3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var eac3Cases = []struct {
+	name         string
+	enc          []byte
+	bsi          EAC3BSI
+	sampleRate   int
+	sampleCount  int
+	channelCount int
+}{
+	{
+		"independent 5.1",
+		append([]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x87, 0xc8, 0x00,
+		}, bytes.Repeat([]byte{0x00}, 24)...),
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeIndependent,
+			Frmsiz:     15,
+			Numblkscod: 3,
+			Acmod:      7,
+			LfeOn:      true,
+			Bsid:       16,
+			Dialnorm:   31,
+		},
+		48000,
+		1536,
+		6,
+	},
+	{
+		"dependent with chanmap",
+		append([]byte{
+			0x0b, 0x77, 0x40, 0x0f, 0x3c, 0x87, 0xd1, 0xa0,
+		}, bytes.Repeat([]byte{0x00}, 24)...),
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeDependent,
+			Frmsiz:     15,
+			Numblkscod: 3,
+			Acmod:      6,
+			Bsid:       16,
+			Dialnorm:   31,
+			Chanmape:   true,
+			Chanmap:    ChanmapLs | ChanmapRs | ChanmapLrsRrs,
+		},
+		48000,
+		1536,
+		4,
+	},
+	{
+		"22050 mono with mixing metadata",
+		append([]byte{
+			0x0b, 0x77, 0x00, 0x17, 0xd2, 0x86, 0xea, 0xbd,
+			0x4c, 0x15, 0x79, 0xb4, 0x8d, 0x31, 0xa4, 0x14,
+		}, bytes.Repeat([]byte{0x00}, 32)...),
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeIndependent,
+			Frmsiz:     23,
+			Fscod:      3,
+			Fscod2:     1,
+			Numblkscod: 3,
+			Acmod:      1,
+			Bsid:       16,
+			Dialnorm:   27,
+			Bsmod:      2,
+		},
+		22050,
+		1536,
+		1,
+	},
+}
+
+func TestEAC3BSIUnmarshal(t *testing.T) {
+	for _, ca := range eac3Cases {
+		t.Run(ca.name, func(t *testing.T) {
+			require.True(t, IsEAC3(ca.enc))
+
+			var bsi EAC3BSI
+			err := bsi.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.bsi, bsi)
+			require.Equal(t, len(ca.enc), bsi.FrameSize())
+			require.Equal(t, ca.sampleRate, bsi.SampleRate())
+			require.Equal(t, ca.sampleCount, bsi.SampleCount())
+			require.Equal(t, ca.channelCount, bsi.ChannelCount())
+		})
+	}
+}
+
+func TestIsEAC3(t *testing.T) {
+	for _, ca := range ac3Cases {
+		require.False(t, IsEAC3(ca.enc))
+	}
+}
+
+func FuzzEAC3BSIUnmarshal(f *testing.F) {
+	for _, ca := range eac3Cases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var bsi EAC3BSI
+		err := bsi.Unmarshal(b)
+		if err == nil {
+			bsi.SampleRate()
+			bsi.SampleCount()
+			bsi.ChannelCount()
+		}
+	})
+}
@@ -70,8 +70,10 @@ var ac3Cases = []struct {
 		},
 		48000,
 		BSI{
-			Bsid:  8,
-			Acmod: 1,
+			Bsid:     8,
+			Acmod:    1,
+			Dialnorm: 31,
+			Origbs:   true,
 		},
 	},
 	{
@@ -307,9 +309,13 @@ var ac3Cases = []struct {
 		},
 		48000,
 		BSI{
-			Bsid:  8,
-			Acmod: 7,
-			LfeOn: true,
+			Bsid:      8,
+			Acmod:     7,
+			Cmixlev:   1,
+			Surmixlev: 1,
+			LfeOn:     true,
+			Dialnorm:  31,
+			Origbs:    true,
 		},
 	},
 }
@@ -0,0 +1,16 @@
+// Package amr contains utilities to work with the AMR-NB and AMR-WB codecs.
+package amr
+
+const (
+	// SampleRateNarrowband is the sample rate of AMR-NB.
+	SampleRateNarrowband = 8000
+
+	// SampleRateWideband is the sample rate of AMR-WB.
+	SampleRateWideband = 16000
+
+	// SamplesPerFrameNarrowband is the number of samples contained in a AMR-NB frame.
+	SamplesPerFrameNarrowband = 160
+
+	// SamplesPerFrameWideband is the number of samples contained in a AMR-WB frame.
+	SamplesPerFrameWideband = 320
+)
@@ -0,0 +1,116 @@
+package amr
+
+import (
+	"fmt"
+)
+
+// FrameType is the type of a frame.
+// Specification: RFC 4867, 4.3.2
+type FrameType uint8
+
+// frame types.
+const (
+	FrameTypeSIDNarrowband FrameType = 8
+	FrameTypeSIDWideband   FrameType = 9
+	FrameTypeSpeechLost    FrameType = 14
+	FrameTypeNoData        FrameType = 15
+)
+
+// number of speech bits of each frame type.
+// Specification: 3GPP TS 26.101, Table 1a
+var frameBitsNarrowband = []int{
+	95,
+	103,
+	118,
+	134,
+	148,
+	159,
+	204,
+	244,
+	39,
+}
+
+// number of speech bits of each frame type.
+// Specification: 3GPP TS 26.201, Table 1a
+var frameBitsWideband = []int{
+	132,
+	177,
+	253,
+	285,
+	317,
+	365,
+	397,
+	461,
+	477,
+	40,
+}
+
+// bit rates of speech frame types.
+var bitRatesNarrowband = []int{
+	4750,
+	5150,
+	5900,
+	6700,
+	7400,
+	7950,
+	10200,
+	12200,
+}
+
+// bit rates of speech frame types.
+var bitRatesWideband = []int{
+	6600,
+	8850,
+	12650,
+	14250,
+	15850,
+	18250,
+	19850,
+	23050,
+	23850,
+}
+
+// FrameBits returns the number of speech bits of a frame.
+func FrameBits(wideband bool, typ FrameType) (int, error) {
+	if wideband {
+		switch {
+		case int(typ) < len(frameBitsWideband):
+			return frameBitsWideband[typ], nil
+
+		case typ == FrameTypeSpeechLost || typ == FrameTypeNoData:
+			return 0, nil
+		}
+	} else {
+		switch {
+		case int(typ) < len(frameBitsNarrowband):
+			return frameBitsNarrowband[typ], nil
+
+		case typ == FrameTypeNoData:
+			return 0, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid frame type: %d", typ)
+}
+
+// FrameSize returns the size in bytes of the speech data of an octet-aligned frame.
+func FrameSize(wideband bool, typ FrameType) (int, error) {
+	n, err := FrameBits(wideband, typ)
+	if err != nil {
+		return 0, err
+	}
+	return (n + 7) / 8, nil
+}
+
+// BitRate returns the bit rate of a speech frame type.
+func BitRate(wideband bool, typ FrameType) (int, error) {
+	if wideband {
+		if int(typ) < len(bitRatesWideband) {
+			return bitRatesWideband[typ], nil
+		}
+	} else if int(typ) < len(bitRatesNarrowband) {
+		return bitRatesNarrowband[typ], nil
+	}
+
+	return 0, fmt.Errorf("frame type %d is not a speech frame type", typ)
+}
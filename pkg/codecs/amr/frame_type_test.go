@@ -0,0 +1,95 @@
+package amr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameSize(t *testing.T) {
+	for _, ca := range []struct {
+		name     string
+		wideband bool
+		typ      FrameType
+		size     int
+	}{
+		{
+			"narrowband 4.75",
+			false,
+			0,
+			12,
+		},
+		{
+			"narrowband 12.2",
+			false,
+			7,
+			31,
+		},
+		{
+			"narrowband sid",
+			false,
+			FrameTypeSIDNarrowband,
+			5,
+		},
+		{
+			"narrowband no data",
+			false,
+			FrameTypeNoData,
+			0,
+		},
+		{
+			"wideband 6.60",
+			true,
+			0,
+			17,
+		},
+		{
+			"wideband 23.85",
+			true,
+			8,
+			60,
+		},
+		{
+			"wideband sid",
+			true,
+			FrameTypeSIDWideband,
+			5,
+		},
+		{
+			"wideband speech lost",
+			true,
+			FrameTypeSpeechLost,
+			0,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			size, err := FrameSize(ca.wideband, ca.typ)
+			require.NoError(t, err)
+			require.Equal(t, ca.size, size)
+		})
+	}
+}
+
+func TestFrameSizeInvalid(t *testing.T) {
+	_, err := FrameSize(false, 9)
+	require.EqualError(t, err, "invalid frame type: 9")
+
+	_, err = FrameSize(false, FrameTypeSpeechLost)
+	require.EqualError(t, err, "invalid frame type: 14")
+
+	_, err = FrameSize(true, 10)
+	require.EqualError(t, err, "invalid frame type: 10")
+}
+
+func TestBitRate(t *testing.T) {
+	br, err := BitRate(false, 7)
+	require.NoError(t, err)
+	require.Equal(t, 12200, br)
+
+	br, err = BitRate(true, 2)
+	require.NoError(t, err)
+	require.Equal(t, 12650, br)
+
+	_, err = BitRate(false, FrameTypeSIDNarrowband)
+	require.EqualError(t, err, "frame type 8 is not a speech frame type")
+}
@@ -0,0 +1,124 @@
+package amr
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var (
+	magicNarrowband             = []byte("#!AMR\n")
+	magicWideband               = []byte("#!AMR-WB\n")
+	magicNarrowbandMultichannel = []byte("#!AMR_MC1.0\n")
+	magicWidebandMultichannel   = []byte("#!AMR-WB_MC1.0\n")
+)
+
+// StorageFrame is a frame of the storage format.
+type StorageFrame struct {
+	Type FrameType
+	// frame quality indicator.
+	Quality bool
+	// octet-aligned speech data.
+	Payload []byte
+}
+
+// Storage is a single-channel stream in the storage format.
+// Specification: RFC 4867, 5
+type Storage struct {
+	Wideband bool
+	Frames   []*StorageFrame
+}
+
+// Unmarshal decodes a Storage.
+func (s *Storage) Unmarshal(buf []byte) error {
+	var pos int
+
+	switch {
+	case bytes.HasPrefix(buf, magicNarrowband):
+		s.Wideband = false
+		pos = len(magicNarrowband)
+
+	case bytes.HasPrefix(buf, magicWideband):
+		s.Wideband = true
+		pos = len(magicWideband)
+
+	case bytes.HasPrefix(buf, magicNarrowbandMultichannel),
+		bytes.HasPrefix(buf, magicWidebandMultichannel):
+		return fmt.Errorf("multichannel storage format is not supported")
+
+	default:
+		return fmt.Errorf("invalid magic number")
+	}
+
+	s.Frames = nil
+
+	for pos < len(buf) {
+		header := buf[pos]
+		pos++
+
+		if (header & 0x83) != 0 {
+			return fmt.Errorf("invalid padding bits")
+		}
+
+		typ := FrameType(header >> 3)
+
+		size, err := FrameSize(s.Wideband, typ)
+		if err != nil {
+			return err
+		}
+
+		if len(buf[pos:]) < size {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		s.Frames = append(s.Frames, &StorageFrame{
+			Type:    typ,
+			Quality: (header & 0x04) != 0,
+			Payload: buf[pos : pos+size],
+		})
+		pos += size
+	}
+
+	return nil
+}
+
+func (s Storage) magic() []byte {
+	if s.Wideband {
+		return magicWideband
+	}
+	return magicNarrowband
+}
+
+func (s Storage) marshalSize() int {
+	n := len(s.magic())
+	for _, fr := range s.Frames {
+		n += 1 + len(fr.Payload)
+	}
+	return n
+}
+
+// Marshal encodes a Storage.
+func (s Storage) Marshal() ([]byte, error) {
+	buf := make([]byte, s.marshalSize())
+	n := copy(buf, s.magic())
+
+	for _, fr := range s.Frames {
+		size, err := FrameSize(s.Wideband, fr.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fr.Payload) != size {
+			return nil, fmt.Errorf("invalid payload size: %d, expected %d", len(fr.Payload), size)
+		}
+
+		buf[n] = byte(fr.Type) << 3
+		if fr.Quality {
+			buf[n] |= 0x04
+		}
+		n++
+
+		n += copy(buf[n:], fr.Payload)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,141 @@
+package amr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStorage = []struct {
+	name string
+	enc  []byte
+	dec  Storage
+}{
+	{
+		"narrowband",
+		append(append([]byte("#!AMR\n"),
+			append([]byte{0x3c}, bytes.Repeat([]byte{0x11}, 31)...)...),
+			0x44, 0x01, 0x02, 0x03, 0x04, 0x05,
+			0x7c,
+		),
+		Storage{
+			Frames: []*StorageFrame{
+				{
+					Type:    7,
+					Quality: true,
+					Payload: bytes.Repeat([]byte{0x11}, 31),
+				},
+				{
+					Type:    FrameTypeSIDNarrowband,
+					Quality: true,
+					Payload: []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+				},
+				{
+					Type:    FrameTypeNoData,
+					Quality: true,
+					Payload: []byte{},
+				},
+			},
+		},
+	},
+	{
+		"wideband",
+		append(append([]byte("#!AMR-WB\n"),
+			append([]byte{0x14}, bytes.Repeat([]byte{0x22}, 32)...)...),
+			0x70,
+		),
+		Storage{
+			Wideband: true,
+			Frames: []*StorageFrame{
+				{
+					Type:    2,
+					Quality: true,
+					Payload: bytes.Repeat([]byte{0x22}, 32),
+				},
+				{
+					Type:    FrameTypeSpeechLost,
+					Payload: []byte{},
+				},
+			},
+		},
+	},
+}
+
+func TestStorageUnmarshal(t *testing.T) {
+	for _, ca := range casesStorage {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Storage
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestStorageMarshal(t *testing.T) {
+	for _, ca := range casesStorage {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestStorageUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"invalid magic",
+			[]byte("#!AMR-XX\n"),
+			"invalid magic number",
+		},
+		{
+			"multichannel",
+			[]byte("#!AMR_MC1.0\n\x00\x00\x00\x01"),
+			"multichannel storage format is not supported",
+		},
+		{
+			"invalid padding",
+			[]byte("#!AMR\n\xfc"),
+			"invalid padding bits",
+		},
+		{
+			"invalid frame type",
+			[]byte("#!AMR\n\x4c"),
+			"invalid frame type: 9",
+		},
+		{
+			"truncated frame",
+			[]byte("#!AMR\n\x3c\x00\x00"),
+			"not enough bytes",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Storage
+			err := dec.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzStorageUnmarshal(f *testing.F) {
+	for _, ca := range casesStorage {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var dec Storage
+		err := dec.Unmarshal(b)
+		if err == nil {
+			var enc []byte
+			enc, err = dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, b, enc)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package flac
+
+import (
+	"fmt"
+)
+
+// crc8Update computes a CRC-8 with polynomial 0x07.
+// Specification: RFC 9639, 9.1.8
+func crc8Update(crc uint8, buf []byte) uint8 {
+	for _, b := range buf {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if (crc & 0x80) != 0 {
+				crc = (crc << 1) ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// crc16Update computes a CRC-16 with polynomial 0x8005.
+// Specification: RFC 9639, 9.3
+func crc16Update(crc uint16, buf []byte) uint16 {
+	for _, b := range buf {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if (crc & 0x8000) != 0 {
+				crc = (crc << 1) ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// VerifyCRC checks the CRCs of a frame.
+// The CRC-8 protects the frame header, the CRC-16 protects the whole frame.
+func VerifyCRC(frame []byte) error {
+	var h FrameHeader
+	err := h.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	if crc16Update(0, frame) != 0 {
+		return fmt.Errorf("crc-16 mismatch")
+	}
+
+	return nil
+}
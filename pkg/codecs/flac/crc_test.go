@@ -0,0 +1,40 @@
+package flac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFrame = []byte{
+	0xff, 0xf8, 0x19, 0x02, 0x00, 0x38, 0x00, 0x55,
+	0xbf, 0xd5,
+}
+
+func TestCRC(t *testing.T) {
+	require.Equal(t, uint8(0xf4), crc8Update(0, []byte("123456789")))
+	require.Equal(t, uint16(0xfee8), crc16Update(0, []byte("123456789")))
+}
+
+func TestVerifyCRC(t *testing.T) {
+	err := VerifyCRC(testFrame)
+	require.NoError(t, err)
+
+	frame := append([]byte(nil), testFrame...)
+	frame[3] ^= 0x10
+	err = VerifyCRC(frame)
+	require.EqualError(t, err, "crc-8 mismatch")
+
+	frame = append([]byte(nil), testFrame...)
+	frame[7] ^= 0x01
+	err = VerifyCRC(frame)
+	require.EqualError(t, err, "crc-16 mismatch")
+}
+
+func FuzzVerifyCRC(f *testing.F) {
+	f.Add(testFrame)
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		VerifyCRC(b) //nolint:errcheck
+	})
+}
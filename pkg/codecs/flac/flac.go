@@ -0,0 +1,22 @@
+// Package flac contains utilities to work with the FLAC codec.
+package flac
+
+const (
+	// MaxBlockSize is the maximum size of a block, in samples.
+	MaxBlockSize = 65535
+)
+
+// MetadataBlockType is the type of a metadata block.
+// Specification: RFC 9639, 8.1
+type MetadataBlockType uint8
+
+// metadata block types.
+const (
+	MetadataBlockTypeStreamInfo    MetadataBlockType = 0
+	MetadataBlockTypePadding       MetadataBlockType = 1
+	MetadataBlockTypeApplication   MetadataBlockType = 2
+	MetadataBlockTypeSeekTable     MetadataBlockType = 3
+	MetadataBlockTypeVorbisComment MetadataBlockType = 4
+	MetadataBlockTypeCueSheet      MetadataBlockType = 5
+	MetadataBlockTypePicture       MetadataBlockType = 6
+)
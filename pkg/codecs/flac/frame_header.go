@@ -0,0 +1,243 @@
+package flac
+
+import (
+	"fmt"
+)
+
+// ChannelAssignment is the channel assignment of a frame.
+type ChannelAssignment uint8
+
+// channel assignments.
+const (
+	ChannelAssignmentLeftSide  ChannelAssignment = 8
+	ChannelAssignmentSideRight ChannelAssignment = 9
+	ChannelAssignmentMidSide   ChannelAssignment = 10
+)
+
+// ChannelCount returns the channel count of a channel assignment.
+func (a ChannelAssignment) ChannelCount() int {
+	if a < ChannelAssignmentLeftSide {
+		return int(a) + 1
+	}
+	return 2
+}
+
+// RFC 9639, 9.1.2
+var sampleRates = []int{
+	0,
+	88200,
+	176400,
+	192000,
+	8000,
+	16000,
+	22050,
+	24000,
+	32000,
+	44100,
+	48000,
+	96000,
+}
+
+// RFC 9639, 9.1.4
+var bitsPerSamples = []int{
+	0,
+	8,
+	12,
+	0,
+	16,
+	20,
+	24,
+	32,
+}
+
+func readCodedNumber(buf []byte, pos *int) (uint64, error) {
+	if len(buf) <= *pos {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	first := buf[*pos]
+	*pos++
+
+	var n int
+	var v uint64
+
+	switch {
+	case (first & 0x80) == 0:
+		return uint64(first), nil
+
+	case (first & 0xE0) == 0xC0:
+		n = 1
+		v = uint64(first & 0x1F)
+
+	case (first & 0xF0) == 0xE0:
+		n = 2
+		v = uint64(first & 0x0F)
+
+	case (first & 0xF8) == 0xF0:
+		n = 3
+		v = uint64(first & 0x07)
+
+	case (first & 0xFC) == 0xF8:
+		n = 4
+		v = uint64(first & 0x03)
+
+	case (first & 0xFE) == 0xFC:
+		n = 5
+		v = uint64(first & 0x01)
+
+	case first == 0xFE:
+		n = 6
+
+	default:
+		return 0, fmt.Errorf("invalid coded number")
+	}
+
+	if len(buf) < (*pos + n) {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	for i := 0; i < n; i++ {
+		b := buf[*pos]
+		*pos++
+
+		if (b & 0xC0) != 0x80 {
+			return 0, fmt.Errorf("invalid coded number")
+		}
+
+		v = v<<6 | uint64(b&0x3F)
+	}
+
+	return v, nil
+}
+
+// FrameHeader is a frame header.
+// Specification: RFC 9639, 9.1
+type FrameHeader struct {
+	VariableBlockSize bool
+	BlockSize         int
+	// if zero, it must be taken from STREAMINFO.
+	SampleRate        int
+	ChannelAssignment ChannelAssignment
+	// if zero, it must be taken from STREAMINFO.
+	BitsPerSample int
+	// frame number in case of fixed block size, sample number otherwise.
+	Number uint64
+}
+
+// Unmarshal decodes a FrameHeader.
+func (h *FrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 0xFF || (buf[1]&0xFE) != 0xF8 {
+		return fmt.Errorf("invalid sync code")
+	}
+
+	h.VariableBlockSize = (buf[1] & 0x01) != 0
+
+	blockSizeCode := buf[2] >> 4
+	sampleRateCode := buf[2] & 0x0F
+
+	h.ChannelAssignment = ChannelAssignment(buf[3] >> 4)
+	if h.ChannelAssignment > ChannelAssignmentMidSide {
+		return fmt.Errorf("invalid channel assignment: %d", h.ChannelAssignment)
+	}
+
+	bitsPerSampleCode := (buf[3] >> 1) & 0x07
+	if bitsPerSampleCode == 3 {
+		return fmt.Errorf("invalid bits per sample code")
+	}
+	h.BitsPerSample = bitsPerSamples[bitsPerSampleCode]
+
+	if (buf[3] & 0x01) != 0 {
+		return fmt.Errorf("invalid reserved bit")
+	}
+
+	pos := 4
+
+	var err error
+	h.Number, err = readCodedNumber(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if !h.VariableBlockSize && h.Number >= (1<<31) {
+		return fmt.Errorf("invalid frame number")
+	}
+
+	switch {
+	case blockSizeCode == 0:
+		return fmt.Errorf("invalid block size code")
+
+	case blockSizeCode == 1:
+		h.BlockSize = 192
+
+	case blockSizeCode <= 5:
+		h.BlockSize = 144 << blockSizeCode
+
+	case blockSizeCode == 6:
+		if len(buf) < (pos + 1) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.BlockSize = int(buf[pos]) + 1
+		pos++
+
+	case blockSizeCode == 7:
+		if len(buf) < (pos + 2) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.BlockSize = (int(buf[pos])<<8 | int(buf[pos+1])) + 1
+		if h.BlockSize > MaxBlockSize {
+			return fmt.Errorf("invalid block size: %d", h.BlockSize)
+		}
+		pos += 2
+
+	default:
+		h.BlockSize = 1 << blockSizeCode
+	}
+
+	switch {
+	case sampleRateCode <= 11:
+		h.SampleRate = sampleRates[sampleRateCode]
+
+	case sampleRateCode == 12:
+		if len(buf) < (pos + 1) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = int(buf[pos]) * 1000
+		pos++
+
+	case sampleRateCode == 13:
+		if len(buf) < (pos + 2) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = int(buf[pos])<<8 | int(buf[pos+1])
+		pos += 2
+
+	case sampleRateCode == 14:
+		if len(buf) < (pos + 2) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = (int(buf[pos])<<8 | int(buf[pos+1])) * 10
+		pos += 2
+
+	default:
+		return fmt.Errorf("invalid sample rate code")
+	}
+
+	if len(buf) < (pos + 1) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if crc8Update(0, buf[:pos+1]) != 0 {
+		return fmt.Errorf("crc-8 mismatch")
+	}
+
+	return nil
+}
+
+// ChannelCount returns the channel count.
+func (h FrameHeader) ChannelCount() int {
+	return h.ChannelAssignment.ChannelCount()
+}
@@ -0,0 +1,91 @@
+package flac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesFrameHeader = []struct {
+	name string
+	enc  []byte
+	dec  FrameHeader
+}{
+	{
+		"fixed block size",
+		[]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2},
+		FrameHeader{
+			BlockSize:         4096,
+			SampleRate:        44100,
+			ChannelAssignment: 1,
+			BitsPerSample:     16,
+		},
+	},
+	{
+		"variable block size, 8-bit block size, sample rate in kHz",
+		[]byte{
+			0xff, 0xf9, 0x6c, 0xac, 0xf3, 0xb4, 0x89, 0x80,
+			0x63, 0x16, 0xdf,
+		},
+		FrameHeader{
+			VariableBlockSize: true,
+			BlockSize:         100,
+			SampleRate:        22000,
+			ChannelAssignment: ChannelAssignmentMidSide,
+			BitsPerSample:     24,
+			Number:            1000000,
+		},
+	},
+	{
+		"16-bit block size, sample rate in Hz",
+		[]byte{
+			0xff, 0xf8, 0x7d, 0x80, 0xf0, 0x91, 0x85, 0xb0,
+			0x13, 0x87, 0x2b, 0x11, 0x8e,
+		},
+		FrameHeader{
+			BlockSize:         5000,
+			SampleRate:        11025,
+			ChannelAssignment: ChannelAssignmentLeftSide,
+			Number:            70000,
+		},
+	},
+	{
+		"sample rate in tens of Hz",
+		[]byte{0xff, 0xf8, 0x1e, 0x5a, 0x05, 0x04, 0xd2, 0x90},
+		FrameHeader{
+			BlockSize:         192,
+			SampleRate:        12340,
+			ChannelAssignment: 5,
+			BitsPerSample:     20,
+			Number:            5,
+		},
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesFrameHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec FrameHeader
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestFrameHeaderChannelCount(t *testing.T) {
+	require.Equal(t, 2, casesFrameHeader[0].dec.ChannelCount())
+	require.Equal(t, 2, casesFrameHeader[1].dec.ChannelCount())
+	require.Equal(t, 6, casesFrameHeader[3].dec.ChannelCount())
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesFrameHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec FrameHeader
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
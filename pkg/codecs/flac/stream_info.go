@@ -0,0 +1,109 @@
+package flac
+
+import (
+	"fmt"
+)
+
+const (
+	// StreamInfoSize is the size of a STREAMINFO metadata block, without the block header.
+	StreamInfoSize = 34
+)
+
+// StreamInfo is a STREAMINFO metadata block.
+// Specification: RFC 9639, 8.2
+type StreamInfo struct {
+	MinimumBlockSize uint16
+	MaximumBlockSize uint16
+	MinimumFrameSize uint32
+	MaximumFrameSize uint32
+	SampleRate       int
+	ChannelCount     int
+	BitsPerSample    int
+	TotalSamples     uint64
+	MD5              [16]byte
+}
+
+// Unmarshal decodes a StreamInfo.
+func (s *StreamInfo) Unmarshal(buf []byte) error {
+	if len(buf) < StreamInfoSize {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	s.MinimumBlockSize = uint16(buf[0])<<8 | uint16(buf[1])
+	s.MaximumBlockSize = uint16(buf[2])<<8 | uint16(buf[3])
+
+	if s.MinimumBlockSize < 16 {
+		return fmt.Errorf("invalid minimum block size: %d", s.MinimumBlockSize)
+	}
+
+	if s.MaximumBlockSize < s.MinimumBlockSize {
+		return fmt.Errorf("invalid maximum block size: %d", s.MaximumBlockSize)
+	}
+
+	s.MinimumFrameSize = uint32(buf[4])<<16 | uint32(buf[5])<<8 | uint32(buf[6])
+	s.MaximumFrameSize = uint32(buf[7])<<16 | uint32(buf[8])<<8 | uint32(buf[9])
+	s.SampleRate = int(buf[10])<<12 | int(buf[11])<<4 | int(buf[12]>>4)
+	s.ChannelCount = int((buf[12]>>1)&0x07) + 1
+	s.BitsPerSample = int((buf[12]&0x01)<<4|(buf[13]>>4)) + 1
+
+	if s.BitsPerSample < 4 {
+		return fmt.Errorf("invalid bits per sample: %d", s.BitsPerSample)
+	}
+
+	s.TotalSamples = uint64(buf[13]&0x0F)<<32 | uint64(buf[14])<<24 | uint64(buf[15])<<16 |
+		uint64(buf[16])<<8 | uint64(buf[17])
+	copy(s.MD5[:], buf[18:34])
+
+	return nil
+}
+
+// Marshal encodes a StreamInfo.
+func (s StreamInfo) Marshal() ([]byte, error) {
+	if s.MinimumBlockSize < 16 || s.MaximumBlockSize < s.MinimumBlockSize {
+		return nil, fmt.Errorf("invalid block size")
+	}
+
+	if s.MinimumFrameSize >= (1<<24) || s.MaximumFrameSize >= (1<<24) {
+		return nil, fmt.Errorf("invalid frame size")
+	}
+
+	if s.SampleRate < 0 || s.SampleRate >= (1<<20) {
+		return nil, fmt.Errorf("invalid sample rate: %d", s.SampleRate)
+	}
+
+	if s.ChannelCount < 1 || s.ChannelCount > 8 {
+		return nil, fmt.Errorf("invalid channel count: %d", s.ChannelCount)
+	}
+
+	if s.BitsPerSample < 4 || s.BitsPerSample > 32 {
+		return nil, fmt.Errorf("invalid bits per sample: %d", s.BitsPerSample)
+	}
+
+	if s.TotalSamples >= (1 << 36) {
+		return nil, fmt.Errorf("invalid total samples")
+	}
+
+	buf := make([]byte, StreamInfoSize)
+
+	buf[0] = byte(s.MinimumBlockSize >> 8)
+	buf[1] = byte(s.MinimumBlockSize)
+	buf[2] = byte(s.MaximumBlockSize >> 8)
+	buf[3] = byte(s.MaximumBlockSize)
+	buf[4] = byte(s.MinimumFrameSize >> 16)
+	buf[5] = byte(s.MinimumFrameSize >> 8)
+	buf[6] = byte(s.MinimumFrameSize)
+	buf[7] = byte(s.MaximumFrameSize >> 16)
+	buf[8] = byte(s.MaximumFrameSize >> 8)
+	buf[9] = byte(s.MaximumFrameSize)
+	buf[10] = byte(s.SampleRate >> 12)
+	buf[11] = byte(s.SampleRate >> 4)
+	buf[12] = byte(s.SampleRate<<4) | byte(s.ChannelCount-1)<<1 | byte(s.BitsPerSample-1)>>4
+	buf[13] = byte(s.BitsPerSample-1)<<4 | byte(s.TotalSamples>>32)
+	buf[14] = byte(s.TotalSamples >> 24)
+	buf[15] = byte(s.TotalSamples >> 16)
+	buf[16] = byte(s.TotalSamples >> 8)
+	buf[17] = byte(s.TotalSamples)
+	copy(buf[18:], s.MD5[:])
+
+	return buf, nil
+}
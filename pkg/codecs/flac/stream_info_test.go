@@ -0,0 +1,93 @@
+package flac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStreamInfo = []struct {
+	name string
+	enc  []byte
+	dec  StreamInfo
+}{
+	{
+		"16 bit stereo",
+		[]byte{
+			0x10, 0x00, 0x10, 0x00, 0x00, 0x00, 0x0e, 0x00,
+			0x20, 0x00, 0x0a, 0xc4, 0x42, 0xf0, 0x00, 0x06,
+			0xba, 0xa8, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05,
+			0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d,
+			0x0e, 0x0f,
+		},
+		StreamInfo{
+			MinimumBlockSize: 4096,
+			MaximumBlockSize: 4096,
+			MinimumFrameSize: 14,
+			MaximumFrameSize: 8192,
+			SampleRate:       44100,
+			ChannelCount:     2,
+			BitsPerSample:    16,
+			TotalSamples:     441000,
+			MD5: [16]byte{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+				0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+			},
+		},
+	},
+	{
+		"24 bit 8 channels",
+		[]byte{
+			0x00, 0x10, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x2e, 0xe0, 0x0f, 0x70, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		StreamInfo{
+			MinimumBlockSize: 16,
+			MaximumBlockSize: 65535,
+			SampleRate:       192000,
+			ChannelCount:     8,
+			BitsPerSample:    24,
+		},
+	},
+}
+
+func TestStreamInfoUnmarshal(t *testing.T) {
+	for _, ca := range casesStreamInfo {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec StreamInfo
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestStreamInfoMarshal(t *testing.T) {
+	for _, ca := range casesStreamInfo {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzStreamInfoUnmarshal(f *testing.F) {
+	for _, ca := range casesStreamInfo {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var dec StreamInfo
+		err := dec.Unmarshal(b)
+		if err == nil {
+			var enc []byte
+			enc, err = dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, b[:StreamInfoSize], enc)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package g711
+
+var mulawSegmentEnds = [8]int{0x3F, 0x7F, 0xFF, 0x1FF, 0x3FF, 0x7FF, 0xFFF, 0x1FFF}
+
+var alawSegmentEnds = [8]int{0x1F, 0x3F, 0x7F, 0xFF, 0x1FF, 0x3FF, 0x7FF, 0xFFF}
+
+func findSegment(v int, ends *[8]int) int {
+	for i, end := range ends {
+		if v <= end {
+			return i
+		}
+	}
+	return 8
+}
+
+func encodeMulawSample(sample int16) uint8 {
+	v := int(sample) >> 2
+
+	var mask int
+	if v < 0 {
+		v = -v
+		mask = 0x7F
+	} else {
+		mask = 0xFF
+	}
+
+	if v > 8159 {
+		v = 8159
+	}
+	v += 0x84 >> 2
+
+	seg := findSegment(v, &mulawSegmentEnds)
+	if seg >= 8 {
+		return uint8(0x7F ^ mask)
+	}
+
+	return uint8(((seg << 4) | ((v >> (seg + 1)) & 0x0F)) ^ mask)
+}
+
+func encodeAlawSample(sample int16) uint8 {
+	v := int(sample) >> 3
+
+	var mask int
+	if v >= 0 {
+		mask = 0xD5
+	} else {
+		mask = 0x55
+		v = -v - 1
+	}
+
+	seg := findSegment(v, &alawSegmentEnds)
+	if seg >= 8 {
+		return uint8(0x7F ^ mask)
+	}
+
+	ret := seg << 4
+	if seg < 2 {
+		ret |= (v >> 1) & 0x0F
+	} else {
+		ret |= (v >> seg) & 0x0F
+	}
+
+	return uint8(ret ^ mask)
+}
+
+// EncodeMulaw encodes 16-bit LPCM samples into 8-bit G711 samples (MU-law).
+// Input samples are big endian.
+func EncodeMulaw(in []byte) []byte {
+	out := make([]byte, len(in)/2)
+	for i := range out {
+		out[i] = encodeMulawSample(int16(uint16(in[i*2])<<8 | uint16(in[(i*2)+1])))
+	}
+	return out
+}
+
+// EncodeAlaw encodes 16-bit LPCM samples into 8-bit G711 samples (A-law).
+// Input samples are big endian.
+func EncodeAlaw(in []byte) []byte {
+	out := make([]byte, len(in)/2)
+	for i := range out {
+		out[i] = encodeAlawSample(int16(uint16(in[i*2])<<8 | uint16(in[(i*2)+1])))
+	}
+	return out
+}
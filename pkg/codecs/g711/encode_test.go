@@ -0,0 +1,48 @@
+package g711
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMuLaw(t *testing.T) {
+	require.Equal(t,
+		[]byte{1, 2, 3, 255, 254, 253},
+		EncodeMulaw([]byte{
+			0x86, 0x84, 0x8a, 0x84, 0x8e, 0x84, 0x00, 0x00,
+			0x00, 0x08, 0x00, 0x10,
+		}),
+	)
+}
+
+func TestEncodeALaw(t *testing.T) {
+	require.Equal(t,
+		[]byte{1, 2, 3, 0xd5, 254, 253},
+		EncodeAlaw([]byte{
+			0xeb, 0x80, 0xe8, 0x80, 0xe9, 0x80, 0x00, 0x00,
+			0x03, 0x70, 0x03, 0x10,
+		}),
+	)
+}
+
+func TestEncodeDecodeAllValues(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		dec := DecodeMulaw([]byte{byte(i)})
+		require.Equal(t, dec, DecodeMulaw(EncodeMulaw(dec)))
+
+		dec = DecodeAlaw([]byte{byte(i)})
+		require.Equal(t, dec, DecodeAlaw(EncodeAlaw(dec)))
+	}
+}
+
+func BenchmarkEncodeMulaw(b *testing.B) {
+	in := make([]byte, 2048)
+	for i := range in {
+		in[i] = byte(i)
+	}
+
+	for n := 0; n < b.N; n++ {
+		EncodeMulaw(in)
+	}
+}
@@ -0,0 +1,248 @@
+package h266
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+)
+
+const (
+	maxReorderedFrames = 10
+	/*
+		(max_size(sh_picture_header_in_slice_header_flag) + max_size(ph_gdr_or_irap_pic_flag) +
+		max_size(ph_non_ref_pic_flag) + max_size(ph_gdr_pic_flag) + max_size(ph_inter_slice_allowed_flag) +
+		max_size(ph_intra_slice_allowed_flag) + max_size(ph_pic_parameter_set_id) +
+		max_size(ph_pic_order_cnt_lsb)) * 4 / 3 =
+		ceil((6 + 13 + 16) / 8) * 4 / 3 = 7
+	*/
+	maxBytesToGetPOC = 7
+)
+
+func getPictureOrderCount(buf []byte, sps *SPS, isPictureHeader bool) (uint32, error) {
+	buf = buf[2:]
+	lb := len(buf)
+
+	if lb > maxBytesToGetPOC {
+		lb = maxBytesToGetPOC
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[:lb])
+	pos := 0
+
+	if !isPictureHeader {
+		pictureHeaderInSliceHeaderFlag, err := bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return 0, err
+		}
+
+		if !pictureHeaderInSliceHeaderFlag {
+			return 0, fmt.Errorf("picture header not found")
+		}
+	}
+
+	gdrOrIrapPicFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = bits.ReadFlag(buf, &pos) // ph_non_ref_pic_flag
+	if err != nil {
+		return 0, err
+	}
+
+	if gdrOrIrapPicFlag {
+		_, err = bits.ReadFlag(buf, &pos) // ph_gdr_pic_flag
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	interSliceAllowedFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if interSliceAllowedFlag {
+		_, err = bits.ReadFlag(buf, &pos) // ph_intra_slice_allowed_flag
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = bits.ReadGolombUnsigned(buf, &pos) // ph_pic_parameter_set_id
+	if err != nil {
+		return 0, err
+	}
+
+	picOrderCntLsb, err := bits.ReadBits(buf, &pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(picOrderCntLsb), nil
+}
+
+func getPictureOrderCountDiff(a uint32, b uint32, sps *SPS) int32 {
+	maxVal := uint32(1 << (sps.Log2MaxPicOrderCntLsbMinus4 + 4))
+	d := (a - b) & (maxVal - 1)
+	if d > (maxVal / 2) {
+		return int32(d) - int32(maxVal)
+	}
+	return int32(d)
+}
+
+// DTSExtractor computes DTS from PTS.
+type DTSExtractor struct {
+	sps             []byte
+	spsp            *SPS
+	prevDTSFilled   bool
+	prevDTS         int64
+	expectedPOC     uint32
+	reorderedFrames int
+	pauseDTS        int
+}
+
+// NewDTSExtractor allocates a DTSExtractor.
+func NewDTSExtractor() *DTSExtractor {
+	return &DTSExtractor{}
+}
+
+func (d *DTSExtractor) extractInner(au [][]byte, pts int64) (int64, bool, error) {
+	var ph []byte
+	var idr []byte
+	var nonIDR []byte
+
+	for _, nalu := range au {
+		typ := naluType(nalu)
+		switch typ {
+		case NALUType_SPS_NUT:
+			if !bytes.Equal(d.sps, nalu) {
+				var spsp SPS
+				err := spsp.Unmarshal(nalu)
+				if err != nil {
+					return 0, false, fmt.Errorf("invalid SPS: %w", err)
+				}
+				d.sps = nalu
+				d.spsp = &spsp
+
+				// reset state
+				d.reorderedFrames = 0
+			}
+
+		case NALUType_PH_NUT:
+			ph = nalu
+
+		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP:
+			idr = nalu
+
+		case NALUType_TRAIL_NUT, NALUType_STSA_NUT, NALUType_RADL_NUT, NALUType_RASL_NUT,
+			NALUType_CRA_NUT, NALUType_GDR_NUT:
+			nonIDR = nalu
+		}
+	}
+
+	if d.spsp == nil {
+		return 0, false, fmt.Errorf("SPS not received yet")
+	}
+
+	if d.spsp.MaxNumReorderPics() == 0 {
+		return pts, false, nil
+	}
+
+	getPOC := func(slice []byte) (uint32, error) {
+		if ph != nil {
+			return getPictureOrderCount(ph, d.spsp, true)
+		}
+		return getPictureOrderCount(slice, d.spsp, false)
+	}
+
+	switch {
+	case idr != nil:
+		d.pauseDTS = 0
+
+		var err error
+		d.expectedPOC, err = getPOC(idr)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if !d.prevDTSFilled || d.reorderedFrames == 0 {
+			return pts, false, nil
+		}
+
+		return d.prevDTS + (pts-d.prevDTS)/int64(d.reorderedFrames+1), false, nil
+
+	case nonIDR != nil:
+		d.expectedPOC++
+		d.expectedPOC &= ((1 << (d.spsp.Log2MaxPicOrderCntLsbMinus4 + 4)) - 1)
+
+		if d.pauseDTS > 0 {
+			d.pauseDTS--
+			return d.prevDTS + 90, true, nil
+		}
+
+		poc, err := getPOC(nonIDR)
+		if err != nil {
+			return 0, false, err
+		}
+
+		pocDiff := int(getPictureOrderCountDiff(poc, d.expectedPOC, d.spsp))
+		limit := -(d.reorderedFrames + 1)
+
+		// this happens when there are B-frames immediately following an IDR frame
+		if pocDiff < limit {
+			increase := limit - pocDiff
+			if (d.reorderedFrames + increase) > maxReorderedFrames {
+				return 0, false, fmt.Errorf("too many reordered frames (%d)", d.reorderedFrames+increase)
+			}
+
+			d.reorderedFrames += increase
+			d.pauseDTS = increase
+			return d.prevDTS + 90, true, nil
+		}
+
+		if pocDiff == limit {
+			return pts, false, nil
+		}
+
+		if pocDiff > d.reorderedFrames {
+			increase := pocDiff - d.reorderedFrames
+			if (d.reorderedFrames + increase) > maxReorderedFrames {
+				return 0, false, fmt.Errorf("too many reordered frames (%d)", d.reorderedFrames+increase)
+			}
+
+			d.reorderedFrames += increase
+			d.pauseDTS = increase - 1
+			return d.prevDTS + 90, false, nil
+		}
+
+		return d.prevDTS + (pts-d.prevDTS)/int64(pocDiff+d.reorderedFrames+1), false, nil
+
+	default:
+		return 0, false, fmt.Errorf("access unit doesn't contain an IDR or non-IDR NALU")
+	}
+}
+
+// Extract extracts the DTS of an access unit.
+func (d *DTSExtractor) Extract(au [][]byte, pts int64) (int64, error) {
+	dts, skipChecks, err := d.extractInner(au, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if !skipChecks && dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTS = dts
+	d.prevDTSFilled = true
+
+	return dts, err
+}
@@ -0,0 +1,214 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	au  [][]byte
+	dts int64
+	pts int64
+}
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sample
+}{
+	{
+		"no reordering",
+		[]sample{
+			{
+				[][]byte{
+					{ // SPS
+						0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+						0x00, 0x0a, 0x02, 0x00, 0xb4, 0x46, 0xa0, 0x0b,
+						0x92, 0xc0,
+					},
+					{ // IDR_W_RADL
+						0x00, 0x39, 0xc4, 0x01, 0x6b, 0x0e,
+					},
+				},
+				90000,
+				90000,
+			},
+			{
+				[][]byte{{ // TRAIL
+					0x00, 0x01, 0x9c, 0x05, 0x6b, 0x0e,
+				}},
+				93000,
+				93000,
+			},
+		},
+	},
+	{
+		"with B-frames",
+		[]sample{
+			{
+				[][]byte{
+					{ // SPS
+						0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+						0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+						0xb9, 0x2c,
+					},
+					{ // PPS
+						0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+						0xc8, 0x86,
+					},
+					{ // IDR_W_RADL
+						0x00, 0x39, 0xc4, 0x01, 0x6b, 0x0e,
+					},
+				},
+				90000,
+				90000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 4
+					0x00, 0x01, 0x9c, 0x11, 0x6b, 0x0e,
+				}},
+				90090,
+				102000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 2
+					0x00, 0x01, 0x9c, 0x09, 0x6b, 0x0e,
+				}},
+				90180,
+				96000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 1
+					0x00, 0x01, 0x9c, 0x05, 0x6b, 0x0e,
+				}},
+				90270,
+				93000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 3
+					0x00, 0x01, 0x9c, 0x0d, 0x6b, 0x0e,
+				}},
+				93180,
+				99000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 8
+					0x00, 0x01, 0x9c, 0x21, 0x6b, 0x0e,
+				}},
+				96154,
+				114000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 6
+					0x00, 0x01, 0x9c, 0x19, 0x6b, 0x0e,
+				}},
+				99115,
+				108000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 5
+					0x00, 0x01, 0x9c, 0x15, 0x6b, 0x0e,
+				}},
+				102057,
+				105000,
+			},
+			{
+				[][]byte{{ // TRAIL, POC 7
+					0x00, 0x01, 0x9c, 0x1d, 0x6b, 0x0e,
+				}},
+				105038,
+				111000,
+			},
+		},
+	},
+	{
+		"picture header NALU",
+		[]sample{
+			{
+				[][]byte{
+					{ // SPS
+						0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+						0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+						0xb9, 0x2c,
+					},
+					{ // PH, POC 0
+						0x00, 0x99, 0x88, 0x02,
+					},
+					{ // IDR_W_RADL
+						0x00, 0x39, 0x2d, 0x40,
+					},
+				},
+				90000,
+				90000,
+			},
+			{
+				[][]byte{
+					{ // PH, POC 2
+						0x00, 0x99, 0x38, 0x12,
+					},
+					{ // TRAIL
+						0x00, 0x01, 0x2d, 0x40,
+					},
+				},
+				90090,
+				96000,
+			},
+			{
+				[][]byte{
+					{ // PH, POC 1
+						0x00, 0x99, 0x38, 0x0a,
+					},
+					{ // TRAIL
+						0x00, 0x01, 0x2d, 0x40,
+					},
+				},
+				93000,
+				93000,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := NewDTSExtractor()
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.au, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+
+func FuzzDTSExtractorFirstAU(f *testing.F) {
+	f.Fuzz(func(_ *testing.T, a []byte, b []byte) {
+		ex := NewDTSExtractor()
+
+		ex.Extract([][]byte{ //nolint:errcheck
+			a,
+			b,
+		}, 0)
+	})
+}
+
+func FuzzDTSExtractorSecondAU(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte) {
+		ex := NewDTSExtractor()
+
+		_, err := ex.Extract([][]byte{
+			{ // SPS
+				0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+				0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+				0xb9, 0x2c,
+			},
+			{ // IDR_W_RADL
+				0x00, 0x39, 0xc4, 0x01, 0x6b, 0x0e,
+			},
+		}, 90000)
+		require.NoError(t, err)
+
+		ex.Extract([][]byte{a}, 93000) //nolint:errcheck
+	})
+}
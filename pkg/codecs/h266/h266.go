@@ -0,0 +1,11 @@
+// Package h266 contains utilities to work with the H266 codec.
+package h266
+
+const (
+	// MaxAccessUnitSize is the maximum size of an access unit.
+	// With a 50 Mbps 2160p60 H266 video, the maximum size does not seem to exceed 8 MiB.
+	MaxAccessUnitSize = 8 * 1024 * 1024
+
+	// MaxNALUsPerAccessUnit is the maximum number of NALUs per access unit.
+	MaxNALUsPerAccessUnit = 21
+)
@@ -0,0 +1,12 @@
+package h266
+
+// IsRandomAccess checks whether the access unit is a random access point.
+func IsRandomAccess(au [][]byte) bool {
+	for _, nalu := range au {
+		switch naluType(nalu) {
+		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRandomAccess(t *testing.T) {
+	u := [][]byte{{0x00, byte(NALUType_IDR_W_RADL)<<3 | 1}}
+	require.Equal(t, true, IsRandomAccess(u))
+
+	u = [][]byte{{0x00, byte(NALUType_TRAIL_NUT)<<3 | 1}}
+	require.Equal(t, false, IsRandomAccess(u))
+
+	u = [][]byte{{0x00}}
+	require.Equal(t, false, IsRandomAccess(u))
+}
@@ -0,0 +1,96 @@
+package h266
+
+import (
+	"fmt"
+)
+
+// NALUType is the type of a NALU.
+// Specification: ITU-T Rec. H.266, Table 5
+type NALUType uint8
+
+// NALU types.
+const (
+	NALUType_TRAIL_NUT      NALUType = 0  //nolint:revive
+	NALUType_STSA_NUT       NALUType = 1  //nolint:revive
+	NALUType_RADL_NUT       NALUType = 2  //nolint:revive
+	NALUType_RASL_NUT       NALUType = 3  //nolint:revive
+	NALUType_RSV_VCL_4      NALUType = 4  //nolint:revive
+	NALUType_RSV_VCL_5      NALUType = 5  //nolint:revive
+	NALUType_RSV_VCL_6      NALUType = 6  //nolint:revive
+	NALUType_IDR_W_RADL     NALUType = 7  //nolint:revive
+	NALUType_IDR_N_LP       NALUType = 8  //nolint:revive
+	NALUType_CRA_NUT        NALUType = 9  //nolint:revive
+	NALUType_GDR_NUT        NALUType = 10 //nolint:revive
+	NALUType_RSV_IRAP_11    NALUType = 11 //nolint:revive
+	NALUType_OPI_NUT        NALUType = 12 //nolint:revive
+	NALUType_DCI_NUT        NALUType = 13 //nolint:revive
+	NALUType_VPS_NUT        NALUType = 14 //nolint:revive
+	NALUType_SPS_NUT        NALUType = 15 //nolint:revive
+	NALUType_PPS_NUT        NALUType = 16 //nolint:revive
+	NALUType_PREFIX_APS_NUT NALUType = 17 //nolint:revive
+	NALUType_SUFFIX_APS_NUT NALUType = 18 //nolint:revive
+	NALUType_PH_NUT         NALUType = 19 //nolint:revive
+	NALUType_AUD_NUT        NALUType = 20 //nolint:revive
+	NALUType_EOS_NUT        NALUType = 21 //nolint:revive
+	NALUType_EOB_NUT        NALUType = 22 //nolint:revive
+	NALUType_PREFIX_SEI_NUT NALUType = 23 //nolint:revive
+	NALUType_SUFFIX_SEI_NUT NALUType = 24 //nolint:revive
+	NALUType_FD_NUT         NALUType = 25 //nolint:revive
+	NALUType_RSV_NVCL_26    NALUType = 26 //nolint:revive
+	NALUType_RSV_NVCL_27    NALUType = 27 //nolint:revive
+
+	// additional NALU types for RTP/H266
+	NALUType_AggregationUnit   NALUType = 28 //nolint:revive
+	NALUType_FragmentationUnit NALUType = 29 //nolint:revive
+)
+
+var naluTypeLabels = map[NALUType]string{
+	NALUType_TRAIL_NUT:      "TRAIL_NUT",
+	NALUType_STSA_NUT:       "STSA_NUT",
+	NALUType_RADL_NUT:       "RADL_NUT",
+	NALUType_RASL_NUT:       "RASL_NUT",
+	NALUType_RSV_VCL_4:      "RSV_VCL_4",
+	NALUType_RSV_VCL_5:      "RSV_VCL_5",
+	NALUType_RSV_VCL_6:      "RSV_VCL_6",
+	NALUType_IDR_W_RADL:     "IDR_W_RADL",
+	NALUType_IDR_N_LP:       "IDR_N_LP",
+	NALUType_CRA_NUT:        "CRA_NUT",
+	NALUType_GDR_NUT:        "GDR_NUT",
+	NALUType_RSV_IRAP_11:    "RSV_IRAP_11",
+	NALUType_OPI_NUT:        "OPI_NUT",
+	NALUType_DCI_NUT:        "DCI_NUT",
+	NALUType_VPS_NUT:        "VPS_NUT",
+	NALUType_SPS_NUT:        "SPS_NUT",
+	NALUType_PPS_NUT:        "PPS_NUT",
+	NALUType_PREFIX_APS_NUT: "PREFIX_APS_NUT",
+	NALUType_SUFFIX_APS_NUT: "SUFFIX_APS_NUT",
+	NALUType_PH_NUT:         "PH_NUT",
+	NALUType_AUD_NUT:        "AUD_NUT",
+	NALUType_EOS_NUT:        "EOS_NUT",
+	NALUType_EOB_NUT:        "EOB_NUT",
+	NALUType_PREFIX_SEI_NUT: "PREFIX_SEI_NUT",
+	NALUType_SUFFIX_SEI_NUT: "SUFFIX_SEI_NUT",
+	NALUType_FD_NUT:         "FD_NUT",
+	NALUType_RSV_NVCL_26:    "RSV_NVCL_26",
+	NALUType_RSV_NVCL_27:    "RSV_NVCL_27",
+
+	// additional NALU types for RTP/H266
+	NALUType_AggregationUnit:   "AggregationUnit",
+	NALUType_FragmentationUnit: "FragmentationUnit",
+}
+
+// String implements fmt.Stringer.
+func (nt NALUType) String() string {
+	if l, ok := naluTypeLabels[nt]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", nt)
+}
+
+// the type is contained in the second byte of the NALU header.
+func naluType(nalu []byte) NALUType {
+	if len(nalu) < 2 {
+		return NALUType(0xFF)
+	}
+	return NALUType(nalu[1] >> 3)
+}
@@ -0,0 +1,95 @@
+package h266
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+)
+
+// PPS is a H266 picture parameter set.
+// Decoding stops after pps_no_pic_partition_flag.
+// Specification: ITU-T Rec. H.266, 7.3.2.5
+type PPS struct {
+	ID                                  uint8
+	SPSID                               uint8
+	MixedNALUTypesInPicFlag             bool
+	PicWidthInLumaSamples               uint32
+	PicHeightInLumaSamples              uint32
+	ConformanceWindow                   *SPS_Window
+	ScalingWindowExplicitSignallingFlag bool
+	OutputFlagPresentFlag               bool
+	NoPicPartitionFlag                  bool
+}
+
+// Unmarshal decodes a PPS.
+func (p *PPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if naluType(buf) != NALUType_PPS_NUT {
+		return fmt.Errorf("not a PPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[2:])
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 11)
+	if err != nil {
+		return err
+	}
+
+	p.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	p.SPSID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	p.MixedNALUTypesInPicFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.PicWidthInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.PicHeightInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	conformanceWindowFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if conformanceWindowFlag {
+		p.ConformanceWindow = &SPS_Window{}
+		err = p.ConformanceWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.ConformanceWindow = nil
+	}
+
+	p.ScalingWindowExplicitSignallingFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if p.ScalingWindowExplicitSignallingFlag {
+		for i := 0; i < 4; i++ {
+			_, err = bits.ReadGolombSigned(buf, &pos) // pps_scaling_win_*_offset
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.OutputFlagPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.NoPicPartitionFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
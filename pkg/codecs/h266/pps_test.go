@@ -0,0 +1,48 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPPS = []struct {
+	name string
+	byts []byte
+	pps  PPS
+}{
+	{
+		"1920x1080",
+		[]byte{
+			0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+			0xc8, 0x86,
+		},
+		PPS{
+			PicWidthInLumaSamples:  1920,
+			PicHeightInLumaSamples: 1080,
+			NoPicPartitionFlag:     true,
+		},
+	},
+}
+
+func TestPPSUnmarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var pps PPS
+			err := pps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pps, pps)
+		})
+	}
+}
+
+func FuzzPPSUnmarshal(f *testing.F) {
+	for _, ca := range casesPPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pps PPS
+		pps.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,562 @@
+package h266
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+)
+
+const (
+	// number of bits of general_constraints_info() between gci_present_flag and gci_num_additional_bits.
+	gciFlagsBits = 71
+
+	maxSubLayers = 7
+	maxSubPics   = 600
+)
+
+var subWidthC = []uint32{
+	1,
+	2,
+	2,
+	1,
+}
+
+var subHeightC = []uint32{
+	1,
+	2,
+	1,
+	1,
+}
+
+func byteAligned(pos int) bool {
+	return (pos % 8) == 0
+}
+
+// ceil(log2(v))
+func ceilLog2(v uint32) int {
+	n := 0
+	for (uint32(1) << n) < v {
+		n++
+	}
+	return n
+}
+
+// SPS_ProfileTierLevel is a profile, tier and level.
+type SPS_ProfileTierLevel struct { //nolint:revive
+	GeneralProfileIdc          uint8
+	GeneralTierFlag            uint8
+	GeneralLevelIdc            uint8
+	PtlFrameOnlyConstraintFlag bool
+	PtlMultilayerEnabledFlag   bool
+
+	// general_constraints_info(), including ptl_frame_only_constraint_flag and
+	// ptl_multilayer_enabled_flag in the two most significant bits of the first byte.
+	GeneralConstraintsInfo []byte
+
+	PtlSublayerLevelPresentFlag []bool
+	SublayerLevelIdc            []uint8
+	GeneralSubProfileIdc        []uint32
+}
+
+func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	err := bits.HasSpace(buf, *pos, 7+1+8+1+1+1)
+	if err != nil {
+		return err
+	}
+
+	p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 7))
+	p.GeneralTierFlag = uint8(bits.ReadBitsUnsafe(buf, pos, 1))
+	p.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	gciStart := *pos
+	p.PtlFrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.PtlMultilayerEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	gciPresentFlag := bits.ReadFlagUnsafe(buf, pos)
+
+	if gciPresentFlag {
+		err = bits.HasSpace(buf, *pos, gciFlagsBits+8)
+		if err != nil {
+			return err
+		}
+
+		*pos += gciFlagsBits
+		gciNumAdditionalBits := int(bits.ReadBitsUnsafe(buf, pos, 8))
+
+		err = bits.HasSpace(buf, *pos, gciNumAdditionalBits)
+		if err != nil {
+			return err
+		}
+
+		*pos += gciNumAdditionalBits
+	}
+
+	for !byteAligned(*pos) {
+		_, err = bits.ReadFlag(buf, pos) // gci_alignment_zero_bit
+		if err != nil {
+			return err
+		}
+	}
+
+	p.GeneralConstraintsInfo = buf[gciStart/8 : *pos/8]
+
+	if maxSubLayersMinus1 > 0 {
+		p.PtlSublayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+
+		err = bits.HasSpace(buf, *pos, int(maxSubLayersMinus1))
+		if err != nil {
+			return err
+		}
+
+		for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+			p.PtlSublayerLevelPresentFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+		}
+	} else {
+		p.PtlSublayerLevelPresentFlag = nil
+	}
+
+	for !byteAligned(*pos) {
+		_, err = bits.ReadFlag(buf, pos) // ptl_reserved_zero_bit
+		if err != nil {
+			return err
+		}
+	}
+
+	p.SublayerLevelIdc = nil
+
+	for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+		if p.PtlSublayerLevelPresentFlag[i] {
+			if p.SublayerLevelIdc == nil {
+				p.SublayerLevelIdc = make([]uint8, maxSubLayersMinus1)
+			}
+
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			p.SublayerLevelIdc[i] = uint8(tmp)
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+	ptlNumSubProfiles := int(tmp)
+
+	if ptlNumSubProfiles > 0 {
+		err = bits.HasSpace(buf, *pos, 32*ptlNumSubProfiles)
+		if err != nil {
+			return err
+		}
+
+		p.GeneralSubProfileIdc = make([]uint32, ptlNumSubProfiles)
+
+		for i := 0; i < ptlNumSubProfiles; i++ {
+			p.GeneralSubProfileIdc[i] = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+		}
+	} else {
+		p.GeneralSubProfileIdc = nil
+	}
+
+	return nil
+}
+
+// SPS_Window is a window.
+type SPS_Window struct { //nolint:revive
+	LeftOffset   uint32
+	RightOffset  uint32
+	TopOffset    uint32
+	BottomOffset uint32
+}
+
+func (w *SPS_Window) unmarshal(buf []byte, pos *int) error {
+	var err error
+	w.LeftOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.RightOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.TopOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.BottomOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SPS_DPBParameters are decoded picture buffer parameters.
+type SPS_DPBParameters struct { //nolint:revive
+	MaxDecPicBufferingMinus1 []uint32
+	MaxNumReorderPics        []uint32
+	MaxLatencyIncreasePlus1  []uint32
+}
+
+func (d *SPS_DPBParameters) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8, subLayerInfoFlag bool) error {
+	start := uint8(0)
+	if !subLayerInfoFlag {
+		start = maxSubLayersMinus1
+	}
+
+	n := maxSubLayersMinus1 - start + 1
+
+	d.MaxDecPicBufferingMinus1 = make([]uint32, n)
+	d.MaxNumReorderPics = make([]uint32, n)
+	d.MaxLatencyIncreasePlus1 = make([]uint32, n)
+
+	for i := uint8(0); i < n; i++ {
+		var err error
+		d.MaxDecPicBufferingMinus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		d.MaxNumReorderPics[i], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		d.MaxLatencyIncreasePlus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SPS is a H266 sequence parameter set.
+// Decoding stops after dpb_parameters().
+// Specification: ITU-T Rec. H.266, 7.3.2.4
+type SPS struct {
+	ID                           uint8
+	VPSID                        uint8
+	MaxSublayersMinus1           uint8
+	ChromaFormatIdc              uint8
+	Log2CTUSizeMinus5            uint8
+	PtlDpbHrdParamsPresentFlag   bool
+	ProfileTierLevel             *SPS_ProfileTierLevel
+	GdrEnabledFlag               bool
+	RefPicResamplingEnabledFlag  bool
+	ResChangeInClvsAllowedFlag   bool
+	PicWidthMaxInLumaSamples     uint32
+	PicHeightMaxInLumaSamples    uint32
+	ConformanceWindow            *SPS_Window
+	SubpicInfoPresentFlag        bool
+	BitDepthMinus8               uint32
+	EntropyCodingSyncEnabledFlag bool
+	EntryPointOffsetsPresentFlag bool
+	Log2MaxPicOrderCntLsbMinus4  uint8
+	PocMsbCycleFlag              bool
+	PocMsbCycleLenMinus1         uint32
+	NumExtraPhBytes              uint8
+	NumExtraShBytes              uint8
+	SublayerDpbParamsFlag        bool
+	DPBParameters                *SPS_DPBParameters
+}
+
+func (s *SPS) skipSubpicInfo(buf []byte, pos *int) error {
+	spsNumSubpicsMinus1, err := bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if spsNumSubpicsMinus1 >= maxSubPics {
+		return fmt.Errorf("sps_num_subpics_minus1 exceeds %d", maxSubPics)
+	}
+
+	independentSubpicsFlag := true
+	subpicSameSizeFlag := false
+
+	if spsNumSubpicsMinus1 > 0 {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		independentSubpicsFlag = bits.ReadFlagUnsafe(buf, pos)
+		subpicSameSizeFlag = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	ctbSizeY := uint32(1) << (s.Log2CTUSizeMinus5 + 5)
+	xBits := ceilLog2((s.PicWidthMaxInLumaSamples + ctbSizeY - 1) / ctbSizeY)
+	yBits := ceilLog2((s.PicHeightMaxInLumaSamples + ctbSizeY - 1) / ctbSizeY)
+
+	for i := uint32(0); spsNumSubpicsMinus1 > 0 && i <= spsNumSubpicsMinus1; i++ {
+		n := 0
+
+		if !subpicSameSizeFlag || i == 0 {
+			if i > 0 && s.PicWidthMaxInLumaSamples > ctbSizeY {
+				n += xBits // sps_subpic_ctu_top_left_x
+			}
+			if i > 0 && s.PicHeightMaxInLumaSamples > ctbSizeY {
+				n += yBits // sps_subpic_ctu_top_left_y
+			}
+			if i < spsNumSubpicsMinus1 && s.PicWidthMaxInLumaSamples > ctbSizeY {
+				n += xBits // sps_subpic_width_minus1
+			}
+			if i < spsNumSubpicsMinus1 && s.PicHeightMaxInLumaSamples > ctbSizeY {
+				n += yBits // sps_subpic_height_minus1
+			}
+		}
+
+		if !independentSubpicsFlag {
+			n += 2 // sps_subpic_treated_as_pic_flag, sps_loop_filter_across_subpic_enabled_flag
+		}
+
+		err = bits.HasSpace(buf, *pos, n)
+		if err != nil {
+			return err
+		}
+		*pos += n
+	}
+
+	spsSubpicIDLenMinus1, err := bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if spsSubpicIDLenMinus1 > 15 {
+		return fmt.Errorf("invalid sps_subpic_id_len_minus1")
+	}
+
+	subpicIDMappingExplicitlySignalledFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if subpicIDMappingExplicitlySignalledFlag {
+		var subpicIDMappingPresentFlag bool
+		subpicIDMappingPresentFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if subpicIDMappingPresentFlag {
+			n := int(spsNumSubpicsMinus1+1) * int(spsSubpicIDLenMinus1+1)
+			err = bits.HasSpace(buf, *pos, n)
+			if err != nil {
+				return err
+			}
+			*pos += n
+		}
+	}
+
+	return nil
+}
+
+// Unmarshal decodes a SPS.
+func (s *SPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if naluType(buf) != NALUType_SPS_NUT {
+		return fmt.Errorf("not a SPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[2:])
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+
+	s.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.VPSID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.MaxSublayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+
+	if s.MaxSublayersMinus1 >= maxSubLayers {
+		return fmt.Errorf("invalid sps_max_sublayers_minus1")
+	}
+
+	s.ChromaFormatIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	s.Log2CTUSizeMinus5 = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	s.PtlDpbHrdParamsPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.PtlDpbHrdParamsPresentFlag {
+		s.ProfileTierLevel = &SPS_ProfileTierLevel{}
+		err = s.ProfileTierLevel.unmarshal(buf, &pos, s.MaxSublayersMinus1)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ProfileTierLevel = nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.GdrEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.RefPicResamplingEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.RefPicResamplingEnabledFlag {
+		s.ResChangeInClvsAllowedFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ResChangeInClvsAllowedFlag = false
+	}
+
+	s.PicWidthMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.PicHeightMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	conformanceWindowFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if conformanceWindowFlag {
+		s.ConformanceWindow = &SPS_Window{}
+		err = s.ConformanceWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ConformanceWindow = nil
+	}
+
+	s.SubpicInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.SubpicInfoPresentFlag {
+		err = s.skipSubpicInfo(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.BitDepthMinus8, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	s.EntropyCodingSyncEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.EntryPointOffsetsPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.Log2MaxPicOrderCntLsbMinus4 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+
+	if s.Log2MaxPicOrderCntLsbMinus4 > 12 {
+		return fmt.Errorf("invalid sps_log2_max_pic_order_cnt_lsb_minus4")
+	}
+
+	s.PocMsbCycleFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.PocMsbCycleFlag {
+		s.PocMsbCycleLenMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.PocMsbCycleLenMinus1 = 0
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	s.NumExtraPhBytes = uint8(tmp)
+
+	err = bits.HasSpace(buf, pos, int(s.NumExtraPhBytes)*8)
+	if err != nil {
+		return err
+	}
+	pos += int(s.NumExtraPhBytes) * 8 // sps_extra_ph_bit_present_flag
+
+	tmp, err = bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	s.NumExtraShBytes = uint8(tmp)
+
+	err = bits.HasSpace(buf, pos, int(s.NumExtraShBytes)*8)
+	if err != nil {
+		return err
+	}
+	pos += int(s.NumExtraShBytes) * 8 // sps_extra_sh_bit_present_flag
+
+	if s.PtlDpbHrdParamsPresentFlag {
+		if s.MaxSublayersMinus1 > 0 {
+			s.SublayerDpbParamsFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			s.SublayerDpbParamsFlag = false
+		}
+
+		s.DPBParameters = &SPS_DPBParameters{}
+		err = s.DPBParameters.unmarshal(buf, &pos, s.MaxSublayersMinus1, s.SublayerDpbParamsFlag)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.SublayerDpbParamsFlag = false
+		s.DPBParameters = nil
+	}
+
+	return nil
+}
+
+// Width returns the video width.
+func (s SPS) Width() int {
+	width := s.PicWidthMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitX := subWidthC[s.ChromaFormatIdc]
+		width -= (s.ConformanceWindow.LeftOffset + s.ConformanceWindow.RightOffset) * cropUnitX
+	}
+
+	return int(width)
+}
+
+// Height returns the video height.
+func (s SPS) Height() int {
+	height := s.PicHeightMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitY := subHeightC[s.ChromaFormatIdc]
+		height -= (s.ConformanceWindow.TopOffset + s.ConformanceWindow.BottomOffset) * cropUnitY
+	}
+
+	return int(height)
+}
+
+// MaxNumReorderPics returns the maximum number of pictures that can precede
+// any picture in decoding order and follow it in output order.
+func (s SPS) MaxNumReorderPics() uint32 {
+	if s.DPBParameters == nil {
+		return 0
+	}
+
+	return s.DPBParameters.MaxNumReorderPics[len(s.DPBParameters.MaxNumReorderPics)-1]
+}
@@ -0,0 +1,150 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSPS = []struct {
+	name   string
+	byts   []byte
+	sps    SPS
+	width  int
+	height int
+}{
+	{
+		"1920x1080",
+		[]byte{
+			0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+			0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+			0xb9, 0x2c,
+		},
+		SPS{
+			ChromaFormatIdc:            1,
+			Log2CTUSizeMinus5:          2,
+			PtlDpbHrdParamsPresentFlag: true,
+			ProfileTierLevel: &SPS_ProfileTierLevel{
+				GeneralProfileIdc:          1,
+				GeneralLevelIdc:            83,
+				PtlFrameOnlyConstraintFlag: true,
+				GeneralConstraintsInfo:     []byte{0x80},
+			},
+			PicWidthMaxInLumaSamples:     1920,
+			PicHeightMaxInLumaSamples:    1080,
+			BitDepthMinus8:               2,
+			EntryPointOffsetsPresentFlag: true,
+			Log2MaxPicOrderCntLsbMinus4:  4,
+			DPBParameters: &SPS_DPBParameters{
+				MaxDecPicBufferingMinus1: []uint32{4},
+				MaxNumReorderPics:        []uint32{2},
+				MaxLatencyIncreasePlus1:  []uint32{0},
+			},
+		},
+		1920,
+		1080,
+	},
+	{
+		"1920x1080 with conformance window",
+		[]byte{
+			0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+			0x00, 0x0f, 0x02, 0x00, 0x44, 0x1f, 0x29, 0xa8,
+			0x02, 0xb9, 0x2c,
+		},
+		SPS{
+			ChromaFormatIdc:            1,
+			Log2CTUSizeMinus5:          2,
+			PtlDpbHrdParamsPresentFlag: true,
+			ProfileTierLevel: &SPS_ProfileTierLevel{
+				GeneralProfileIdc:          1,
+				GeneralLevelIdc:            83,
+				PtlFrameOnlyConstraintFlag: true,
+				GeneralConstraintsInfo:     []byte{0x80},
+			},
+			PicWidthMaxInLumaSamples:  1920,
+			PicHeightMaxInLumaSamples: 1088,
+			ConformanceWindow: &SPS_Window{
+				BottomOffset: 4,
+			},
+			BitDepthMinus8:               2,
+			EntryPointOffsetsPresentFlag: true,
+			Log2MaxPicOrderCntLsbMinus4:  4,
+			DPBParameters: &SPS_DPBParameters{
+				MaxDecPicBufferingMinus1: []uint32{4},
+				MaxNumReorderPics:        []uint32{2},
+				MaxLatencyIncreasePlus1:  []uint32{0},
+			},
+		},
+		1920,
+		1080,
+	},
+	{
+		"3840x2160 with sublayers and constraints",
+		[]byte{
+			0x00, 0x79, 0x00, 0x2d, 0x02, 0x53, 0xa0, 0x00,
+			0x00, 0x03, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x00, 0x80, 0x50, 0x01,
+			0x12, 0x34, 0x56, 0x78, 0x00, 0x07, 0x80, 0x80,
+			0x08, 0x71, 0x1a, 0x80, 0x15, 0xc9, 0x60,
+		},
+		SPS{
+			MaxSublayersMinus1:         1,
+			ChromaFormatIdc:            1,
+			Log2CTUSizeMinus5:          2,
+			PtlDpbHrdParamsPresentFlag: true,
+			ProfileTierLevel: &SPS_ProfileTierLevel{
+				GeneralProfileIdc:          1,
+				GeneralLevelIdc:            83,
+				PtlFrameOnlyConstraintFlag: true,
+				GeneralConstraintsInfo: []byte{
+					0xa0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00,
+				},
+				PtlSublayerLevelPresentFlag: []bool{true},
+				SublayerLevelIdc:            []uint8{80},
+				GeneralSubProfileIdc:        []uint32{0x12345678},
+			},
+			PicWidthMaxInLumaSamples:     3840,
+			PicHeightMaxInLumaSamples:    2160,
+			BitDepthMinus8:               2,
+			EntryPointOffsetsPresentFlag: true,
+			Log2MaxPicOrderCntLsbMinus4:  4,
+			DPBParameters: &SPS_DPBParameters{
+				MaxDecPicBufferingMinus1: []uint32{4},
+				MaxNumReorderPics:        []uint32{2},
+				MaxLatencyIncreasePlus1:  []uint32{0},
+			},
+		},
+		3840,
+		2160,
+	},
+}
+
+func TestSPSUnmarshal(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sps, sps)
+			require.Equal(t, ca.width, sps.Width())
+			require.Equal(t, ca.height, sps.Height())
+		})
+	}
+}
+
+func FuzzSPSUnmarshal(f *testing.F) {
+	for _, ca := range casesSPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var sps SPS
+		err := sps.Unmarshal(b)
+		if err == nil {
+			sps.Width()
+			sps.Height()
+			sps.MaxNumReorderPics()
+		}
+	})
+}
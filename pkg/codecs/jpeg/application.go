@@ -0,0 +1,61 @@
+package jpeg
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var (
+	jfifIdentifier = []byte{'J', 'F', 'I', 'F', 0}
+	exifIdentifier = []byte{'E', 'x', 'i', 'f', 0, 0}
+)
+
+// JFIF is the content of an APP0 JFIF marker.
+// Specification: ITU-T Rec. T.871, 10.1
+type JFIF struct {
+	MajorVersion uint8
+	MinorVersion uint8
+	Units        uint8 // 0 for no units, 1 for dots per inch, 2 for dots per cm
+	XDensity     uint16
+	YDensity     uint16
+}
+
+// Unmarshal decodes the marker.
+func (m *JFIF) Unmarshal(buf []byte) error {
+	if len(buf) < 14 || !bytes.Equal(buf[:5], jfifIdentifier) {
+		return fmt.Errorf("invalid JFIF marker")
+	}
+
+	m.MajorVersion = buf[5]
+	m.MinorVersion = buf[6]
+	m.Units = buf[7]
+	m.XDensity = uint16(buf[8])<<8 | uint16(buf[9])
+	m.YDensity = uint16(buf[10])<<8 | uint16(buf[11])
+
+	thumbnailSize := 3 * int(buf[12]) * int(buf[13])
+	if len(buf) != (14 + thumbnailSize) {
+		return fmt.Errorf("unsupported JFIF size of %d", len(buf))
+	}
+
+	return nil
+}
+
+// Marshal encodes the marker.
+func (m JFIF) Marshal(buf []byte) []byte {
+	buf = append(buf, []byte{0xFF, MarkerApplication0}...)
+	buf = append(buf, []byte{0, 16}...) // length
+	buf = append(buf, jfifIdentifier...)
+	buf = append(buf, []byte{m.MajorVersion, m.MinorVersion, m.Units}...)
+	buf = append(buf, []byte{byte(m.XDensity >> 8), byte(m.XDensity)}...)
+	buf = append(buf, []byte{byte(m.YDensity >> 8), byte(m.YDensity)}...)
+	buf = append(buf, []byte{0, 0}...) // thumbnail size
+	return buf
+}
+
+func isJFIF(buf []byte) bool {
+	return bytes.HasPrefix(buf, jfifIdentifier)
+}
+
+func isEXIF(buf []byte) bool {
+	return bytes.HasPrefix(buf, exifIdentifier)
+}
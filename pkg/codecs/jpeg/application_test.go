@@ -0,0 +1,59 @@
+package jpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesJFIF = []struct {
+	name string
+	enc  []byte
+	dec  JFIF
+}{
+	{
+		"base",
+		[]byte{
+			0xff, 0xe0, 0x0, 0x10, 0x4a, 0x46, 0x49, 0x46,
+			0x0, 0x1, 0x1, 0x0, 0x0, 0x1, 0x0, 0x1,
+			0x0, 0x0,
+		},
+		JFIF{
+			MajorVersion: 1,
+			MinorVersion: 1,
+			XDensity:     1,
+			YDensity:     1,
+		},
+	},
+}
+
+func TestJFIFUnmarshal(t *testing.T) {
+	for _, ca := range casesJFIF {
+		t.Run(ca.name, func(t *testing.T) {
+			var h JFIF
+			err := h.Unmarshal(ca.enc[4:])
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestJFIFMarshal(t *testing.T) {
+	for _, ca := range casesJFIF {
+		t.Run(ca.name, func(t *testing.T) {
+			byts := ca.dec.Marshal(nil)
+			require.Equal(t, ca.enc, byts)
+		})
+	}
+}
+
+func FuzzJFIFUnmarshal(f *testing.F) {
+	for _, ca := range casesJFIF {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h JFIF
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
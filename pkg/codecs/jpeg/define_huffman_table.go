@@ -1,11 +1,53 @@
 package jpeg
 
+import (
+	"fmt"
+)
+
 // DefineHuffmanTable is a DHT marker.
 type DefineHuffmanTable struct {
-	Codes       []byte
+	Codes       []byte // number of codes of each length, from 1 to 16 bits
 	Symbols     []byte
 	TableNumber int
-	TableClass  int
+	TableClass  int // 0 for DC, 1 for AC
+}
+
+func (m *DefineHuffmanTable) unmarshal(buf []byte) (int, error) {
+	if len(buf) < 17 {
+		return 0, fmt.Errorf("image is too short")
+	}
+
+	m.TableClass = int(buf[0] >> 4)
+	m.TableNumber = int(buf[0] & 0x0F)
+	m.Codes = buf[1:17]
+
+	symbolCount := 0
+	for _, c := range m.Codes {
+		symbolCount += int(c)
+	}
+
+	if len(buf) < (17 + symbolCount) {
+		return 0, fmt.Errorf("image is too short")
+	}
+
+	m.Symbols = buf[17 : 17+symbolCount]
+
+	return 17 + symbolCount, nil
+}
+
+// Unmarshal decodes the marker.
+// The marker must contain a single table.
+func (m *DefineHuffmanTable) Unmarshal(buf []byte) error {
+	n, err := m.unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	if n != len(buf) {
+		return fmt.Errorf("unsupported DHT size of %d", len(buf))
+	}
+
+	return nil
 }
 
 // Marshal encodes the marker.
@@ -33,3 +33,29 @@ func TestDefineHuffmanTableMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestDefineHuffmanTableUnmarshal(t *testing.T) {
+	enc := []byte{
+		0xff, 0xc4, 0x0, 0x15, 0x11, 0x0, 0x2, 0x0,
+		0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x2,
+	}
+
+	var h DefineHuffmanTable
+	err := h.Unmarshal(enc[4:])
+	require.NoError(t, err)
+	require.Equal(t, DefineHuffmanTable{
+		Codes:       []byte{0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		Symbols:     []byte{1, 2},
+		TableNumber: 1,
+		TableClass:  1,
+	}, h)
+	require.Equal(t, enc, h.Marshal(nil))
+}
+
+func FuzzDefineHuffmanTableUnmarshal(f *testing.F) {
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h DefineHuffmanTable
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
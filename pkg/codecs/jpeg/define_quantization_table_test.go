@@ -46,6 +46,21 @@ var casesDefineQuantizationTable = []struct {
 			},
 		},
 	},
+	{
+		"16-bit",
+		append([]byte{
+			0xff, 0xdb, 0x0, 0x83, 0x12,
+		}, bytes.Repeat([]byte{0x01, 0x02}, 64)...),
+		DefineQuantizationTable{
+			Tables: []QuantizationTable{
+				{
+					ID:        2,
+					Precision: 1,
+					Data:      bytes.Repeat([]byte{0x01, 0x02}, 64),
+				},
+			},
+		},
+	},
 }
 
 func TestDefineQuantizationTableUnmarshal(t *testing.T) {
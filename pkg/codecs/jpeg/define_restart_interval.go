@@ -18,3 +18,11 @@ func (m *DefineRestartInterval) Unmarshal(buf []byte) error {
 	m.Interval = uint16(buf[0])<<8 | uint16(buf[1])
 	return nil
 }
+
+// Marshal encodes the marker.
+func (m DefineRestartInterval) Marshal(buf []byte) []byte {
+	buf = append(buf, []byte{0xFF, MarkerDefineRestartInterval}...)
+	buf = append(buf, []byte{0, 4}...) // length
+	buf = append(buf, []byte{byte(m.Interval >> 8), byte(m.Interval)}...)
+	return buf
+}
@@ -33,6 +33,15 @@ func TestDefineRestartIntervalUnmarshal(t *testing.T) {
 	}
 }
 
+func TestDefineRestartIntervalMarshal(t *testing.T) {
+	for _, ca := range casesDefineRestartInterval {
+		t.Run(ca.name, func(t *testing.T) {
+			byts := ca.dec.Marshal(nil)
+			require.Equal(t, ca.enc, byts)
+		})
+	}
+}
+
 func FuzzDefineRestartIntervalUnmarshal(f *testing.F) {
 	for _, ca := range casesDefineRestartInterval {
 		f.Add(ca.enc)
@@ -0,0 +1,147 @@
+package jpeg
+
+import (
+	"fmt"
+)
+
+// FrameParams are the parameters needed to rebuild a JPEG image
+// around entropy-coded scan data.
+// Specification: RFC 2435
+type FrameParams struct {
+	// 0 for 4:2:2 and 1 for 4:2:0 chroma subsampling.
+	// 64 is added when restart markers are present.
+	Type uint8
+
+	Width  int
+	Height int
+
+	// restart interval in MCUs, used when Type >= 64.
+	RestartInterval uint16
+
+	// quantization tables, in zigzag order.
+	// They can be generated from a Q factor with MakeQuantizationTables.
+	LumaQuantizationTable   []byte
+	ChromaQuantizationTable []byte
+}
+
+// Unmarshal infers parameters from a JPEG image.
+func (p *FrameParams) Unmarshal(buf []byte) error {
+	var h Header
+	err := h.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	sof := h.StartOfFrame
+
+	if sof.Marker != MarkerStartOfFrame1 || sof.Precision != 8 {
+		return fmt.Errorf("only baseline 8-bit images are supported")
+	}
+
+	if len(sof.Components) != 3 {
+		return fmt.Errorf("number of components = %d is not supported", len(sof.Components))
+	}
+
+	switch {
+	case sof.Components[0].HorizontalSampling == 2 && sof.Components[0].VerticalSampling == 1:
+		p.Type = 0
+
+	case sof.Components[0].HorizontalSampling == 2 && sof.Components[0].VerticalSampling == 2:
+		p.Type = 1
+
+	default:
+		return fmt.Errorf("luma sampling %dx%d is not supported",
+			sof.Components[0].HorizontalSampling, sof.Components[0].VerticalSampling)
+	}
+
+	for _, c := range sof.Components[1:] {
+		if c.HorizontalSampling != 1 || c.VerticalSampling != 1 {
+			return fmt.Errorf("chroma sampling %dx%d is not supported", c.HorizontalSampling, c.VerticalSampling)
+		}
+	}
+
+	if sof.Components[1].QuantizationTableID != sof.Components[2].QuantizationTableID {
+		return fmt.Errorf("chroma components use different quantization tables")
+	}
+
+	p.LumaQuantizationTable, err = findQuantizationTable(h.QuantizationTables, sof.Components[0].QuantizationTableID)
+	if err != nil {
+		return err
+	}
+
+	p.ChromaQuantizationTable, err = findQuantizationTable(h.QuantizationTables, sof.Components[1].QuantizationTableID)
+	if err != nil {
+		return err
+	}
+
+	p.Width = sof.Width
+	p.Height = sof.Height
+	p.RestartInterval = h.RestartInterval
+
+	if p.RestartInterval != 0 {
+		p.Type += 64
+	}
+
+	return nil
+}
+
+func findQuantizationTable(tables []QuantizationTable, id uint8) ([]byte, error) {
+	// when a table is defined multiple times, the last definition is used
+	for i := len(tables) - 1; i >= 0; i-- {
+		if tables[i].ID == id {
+			if tables[i].Precision != 0 {
+				return nil, fmt.Errorf("16-bit quantization tables are not supported")
+			}
+			return tables[i].Data, nil
+		}
+	}
+	return nil, fmt.Errorf("quantization table %d not found", id)
+}
+
+// Quality returns the Q factor that generates the quantization tables.
+// It returns false if tables cannot be generated by any Q factor.
+func (p FrameParams) Quality() (int, bool) {
+	return QuantizationTablesQuality(p.LumaQuantizationTable, p.ChromaQuantizationTable)
+}
+
+// Marshal encodes a JPEG image, composed by a JFIF header,
+// the entropy-coded scan data and an EOI marker.
+func (p FrameParams) Marshal(buf []byte, scanData []byte) []byte {
+	buf = StartOfImage{}.Marshal(buf)
+
+	buf = JFIF{
+		MajorVersion: 1,
+		MinorVersion: 1,
+		XDensity:     1,
+		YDensity:     1,
+	}.Marshal(buf)
+
+	buf = DefineQuantizationTable{
+		Tables: []QuantizationTable{
+			{ID: 0, Data: p.LumaQuantizationTable},
+			{ID: 1, Data: p.ChromaQuantizationTable},
+		},
+	}.Marshal(buf)
+
+	buf = StartOfFrame1{
+		Type:                   p.Type,
+		Width:                  p.Width,
+		Height:                 p.Height,
+		QuantizationTableCount: 2,
+	}.Marshal(buf)
+
+	buf = HuffmanTableLumaDC.Marshal(buf)
+	buf = HuffmanTableLumaAC.Marshal(buf)
+	buf = HuffmanTableChromaDC.Marshal(buf)
+	buf = HuffmanTableChromaAC.Marshal(buf)
+
+	if p.Type >= 64 {
+		buf = DefineRestartInterval{Interval: p.RestartInterval}.Marshal(buf)
+	}
+
+	buf = StartOfScan{}.Marshal(buf)
+	buf = append(buf, scanData...)
+	buf = append(buf, []byte{0xFF, MarkerEndOfImage}...)
+
+	return buf
+}
@@ -0,0 +1,123 @@
+package jpeg
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestImage(t *testing.T, quality int) (image.Image, []byte) {
+	img := image.NewYCbCr(image.Rect(0, 0, 64, 48), image.YCbCrSubsampleRatio420)
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			img.Y[img.YOffset(x, y)] = uint8(x * 4)
+			img.Cb[img.COffset(x, y)] = uint8(y * 5)
+			img.Cr[img.COffset(x, y)] = uint8(255 - x*2)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	require.NoError(t, err)
+
+	return img, buf.Bytes()
+}
+
+// scanData returns the entropy-coded data of a single-scan image.
+func scanData(t *testing.T, buf []byte) []byte {
+	i := bytes.Index(buf, []byte{0xFF, MarkerStartOfScan})
+	require.NotEqual(t, -1, i)
+	size := int(buf[i+2])<<8 | int(buf[i+3])
+	return buf[i+2+size : len(buf)-2]
+}
+
+func TestFrameParamsUnmarshal(t *testing.T) {
+	for _, quality := range []int{10, 50, 75, 99} {
+		_, enc := encodeTestImage(t, quality)
+
+		var p FrameParams
+		err := p.Unmarshal(enc)
+		require.NoError(t, err)
+
+		require.Equal(t, uint8(1), p.Type)
+		require.Equal(t, 64, p.Width)
+		require.Equal(t, 48, p.Height)
+		require.Equal(t, uint16(0), p.RestartInterval)
+
+		q, ok := p.Quality()
+		require.True(t, ok)
+		require.Equal(t, quality, q)
+	}
+}
+
+func TestFrameParamsUnmarshalErrors(t *testing.T) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 16)), nil)
+	require.NoError(t, err)
+
+	var p FrameParams
+	err = p.Unmarshal(buf.Bytes())
+	require.EqualError(t, err, "number of components = 1 is not supported")
+}
+
+func TestFrameParamsMarshal(t *testing.T) {
+	img, enc := encodeTestImage(t, 80)
+
+	luma, chroma := MakeQuantizationTables(80)
+
+	p := FrameParams{
+		Type:                    1,
+		Width:                   64,
+		Height:                  48,
+		LumaQuantizationTable:   luma,
+		ChromaQuantizationTable: chroma,
+	}
+
+	rebuilt := p.Marshal(nil, scanData(t, enc))
+
+	var p2 FrameParams
+	err := p2.Unmarshal(rebuilt)
+	require.NoError(t, err)
+	require.Equal(t, p, p2)
+
+	dec1, err := jpeg.Decode(bytes.NewReader(enc))
+	require.NoError(t, err)
+
+	dec2, err := jpeg.Decode(bytes.NewReader(rebuilt))
+	require.NoError(t, err)
+
+	require.Equal(t, dec1, dec2)
+	require.Equal(t, img.Bounds(), dec2.Bounds())
+	require.Equal(t, color.YCbCrModel, dec2.ColorModel())
+}
+
+func TestFrameParamsMarshalRestartInterval(t *testing.T) {
+	luma, chroma := MakeQuantizationTables(50)
+
+	p := FrameParams{
+		Type:                    64,
+		Width:                   16,
+		Height:                  8,
+		RestartInterval:         3,
+		LumaQuantizationTable:   luma,
+		ChromaQuantizationTable: chroma,
+	}
+
+	var h Header
+	err := h.Unmarshal(p.Marshal(nil, []byte{0x01, 0x02}))
+	require.NoError(t, err)
+	require.Equal(t, uint16(3), h.RestartInterval)
+	require.Equal(t, uint8(2), h.StartOfFrame.Components[0].HorizontalSampling)
+	require.Equal(t, uint8(1), h.StartOfFrame.Components[0].VerticalSampling)
+	require.Equal(t, &JFIF{MajorVersion: 1, MinorVersion: 1, XDensity: 1, YDensity: 1}, h.JFIF)
+	require.Equal(t, []DefineHuffmanTable{
+		HuffmanTableLumaDC,
+		HuffmanTableLumaAC,
+		HuffmanTableChromaDC,
+		HuffmanTableChromaAC,
+	}, h.HuffmanTables)
+}
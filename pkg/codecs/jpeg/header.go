@@ -0,0 +1,200 @@
+package jpeg
+
+import (
+	"fmt"
+)
+
+// Header contains the parameters of a JPEG image, decoded by walking all its markers.
+type Header struct {
+	StartOfFrame StartOfFrame
+
+	// quantization tables, in order of appearance.
+	QuantizationTables []QuantizationTable
+
+	// Huffman tables, in order of appearance.
+	HuffmanTables []DefineHuffmanTable
+
+	// restart interval in MCUs, 0 if not present.
+	RestartInterval uint16
+
+	// scan headers, in order of appearance.
+	// Baseline images contain a single scan, progressive images contain more.
+	Scans []ScanHeader
+
+	// APP0 JFIF marker, nil if not present.
+	JFIF *JFIF
+
+	// content of the APP1 EXIF marker, after the "Exif" identifier, nil if not present.
+	EXIF []byte
+
+	// COM markers.
+	Comments [][]byte
+}
+
+// skipEntropyCodedData returns the position of the first marker
+// that follows entropy-coded data.
+func skipEntropyCodedData(buf []byte, pos int) (int, error) {
+	for {
+		for {
+			if pos >= len(buf) {
+				return 0, fmt.Errorf("image is too short")
+			}
+			if buf[pos] == 0xFF {
+				break
+			}
+			pos++
+		}
+
+		if (pos + 1) >= len(buf) {
+			return 0, fmt.Errorf("image is too short")
+		}
+
+		b := buf[pos+1]
+
+		switch {
+		case b == 0x00: // stuffed byte
+			pos += 2
+
+		case b >= MarkerRestart0 && b <= MarkerRestart7:
+			pos += 2
+
+		case b == 0xFF: // fill byte
+			pos++
+
+		default:
+			return pos, nil
+		}
+	}
+}
+
+// Unmarshal decodes a JPEG image.
+// Markers are parsed until EOI.
+func (h *Header) Unmarshal(buf []byte) error {
+	if len(buf) < 2 || buf[0] != 0xFF || buf[1] != MarkerStartOfImage {
+		return fmt.Errorf("SOI not found")
+	}
+
+	*h = Header{}
+
+	pos := 2
+	sofFound := false
+
+	for {
+		if (pos + 2) > len(buf) {
+			return fmt.Errorf("image is too short")
+		}
+
+		if buf[pos] != 0xFF {
+			return fmt.Errorf("marker not found at position %d", pos)
+		}
+
+		marker := buf[pos+1]
+		pos += 2
+
+		switch {
+		case marker == 0xFF: // fill byte
+			pos--
+			continue
+
+		case marker == MarkerEndOfImage:
+			if !sofFound {
+				return fmt.Errorf("SOF not found")
+			}
+			if len(h.Scans) == 0 {
+				return fmt.Errorf("SOS not found")
+			}
+			return nil
+
+		case marker >= MarkerRestart0 && marker <= MarkerRestart7:
+			continue
+		}
+
+		if (pos + 2) > len(buf) {
+			return fmt.Errorf("image is too short")
+		}
+
+		size := int(buf[pos])<<8 | int(buf[pos+1])
+		if size < 2 || (pos+size) > len(buf) {
+			return fmt.Errorf("invalid marker size")
+		}
+
+		content := buf[pos+2 : pos+size]
+		pos += size
+
+		switch marker {
+		case MarkerStartOfFrame1, MarkerStartOfFrameExtended,
+			MarkerStartOfFrameProgressive, MarkerStartOfFrameLossless:
+			if sofFound {
+				return fmt.Errorf("multiple SOF markers")
+			}
+			sofFound = true
+
+			h.StartOfFrame.Marker = marker
+			err := h.StartOfFrame.Unmarshal(content)
+			if err != nil {
+				return err
+			}
+
+		case MarkerDefineQuantizationTable:
+			var dqt DefineQuantizationTable
+			err := dqt.Unmarshal(content)
+			if err != nil {
+				return err
+			}
+			h.QuantizationTables = append(h.QuantizationTables, dqt.Tables...)
+
+		case MarkerDefineHuffmanTable:
+			for len(content) != 0 {
+				var dht DefineHuffmanTable
+				n, err := dht.unmarshal(content)
+				if err != nil {
+					return err
+				}
+				h.HuffmanTables = append(h.HuffmanTables, dht)
+				content = content[n:]
+			}
+
+		case MarkerDefineRestartInterval:
+			var dri DefineRestartInterval
+			err := dri.Unmarshal(content)
+			if err != nil {
+				return err
+			}
+			h.RestartInterval = dri.Interval
+
+		case MarkerApplication0:
+			if isJFIF(content) {
+				h.JFIF = &JFIF{}
+				err := h.JFIF.Unmarshal(content)
+				if err != nil {
+					return err
+				}
+			}
+
+		case MarkerApplication1:
+			if isEXIF(content) {
+				h.EXIF = content[len(exifIdentifier):]
+			}
+
+		case MarkerComment:
+			h.Comments = append(h.Comments, content)
+
+		case MarkerStartOfScan:
+			if !sofFound {
+				return fmt.Errorf("SOS found before SOF")
+			}
+
+			var sos ScanHeader
+			err := sos.Unmarshal(content)
+			if err != nil {
+				return err
+			}
+			h.Scans = append(h.Scans, sos)
+
+			pos, err = skipEntropyCodedData(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package jpeg
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesHeader = []struct {
+	name string
+	enc  []byte
+	dec  Header
+}{
+	{
+		"progressive 12-bit grayscale",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 0x4a, 0x46,
+			0x49, 0x46, 0x00, 0x01, 0x02, 0x01, 0x00, 0x48,
+			0x00, 0x48, 0x00, 0x00, 0xff, 0xe1, 0x00, 0x0c,
+			0x45, 0x78, 0x69, 0x66, 0x00, 0x00, 0x4d, 0x4d,
+			0x00, 0x2a, 0xff, 0xfe, 0x00, 0x07, 0x68, 0x65,
+			0x6c, 0x6c, 0x6f, 0xff, 0xdb, 0x00, 0x83, 0x10,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01,
+			0xff, 0xdd, 0x00, 0x04, 0x00, 0x04, 0xff, 0xc2,
+			0x00, 0x0b, 0x0c, 0x00, 0x08, 0x00, 0x10, 0x01,
+			0x01, 0x11, 0x00, 0xff, 0xc4, 0x00, 0x26, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x05, 0x10, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0xff, 0xda, 0x00, 0x08, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x12, 0xff, 0x00,
+			0x34, 0xff, 0xd0, 0x56, 0xff, 0xda, 0x00, 0x08,
+			0x01, 0x01, 0x00, 0x01, 0x3f, 0x00, 0x78, 0xff,
+			0x00, 0x9a, 0xff, 0xff, 0xd9,
+		},
+		Header{
+			StartOfFrame: StartOfFrame{
+				Marker:    MarkerStartOfFrameProgressive,
+				Precision: 12,
+				Height:    8,
+				Width:     16,
+				Components: []FrameComponent{{
+					ID:                  1,
+					HorizontalSampling:  1,
+					VerticalSampling:    1,
+					QuantizationTableID: 0,
+				}},
+			},
+			QuantizationTables: []QuantizationTable{{
+				ID:        0,
+				Precision: 1,
+				Data:      bytes.Repeat([]byte{0, 1}, 64),
+			}},
+			HuffmanTables: []DefineHuffmanTable{
+				{
+					Codes:       append([]byte{1}, make([]byte, 15)...),
+					Symbols:     []byte{5},
+					TableNumber: 0,
+					TableClass:  0,
+				},
+				{
+					Codes:       append([]byte{1}, make([]byte, 15)...),
+					Symbols:     []byte{6},
+					TableNumber: 0,
+					TableClass:  1,
+				},
+			},
+			RestartInterval: 4,
+			Scans: []ScanHeader{
+				{
+					Components: []ScanComponent{{ID: 1}},
+				},
+				{
+					Components:             []ScanComponent{{ID: 1}},
+					SpectralSelectionStart: 1,
+					SpectralSelectionEnd:   63,
+				},
+			},
+			JFIF: &JFIF{
+				MajorVersion: 1,
+				MinorVersion: 2,
+				Units:        1,
+				XDensity:     72,
+				YDensity:     72,
+			},
+			EXIF:     []byte{0x4d, 0x4d, 0x00, 0x2a},
+			Comments: [][]byte{[]byte("hello")},
+		},
+	},
+}
+
+func TestHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h Header
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestHeaderUnmarshalEncoded(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		img        image.Image
+		components []FrameComponent
+	}{
+		{
+			"gray",
+			image.NewGray(image.Rect(0, 0, 64, 48)),
+			[]FrameComponent{
+				{ID: 1, HorizontalSampling: 1, VerticalSampling: 1},
+			},
+		},
+		{
+			"4:2:0",
+			image.NewYCbCr(image.Rect(0, 0, 64, 48), image.YCbCrSubsampleRatio420),
+			[]FrameComponent{
+				{ID: 1, HorizontalSampling: 2, VerticalSampling: 2},
+				{ID: 2, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+				{ID: 3, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := jpeg.Encode(&buf, ca.img, &jpeg.Options{Quality: 50})
+			require.NoError(t, err)
+
+			var h Header
+			err = h.Unmarshal(buf.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, StartOfFrame{
+				Marker:     MarkerStartOfFrame1,
+				Precision:  8,
+				Height:     48,
+				Width:      64,
+				Components: ca.components,
+			}, h.StartOfFrame)
+			require.Equal(t, 1, len(h.Scans))
+			require.Equal(t, len(ca.components), len(h.Scans[0].Components))
+		})
+	}
+}
+
+func FuzzHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h Header
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -12,3 +12,15 @@ const (
 	MarkerEndOfImage              = 0xD9
 	MarkerComment                 = 0xFE
 )
+
+// additional JPEG markers.
+// Specification: ITU-T Rec. T.81, Table B.1
+const (
+	MarkerStartOfFrameExtended    = 0xC1
+	MarkerStartOfFrameProgressive = 0xC2
+	MarkerStartOfFrameLossless    = 0xC3
+	MarkerRestart0                = 0xD0
+	MarkerRestart7                = 0xD7
+	MarkerApplication0            = 0xE0
+	MarkerApplication1            = 0xE1
+)
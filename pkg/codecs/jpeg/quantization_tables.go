@@ -0,0 +1,97 @@
+package jpeg
+
+// base quantization tables, in zigzag order.
+// Specification: ITU-T Rec. T.81, Annex K.1
+var (
+	baseLumaQuantizationTable = [64]byte{
+		16, 11, 12, 14, 12, 10, 16, 14,
+		13, 14, 18, 17, 16, 19, 24, 40,
+		26, 24, 22, 22, 24, 49, 35, 37,
+		29, 40, 58, 51, 61, 60, 57, 51,
+		56, 55, 64, 72, 92, 78, 64, 68,
+		87, 69, 55, 56, 80, 109, 81, 87,
+		95, 98, 103, 104, 103, 62, 77, 113,
+		121, 112, 100, 120, 92, 101, 103, 99,
+	}
+
+	baseChromaQuantizationTable = [64]byte{
+		17, 18, 18, 24, 21, 24, 47, 26,
+		26, 47, 99, 66, 56, 66, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+	}
+)
+
+func qualityScale(q int) int {
+	if q < 50 {
+		return 5000 / q
+	}
+	return 200 - q*2
+}
+
+func scaleQuantizationTable(dst []byte, base *[64]byte, scale int) {
+	for i, v := range base {
+		q := (int(v)*scale + 50) / 100
+		if q < 1 {
+			q = 1
+		} else if q > 255 {
+			q = 255
+		}
+		dst[i] = byte(q)
+	}
+}
+
+// MakeQuantizationTables generates luma and chroma quantization tables from a Q factor.
+// Tables are in zigzag order.
+// Q factor is clamped between 1 and 99.
+// Specification: RFC 2435, Appendix A
+func MakeQuantizationTables(q int) ([]byte, []byte) {
+	factor := q
+	if factor < 1 {
+		factor = 1
+	} else if factor > 99 {
+		factor = 99
+	}
+
+	scale := qualityScale(factor)
+
+	luma := make([]byte, 64)
+	scaleQuantizationTable(luma, &baseLumaQuantizationTable, scale)
+
+	chroma := make([]byte, 64)
+	scaleQuantizationTable(chroma, &baseChromaQuantizationTable, scale)
+
+	return luma, chroma
+}
+
+// QuantizationTablesQuality returns the Q factor that generates
+// the given luma and chroma quantization tables with MakeQuantizationTables.
+// It returns false if tables cannot be generated by any Q factor,
+// and therefore must be transmitted explicitly.
+func QuantizationTablesQuality(luma []byte, chroma []byte) (int, bool) {
+	if len(luma) != 64 || len(chroma) != 64 {
+		return 0, false
+	}
+
+	var tmp [64]byte
+
+	for q := 1; q <= 99; q++ {
+		scale := qualityScale(q)
+
+		scaleQuantizationTable(tmp[:], &baseLumaQuantizationTable, scale)
+		if tmp != [64]byte(luma) {
+			continue
+		}
+
+		scaleQuantizationTable(tmp[:], &baseChromaQuantizationTable, scale)
+		if tmp == [64]byte(chroma) {
+			return q, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,44 @@
+package jpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeQuantizationTables(t *testing.T) {
+	luma, chroma := MakeQuantizationTables(50)
+	require.Equal(t, baseLumaQuantizationTable[:], luma)
+	require.Equal(t, baseChromaQuantizationTable[:], chroma)
+
+	luma, chroma = MakeQuantizationTables(90)
+	require.Equal(t, []byte{
+		3, 2, 2, 3, 2, 2, 3, 3,
+		3, 3, 4, 3, 3, 4, 5, 8,
+		5, 5, 4, 4, 5, 10, 7, 7,
+		6, 8, 12, 10, 12, 12, 11, 10,
+		11, 11, 13, 14, 18, 16, 13, 14,
+		17, 14, 11, 11, 16, 22, 16, 17,
+		19, 20, 21, 21, 21, 12, 15, 23,
+		24, 22, 20, 24, 18, 20, 21, 20,
+	}, luma)
+	require.Equal(t, byte(3), chroma[0])
+	require.Equal(t, byte(20), chroma[63])
+
+	luma, _ = MakeQuantizationTables(0)
+	require.Equal(t, byte(255), luma[63])
+}
+
+func TestQuantizationTablesQuality(t *testing.T) {
+	for q := 1; q <= 99; q++ {
+		luma, chroma := MakeQuantizationTables(q)
+		q2, ok := QuantizationTablesQuality(luma, chroma)
+		require.True(t, ok)
+		require.Equal(t, q, q2)
+	}
+
+	luma, chroma := MakeQuantizationTables(50)
+	luma[0]++
+	_, ok := QuantizationTablesQuality(luma, chroma)
+	require.False(t, ok)
+}
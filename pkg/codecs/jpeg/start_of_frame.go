@@ -0,0 +1,94 @@
+package jpeg
+
+import (
+	"fmt"
+)
+
+// FrameComponent is a component of a SOF marker.
+type FrameComponent struct {
+	ID                  uint8
+	HorizontalSampling  uint8
+	VerticalSampling    uint8
+	QuantizationTableID uint8
+}
+
+// StartOfFrame is a SOF0, SOF1, SOF2 or SOF3 marker.
+// Specification: ITU-T Rec. T.81, B.2.2
+type StartOfFrame struct {
+	Marker     uint8
+	Precision  uint8
+	Height     int
+	Width      int
+	Components []FrameComponent
+}
+
+// Unmarshal decodes the marker.
+// Marker is not filled, since it precedes the marker content.
+func (m *StartOfFrame) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("image is too short")
+	}
+
+	// 8 and 12 bits are used by DCT-based modes,
+	// from 2 to 16 bits are used by the lossless mode.
+	m.Precision = buf[0]
+	if m.Precision < 2 || m.Precision > 16 {
+		return fmt.Errorf("precision %d is not supported", m.Precision)
+	}
+
+	m.Height = int(buf[1])<<8 | int(buf[2])
+	m.Width = int(buf[3])<<8 | int(buf[4])
+
+	if m.Width == 0 {
+		return fmt.Errorf("invalid width")
+	}
+
+	componentCount := int(buf[5])
+	if componentCount == 0 || componentCount > 4 {
+		return fmt.Errorf("number of components = %d is not supported", componentCount)
+	}
+
+	if len(buf) != (6 + componentCount*3) {
+		return fmt.Errorf("unsupported SOF size of %d", len(buf))
+	}
+
+	m.Components = make([]FrameComponent, componentCount)
+
+	for i := range m.Components {
+		c := buf[6+i*3:]
+
+		m.Components[i] = FrameComponent{
+			ID:                  c[0],
+			HorizontalSampling:  c[1] >> 4,
+			VerticalSampling:    c[1] & 0x0F,
+			QuantizationTableID: c[2],
+		}
+
+		if m.Components[i].HorizontalSampling < 1 || m.Components[i].HorizontalSampling > 4 ||
+			m.Components[i].VerticalSampling < 1 || m.Components[i].VerticalSampling > 4 {
+			return fmt.Errorf("invalid sampling factors of component %d", i)
+		}
+	}
+
+	return nil
+}
+
+// Progressive returns whether the frame uses the progressive DCT mode.
+func (m StartOfFrame) Progressive() bool {
+	return m.Marker == MarkerStartOfFrameProgressive
+}
+
+// Marshal encodes the marker.
+func (m StartOfFrame) Marshal(buf []byte) []byte {
+	buf = append(buf, []byte{0xFF, m.Marker}...)
+	s := 8 + len(m.Components)*3
+	buf = append(buf, []byte{byte(s >> 8), byte(s)}...) // length
+	buf = append(buf, []byte{m.Precision}...)
+	buf = append(buf, []byte{byte(m.Height >> 8), byte(m.Height)}...)
+	buf = append(buf, []byte{byte(m.Width >> 8), byte(m.Width)}...)
+	buf = append(buf, []byte{byte(len(m.Components))}...)
+	for _, c := range m.Components {
+		buf = append(buf, []byte{c.ID, c.HorizontalSampling<<4 | c.VerticalSampling, c.QuantizationTableID}...)
+	}
+	return buf
+}
@@ -0,0 +1,100 @@
+package jpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStartOfFrame = []struct {
+	name string
+	enc  []byte
+	dec  StartOfFrame
+}{
+	{
+		"baseline 4:2:2",
+		[]byte{
+			0xff, 0xc0, 0x0, 0x11, 0x8, 0x2, 0x58, 0x3,
+			0x20, 0x3, 0x1, 0x21, 0x0, 0x2, 0x11, 0x1,
+			0x3, 0x11, 0x1,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrame1,
+			Precision: 8,
+			Height:    600,
+			Width:     800,
+			Components: []FrameComponent{
+				{ID: 1, HorizontalSampling: 2, VerticalSampling: 1},
+				{ID: 2, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+				{ID: 3, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+			},
+		},
+	},
+	{
+		"extended 12-bit 4:4:4",
+		[]byte{
+			0xff, 0xc1, 0x0, 0x11, 0xc, 0x0, 0x10, 0x0,
+			0x20, 0x3, 0x1, 0x11, 0x0, 0x2, 0x11, 0x1,
+			0x3, 0x11, 0x1,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrameExtended,
+			Precision: 12,
+			Height:    16,
+			Width:     32,
+			Components: []FrameComponent{
+				{ID: 1, HorizontalSampling: 1, VerticalSampling: 1},
+				{ID: 2, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+				{ID: 3, HorizontalSampling: 1, VerticalSampling: 1, QuantizationTableID: 1},
+			},
+		},
+	},
+	{
+		"progressive grayscale",
+		[]byte{
+			0xff, 0xc2, 0x0, 0xb, 0x8, 0x1, 0xe0, 0x2,
+			0x80, 0x1, 0x1, 0x11, 0x0,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrameProgressive,
+			Precision: 8,
+			Height:    480,
+			Width:     640,
+			Components: []FrameComponent{
+				{ID: 1, HorizontalSampling: 1, VerticalSampling: 1},
+			},
+		},
+	},
+}
+
+func TestStartOfFrameUnmarshal(t *testing.T) {
+	for _, ca := range casesStartOfFrame {
+		t.Run(ca.name, func(t *testing.T) {
+			var h StartOfFrame
+			err := h.Unmarshal(ca.enc[4:])
+			require.NoError(t, err)
+			h.Marker = ca.enc[1]
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestStartOfFrameMarshal(t *testing.T) {
+	for _, ca := range casesStartOfFrame {
+		t.Run(ca.name, func(t *testing.T) {
+			byts := ca.dec.Marshal(nil)
+			require.Equal(t, ca.enc, byts)
+		})
+	}
+}
+
+func FuzzStartOfFrameUnmarshal(f *testing.F) {
+	for _, ca := range casesStartOfFrame {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h StartOfFrame
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -4,6 +4,75 @@ import (
 	"fmt"
 )
 
+// ScanComponent is a component of a scan header.
+type ScanComponent struct {
+	ID        uint8
+	DCTableID uint8
+	ACTableID uint8
+}
+
+// ScanHeader is the content of a SOS marker.
+// Specification: ITU-T Rec. T.81, B.2.3
+type ScanHeader struct {
+	Components                  []ScanComponent
+	SpectralSelectionStart      uint8
+	SpectralSelectionEnd        uint8
+	SuccessiveApproximationHigh uint8
+	SuccessiveApproximationLow  uint8
+}
+
+// Unmarshal decodes the marker.
+func (m *ScanHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("image is too short")
+	}
+
+	componentCount := int(buf[0])
+	if componentCount == 0 || componentCount > 4 {
+		return fmt.Errorf("number of components = %d is not supported", componentCount)
+	}
+
+	if len(buf) != (4 + componentCount*2) {
+		return fmt.Errorf("unsupported SOS size of %d", len(buf))
+	}
+
+	m.Components = make([]ScanComponent, componentCount)
+
+	for i := range m.Components {
+		c := buf[1+i*2:]
+		m.Components[i] = ScanComponent{
+			ID:        c[0],
+			DCTableID: c[1] >> 4,
+			ACTableID: c[1] & 0x0F,
+		}
+	}
+
+	buf = buf[1+componentCount*2:]
+	m.SpectralSelectionStart = buf[0]
+	m.SpectralSelectionEnd = buf[1]
+	m.SuccessiveApproximationHigh = buf[2] >> 4
+	m.SuccessiveApproximationLow = buf[2] & 0x0F
+
+	return nil
+}
+
+// Marshal encodes the marker.
+func (m ScanHeader) Marshal(buf []byte) []byte {
+	buf = append(buf, []byte{0xFF, MarkerStartOfScan}...)
+	s := 6 + len(m.Components)*2
+	buf = append(buf, []byte{byte(s >> 8), byte(s)}...) // length
+	buf = append(buf, []byte{byte(len(m.Components))}...)
+	for _, c := range m.Components {
+		buf = append(buf, []byte{c.ID, c.DCTableID<<4 | c.ACTableID}...)
+	}
+	buf = append(buf, []byte{
+		m.SpectralSelectionStart,
+		m.SpectralSelectionEnd,
+		m.SuccessiveApproximationHigh<<4 | m.SuccessiveApproximationLow,
+	}...)
+	return buf
+}
+
 // StartOfScan is a SOS marker.
 type StartOfScan struct{}
 
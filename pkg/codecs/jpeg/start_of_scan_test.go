@@ -51,3 +51,72 @@ func FuzzStartOfScanUnmarshal(f *testing.F) {
 		h.Unmarshal(b) //nolint:errcheck
 	})
 }
+
+var casesScanHeader = []struct {
+	name string
+	enc  []byte
+	dec  ScanHeader
+}{
+	{
+		"baseline",
+		[]byte{
+			0xff, 0xda, 0x0, 0xc, 0x3, 0x1, 0x0, 0x2,
+			0x11, 0x3, 0x11, 0x0, 0x3f, 0x0,
+		},
+		ScanHeader{
+			Components: []ScanComponent{
+				{ID: 1},
+				{ID: 2, DCTableID: 1, ACTableID: 1},
+				{ID: 3, DCTableID: 1, ACTableID: 1},
+			},
+			SpectralSelectionEnd: 63,
+		},
+	},
+	{
+		"progressive refinement",
+		[]byte{
+			0xff, 0xda, 0x0, 0x8, 0x1, 0x1, 0x01, 0x1,
+			0x5, 0x21,
+		},
+		ScanHeader{
+			Components: []ScanComponent{
+				{ID: 1, ACTableID: 1},
+			},
+			SpectralSelectionStart:      1,
+			SpectralSelectionEnd:        5,
+			SuccessiveApproximationHigh: 2,
+			SuccessiveApproximationLow:  1,
+		},
+	},
+}
+
+func TestScanHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesScanHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h ScanHeader
+			err := h.Unmarshal(ca.enc[4:])
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestScanHeaderMarshal(t *testing.T) {
+	for _, ca := range casesScanHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			byts := ca.dec.Marshal(nil)
+			require.Equal(t, ca.enc, byts)
+		})
+	}
+}
+
+func FuzzScanHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesScanHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h ScanHeader
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
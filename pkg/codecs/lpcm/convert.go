@@ -0,0 +1,291 @@
+package lpcm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+func floatToInt32(v float32) int32 {
+	if v >= 1 {
+		return math.MaxInt32
+	}
+	if v <= -1 {
+		return math.MinInt32
+	}
+	return int32(v * 2147483648)
+}
+
+func int32ToFloat(v int32) float32 {
+	return float32(v) * (1.0 / 2147483648)
+}
+
+// decodeBlock decodes samples into left-justified 32-bit integers.
+func decodeBlock(dst []int32, src []byte, f Format) {
+	switch {
+	case f.Float:
+		if f.LittleEndian {
+			for i := range dst {
+				dst[i] = floatToInt32(math.Float32frombits(binary.LittleEndian.Uint32(src[i*4:])))
+			}
+		} else {
+			for i := range dst {
+				dst[i] = floatToInt32(math.Float32frombits(binary.BigEndian.Uint32(src[i*4:])))
+			}
+		}
+
+	case f.Unsigned:
+		for i := range dst {
+			dst[i] = int32(int8(src[i]^0x80)) << 24
+		}
+
+	case f.BitDepth == 8:
+		for i := range dst {
+			dst[i] = int32(int8(src[i])) << 24
+		}
+
+	case f.BitDepth == 16:
+		if f.LittleEndian {
+			for i := range dst {
+				dst[i] = int32(int16(binary.LittleEndian.Uint16(src[i*2:]))) << 16
+			}
+		} else {
+			for i := range dst {
+				dst[i] = int32(int16(binary.BigEndian.Uint16(src[i*2:]))) << 16
+			}
+		}
+
+	case f.BitDepth == 24:
+		if f.LittleEndian {
+			for i := range dst {
+				dst[i] = int32(uint32(src[i*3])<<8 | uint32(src[i*3+1])<<16 | uint32(src[i*3+2])<<24)
+			}
+		} else {
+			for i := range dst {
+				dst[i] = int32(uint32(src[i*3])<<24 | uint32(src[i*3+1])<<16 | uint32(src[i*3+2])<<8)
+			}
+		}
+
+	default:
+		if f.LittleEndian {
+			for i := range dst {
+				dst[i] = int32(binary.LittleEndian.Uint32(src[i*4:]))
+			}
+		} else {
+			for i := range dst {
+				dst[i] = int32(binary.BigEndian.Uint32(src[i*4:]))
+			}
+		}
+	}
+}
+
+// encodeBlock encodes left-justified 32-bit integers into samples.
+func encodeBlock(dst []byte, src []int32, f Format) {
+	switch {
+	case f.Float:
+		if f.LittleEndian {
+			for i, v := range src {
+				binary.LittleEndian.PutUint32(dst[i*4:], math.Float32bits(int32ToFloat(v)))
+			}
+		} else {
+			for i, v := range src {
+				binary.BigEndian.PutUint32(dst[i*4:], math.Float32bits(int32ToFloat(v)))
+			}
+		}
+
+	case f.Unsigned:
+		for i, v := range src {
+			dst[i] = byte(v>>24) ^ 0x80
+		}
+
+	case f.BitDepth == 8:
+		for i, v := range src {
+			dst[i] = byte(v >> 24)
+		}
+
+	case f.BitDepth == 16:
+		if f.LittleEndian {
+			for i, v := range src {
+				binary.LittleEndian.PutUint16(dst[i*2:], uint16(v>>16))
+			}
+		} else {
+			for i, v := range src {
+				binary.BigEndian.PutUint16(dst[i*2:], uint16(v>>16))
+			}
+		}
+
+	case f.BitDepth == 24:
+		if f.LittleEndian {
+			for i, v := range src {
+				dst[i*3] = byte(v >> 8)
+				dst[i*3+1] = byte(v >> 16)
+				dst[i*3+2] = byte(v >> 24)
+			}
+		} else {
+			for i, v := range src {
+				dst[i*3] = byte(v >> 24)
+				dst[i*3+1] = byte(v >> 16)
+				dst[i*3+2] = byte(v >> 8)
+			}
+		}
+
+	default:
+		if f.LittleEndian {
+			for i, v := range src {
+				binary.LittleEndian.PutUint32(dst[i*4:], uint32(v))
+			}
+		} else {
+			for i, v := range src {
+				binary.BigEndian.PutUint32(dst[i*4:], uint32(v))
+			}
+		}
+	}
+}
+
+func checkSizes(srcLen int, srcSampleSize int, dstLen int, dstSampleSize int) (int, error) {
+	if (srcLen % srcSampleSize) != 0 {
+		return 0, fmt.Errorf("source size is not a multiple of sample size")
+	}
+
+	n := srcLen / srcSampleSize
+
+	if dstLen < (n * dstSampleSize) {
+		return 0, fmt.Errorf("destination is too small: got %d, needed %d", dstLen, n*dstSampleSize)
+	}
+
+	return n, nil
+}
+
+// Convert converts samples from a format into another.
+// Samples are converted by truncation when bit depth decreases,
+// and float samples are clamped into the [-1, 1) range,
+// unless they are converted into floats.
+// It returns the number of bytes written into dst.
+func Convert(dst []byte, dstFormat Format, src []byte, srcFormat Format) (int, error) {
+	err := srcFormat.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	err = dstFormat.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	srcSampleSize := srcFormat.SampleSize()
+	dstSampleSize := dstFormat.SampleSize()
+
+	n, err := checkSizes(len(src), srcSampleSize, len(dst), dstSampleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if srcFormat == dstFormat {
+		return copy(dst, src), nil
+	}
+
+	// float samples that differ in endianness only are swapped,
+	// in order to preserve their precision and range.
+	if srcFormat.Float && dstFormat.Float {
+		for i := 0; i < n*4; i += 4 {
+			dst[i], dst[i+1], dst[i+2], dst[i+3] = src[i+3], src[i+2], src[i+1], src[i]
+		}
+		return n * 4, nil
+	}
+
+	var block [blockSize]int32
+
+	for i := 0; i < n; i += blockSize {
+		l := min(blockSize, n-i)
+		decodeBlock(block[:l], src[i*srcSampleSize:], srcFormat)
+		encodeBlock(dst[i*dstSampleSize:], block[:l], dstFormat)
+	}
+
+	return n * dstSampleSize, nil
+}
+
+// ToFloat32 converts samples into floats in the [-1, 1) range.
+// It returns the number of samples written into dst.
+func ToFloat32(dst []float32, src []byte, srcFormat Format) (int, error) {
+	err := srcFormat.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	srcSampleSize := srcFormat.SampleSize()
+
+	n, err := checkSizes(len(src), srcSampleSize, len(dst), 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if srcFormat.Float {
+		if srcFormat.LittleEndian {
+			for i := range dst[:n] {
+				dst[i] = math.Float32frombits(binary.LittleEndian.Uint32(src[i*4:]))
+			}
+		} else {
+			for i := range dst[:n] {
+				dst[i] = math.Float32frombits(binary.BigEndian.Uint32(src[i*4:]))
+			}
+		}
+		return n, nil
+	}
+
+	var block [blockSize]int32
+
+	for i := 0; i < n; i += blockSize {
+		l := min(blockSize, n-i)
+		decodeBlock(block[:l], src[i*srcSampleSize:], srcFormat)
+
+		for j, v := range block[:l] {
+			dst[i+j] = int32ToFloat(v)
+		}
+	}
+
+	return n, nil
+}
+
+// FromFloat32 converts floats in the [-1, 1) range into samples.
+// Values outside the range are clamped.
+// It returns the number of bytes written into dst.
+func FromFloat32(dst []byte, dstFormat Format, src []float32) (int, error) {
+	err := dstFormat.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	dstSampleSize := dstFormat.SampleSize()
+	n := len(src)
+
+	if len(dst) < (n * dstSampleSize) {
+		return 0, fmt.Errorf("destination is too small: got %d, needed %d", len(dst), n*dstSampleSize)
+	}
+
+	if dstFormat.Float {
+		if dstFormat.LittleEndian {
+			for i, v := range src {
+				binary.LittleEndian.PutUint32(dst[i*4:], math.Float32bits(v))
+			}
+		} else {
+			for i, v := range src {
+				binary.BigEndian.PutUint32(dst[i*4:], math.Float32bits(v))
+			}
+		}
+		return n * 4, nil
+	}
+
+	var block [blockSize]int32
+
+	for i := 0; i < n; i += blockSize {
+		l := min(blockSize, n-i)
+
+		for j, v := range src[i : i+l] {
+			block[j] = floatToInt32(v)
+		}
+
+		encodeBlock(dst[i*dstSampleSize:], block[:l], dstFormat)
+	}
+
+	return n * dstSampleSize, nil
+}
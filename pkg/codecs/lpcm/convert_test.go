@@ -0,0 +1,191 @@
+package lpcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConvert = []struct {
+	name      string
+	srcFormat Format
+	src       []byte
+	dstFormat Format
+	dst       []byte
+}{
+	{
+		"16 be to 16 le",
+		Format{BitDepth: 16},
+		[]byte{0x12, 0x34, 0x80, 0x00},
+		Format{BitDepth: 16, LittleEndian: true},
+		[]byte{0x34, 0x12, 0x00, 0x80},
+	},
+	{
+		"16 be to 24 le",
+		Format{BitDepth: 16},
+		[]byte{0x12, 0x34, 0x80, 0x00},
+		Format{BitDepth: 24, LittleEndian: true},
+		[]byte{0x00, 0x34, 0x12, 0x00, 0x00, 0x80},
+	},
+	{
+		"24 le to 16 be",
+		Format{BitDepth: 24, LittleEndian: true},
+		[]byte{0x56, 0x34, 0x12, 0xff, 0xff, 0xff},
+		Format{BitDepth: 16},
+		[]byte{0x12, 0x34, 0xff, 0xff},
+	},
+	{
+		"16 le to 8",
+		Format{BitDepth: 16, LittleEndian: true},
+		[]byte{0x34, 0x12, 0x00, 0x80},
+		Format{BitDepth: 8},
+		[]byte{0x12, 0x80},
+	},
+	{
+		"8 to 32 be",
+		Format{BitDepth: 8},
+		[]byte{0x12, 0x80},
+		Format{BitDepth: 32},
+		[]byte{0x12, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x00},
+	},
+	{
+		"16 be to float le",
+		Format{BitDepth: 16},
+		[]byte{0x40, 0x00, 0x80, 0x00},
+		Format{BitDepth: 32, Float: true, LittleEndian: true},
+		[]byte{0x00, 0x00, 0x00, 0x3f, 0x00, 0x00, 0x80, 0xbf},
+	},
+	{
+		"float be to 16 le",
+		Format{BitDepth: 32, Float: true},
+		[]byte{0x3f, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00},
+		Format{BitDepth: 16, LittleEndian: true},
+		[]byte{0x00, 0x40, 0xff, 0x7f},
+	},
+	{
+		"float be to float le",
+		Format{BitDepth: 32, Float: true},
+		[]byte{0x2b, 0x8c, 0xbc, 0xcc, 0x3f, 0xc0, 0x00, 0x00},
+		Format{BitDepth: 32, Float: true, LittleEndian: true},
+		[]byte{0xcc, 0xbc, 0x8c, 0x2b, 0x00, 0x00, 0xc0, 0x3f},
+	},
+	{
+		"8 unsigned to 16 be",
+		Format{BitDepth: 8, Unsigned: true},
+		[]byte{0x80, 0x00, 0xff, 0x92},
+		Format{BitDepth: 16},
+		[]byte{0x00, 0x00, 0x80, 0x00, 0x7f, 0x00, 0x12, 0x00},
+	},
+	{
+		"16 le to 8 unsigned",
+		Format{BitDepth: 16, LittleEndian: true},
+		[]byte{0x34, 0x12, 0x00, 0x80},
+		Format{BitDepth: 8, Unsigned: true},
+		[]byte{0x92, 0x00},
+	},
+	{
+		"8 to 8 unsigned",
+		Format{BitDepth: 8},
+		[]byte{0x00, 0x80, 0x7f},
+		Format{BitDepth: 8, Unsigned: true},
+		[]byte{0x80, 0x00, 0xff},
+	},
+}
+
+func TestConvert(t *testing.T) {
+	for _, ca := range casesConvert {
+		t.Run(ca.name, func(t *testing.T) {
+			dst := make([]byte, len(ca.dst))
+			n, err := Convert(dst, ca.dstFormat, ca.src, ca.srcFormat)
+			require.NoError(t, err)
+			require.Equal(t, ca.dst, dst[:n])
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	_, err := Convert(make([]byte, 4), Format{BitDepth: 16}, []byte{1, 2}, Format{BitDepth: 12})
+	require.EqualError(t, err, "unsupported bit depth: 12")
+
+	_, err = Convert(make([]byte, 4), Format{BitDepth: 16, Float: true}, []byte{1, 2}, Format{BitDepth: 16})
+	require.EqualError(t, err, "float samples must have a bit depth of 32")
+
+	_, err = Convert(make([]byte, 4), Format{BitDepth: 16, Unsigned: true}, []byte{1, 2}, Format{BitDepth: 16})
+	require.EqualError(t, err, "unsigned samples must have a bit depth of 8")
+
+	_, err = Convert(make([]byte, 4), Format{BitDepth: 16}, []byte{1, 2, 3}, Format{BitDepth: 16})
+	require.EqualError(t, err, "source size is not a multiple of sample size")
+
+	_, err = Convert(make([]byte, 4), Format{BitDepth: 24}, []byte{1, 2, 3, 4}, Format{BitDepth: 16})
+	require.EqualError(t, err, "destination is too small: got 4, needed 6")
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	src := make([]byte, 2*1000)
+	for i := range src {
+		src[i] = byte(i * 7)
+	}
+
+	for _, f := range []Format{
+		{BitDepth: 16, LittleEndian: true},
+		{BitDepth: 24},
+		{BitDepth: 24, LittleEndian: true},
+		{BitDepth: 32},
+		{BitDepth: 32, Float: true},
+		{BitDepth: 32, Float: true, LittleEndian: true},
+	} {
+		tmp := make([]byte, len(src)/2*f.SampleSize())
+		_, err := Convert(tmp, f, src, Format{BitDepth: 16})
+		require.NoError(t, err)
+
+		dst := make([]byte, len(src))
+		_, err = Convert(dst, Format{BitDepth: 16}, tmp, f)
+		require.NoError(t, err)
+		require.Equal(t, src, dst)
+	}
+}
+
+func TestFloat32RoundTrip(t *testing.T) {
+	src := []byte{0x00, 0x00, 0x40, 0x00, 0xc0, 0x00, 0x7f, 0xff, 0x80, 0x00}
+
+	floats := make([]float32, 5)
+	n, err := ToFloat32(floats, src, Format{BitDepth: 16})
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, []float32{0, 0.5, -0.5, 32767.0 / 32768, -1}, floats)
+
+	dst := make([]byte, len(src))
+	n, err = FromFloat32(dst, Format{BitDepth: 16}, floats)
+	require.NoError(t, err)
+	require.Equal(t, len(src), n)
+	require.Equal(t, src, dst)
+}
+
+func TestFromFloat32Clamp(t *testing.T) {
+	dst := make([]byte, 4)
+	_, err := FromFloat32(dst, Format{BitDepth: 16}, []float32{2, -2})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x7f, 0xff, 0x80, 0x00}, dst)
+}
+
+func TestConvertNoAllocs(t *testing.T) {
+	src := make([]byte, 2*4096)
+	dst := make([]byte, 3*4096)
+	floats := make([]float32, 4096)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		Convert(dst, Format{BitDepth: 24, LittleEndian: true}, src, Format{BitDepth: 16}) //nolint:errcheck
+		ToFloat32(floats, src, Format{BitDepth: 16})                                      //nolint:errcheck
+		FromFloat32(src, Format{BitDepth: 16}, floats)                                    //nolint:errcheck
+	})
+	require.Equal(t, float64(0), allocs)
+}
+
+func BenchmarkConvert(b *testing.B) {
+	src := make([]byte, 2*4096)
+	dst := make([]byte, 3*4096)
+
+	for n := 0; n < b.N; n++ {
+		Convert(dst, Format{BitDepth: 24, LittleEndian: true}, src, Format{BitDepth: 16}) //nolint:errcheck
+	}
+}
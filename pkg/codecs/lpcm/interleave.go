@@ -0,0 +1,111 @@
+package lpcm
+
+import (
+	"fmt"
+)
+
+// Interleave merges per-channel samples into interleaved samples.
+// All channels must have the same size.
+// It returns the number of bytes written into dst.
+func Interleave(dst []byte, channels [][]byte, sampleSize int) (int, error) {
+	if len(channels) == 0 {
+		return 0, fmt.Errorf("no channels provided")
+	}
+
+	channelSize := len(channels[0])
+	if (channelSize % sampleSize) != 0 {
+		return 0, fmt.Errorf("channel size is not a multiple of sample size")
+	}
+
+	for _, ch := range channels[1:] {
+		if len(ch) != channelSize {
+			return 0, fmt.Errorf("channels have different sizes")
+		}
+	}
+
+	channelCount := len(channels)
+	n := channelSize * channelCount
+
+	if len(dst) < n {
+		return 0, fmt.Errorf("destination is too small: got %d, needed %d", len(dst), n)
+	}
+
+	frameSize := sampleSize * channelCount
+
+	switch sampleSize {
+	case 1:
+		for c, ch := range channels {
+			for i, v := range ch {
+				dst[i*channelCount+c] = v
+			}
+		}
+
+	case 2:
+		for c, ch := range channels {
+			for i := 0; i < channelSize; i += 2 {
+				pos := (i/2)*frameSize + c*2
+				dst[pos] = ch[i]
+				dst[pos+1] = ch[i+1]
+			}
+		}
+
+	default:
+		for c, ch := range channels {
+			for i := 0; i < channelSize; i += sampleSize {
+				copy(dst[(i/sampleSize)*frameSize+c*sampleSize:], ch[i:i+sampleSize])
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// Deinterleave splits interleaved samples into per-channel samples.
+// It returns the number of bytes written into each channel.
+func Deinterleave(channels [][]byte, src []byte, sampleSize int) (int, error) {
+	if len(channels) == 0 {
+		return 0, fmt.Errorf("no channels provided")
+	}
+
+	channelCount := len(channels)
+	frameSize := sampleSize * channelCount
+
+	if (len(src) % frameSize) != 0 {
+		return 0, fmt.Errorf("source size is not a multiple of frame size")
+	}
+
+	n := len(src) / channelCount
+
+	for _, ch := range channels {
+		if len(ch) < n {
+			return 0, fmt.Errorf("destination is too small: got %d, needed %d", len(ch), n)
+		}
+	}
+
+	switch sampleSize {
+	case 1:
+		for c, ch := range channels {
+			for i := range ch[:n] {
+				ch[i] = src[i*channelCount+c]
+			}
+		}
+
+	case 2:
+		for c, ch := range channels {
+			for i := 0; i < n; i += 2 {
+				pos := (i/2)*frameSize + c*2
+				ch[i] = src[pos]
+				ch[i+1] = src[pos+1]
+			}
+		}
+
+	default:
+		for c, ch := range channels {
+			for i := 0; i < n; i += sampleSize {
+				copy(ch[i:i+sampleSize], src[(i/sampleSize)*frameSize+c*sampleSize:])
+			}
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,77 @@
+package lpcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesInterleave = []struct {
+	name        string
+	sampleSize  int
+	channels    [][]byte
+	interleaved []byte
+}{
+	{
+		"8 bit stereo",
+		1,
+		[][]byte{{1, 2, 3}, {4, 5, 6}},
+		[]byte{1, 4, 2, 5, 3, 6},
+	},
+	{
+		"16 bit stereo",
+		2,
+		[][]byte{{1, 2, 3, 4}, {5, 6, 7, 8}},
+		[]byte{1, 2, 5, 6, 3, 4, 7, 8},
+	},
+	{
+		"24 bit 3 channels",
+		3,
+		[][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	},
+	{
+		"32 bit mono",
+		4,
+		[][]byte{{1, 2, 3, 4, 5, 6, 7, 8}},
+		[]byte{1, 2, 3, 4, 5, 6, 7, 8},
+	},
+}
+
+func TestInterleave(t *testing.T) {
+	for _, ca := range casesInterleave {
+		t.Run(ca.name, func(t *testing.T) {
+			dst := make([]byte, len(ca.interleaved))
+			n, err := Interleave(dst, ca.channels, ca.sampleSize)
+			require.NoError(t, err)
+			require.Equal(t, ca.interleaved, dst[:n])
+		})
+	}
+}
+
+func TestDeinterleave(t *testing.T) {
+	for _, ca := range casesInterleave {
+		t.Run(ca.name, func(t *testing.T) {
+			channels := make([][]byte, len(ca.channels))
+			for i := range channels {
+				channels[i] = make([]byte, len(ca.channels[i]))
+			}
+
+			n, err := Deinterleave(channels, ca.interleaved, ca.sampleSize)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.channels[0]), n)
+			require.Equal(t, ca.channels, channels)
+		})
+	}
+}
+
+func TestInterleaveErrors(t *testing.T) {
+	_, err := Interleave(make([]byte, 8), [][]byte{{1, 2}, {3}}, 1)
+	require.EqualError(t, err, "channels have different sizes")
+
+	_, err = Interleave(make([]byte, 2), [][]byte{{1, 2}, {3, 4}}, 2)
+	require.EqualError(t, err, "destination is too small: got 2, needed 4")
+
+	_, err = Deinterleave([][]byte{make([]byte, 2), make([]byte, 2)}, []byte{1, 2, 3}, 1)
+	require.EqualError(t, err, "source size is not a multiple of frame size")
+}
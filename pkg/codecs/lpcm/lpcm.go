@@ -0,0 +1,51 @@
+// Package lpcm contains utilities to work with the LPCM codec.
+package lpcm
+
+import (
+	"fmt"
+)
+
+// number of samples processed at once by conversion routines.
+// Blocks are allocated on the stack.
+const blockSize = 256
+
+// Format is a sample format.
+type Format struct {
+	// bit depth of each sample.
+	// It can be 8, 16, 24 or 32.
+	BitDepth int
+
+	// whether samples are 32-bit IEEE 754 floats instead of signed integers.
+	Float bool
+
+	// whether samples are unsigned integers instead of signed integers.
+	// It can be used with a bit depth of 8 only (i.e. 8-bit WAV).
+	Unsigned bool
+
+	// whether samples are little endian instead of big endian.
+	LittleEndian bool
+}
+
+// SampleSize returns the size of a sample in bytes.
+func (f Format) SampleSize() int {
+	return f.BitDepth / 8
+}
+
+// Validate checks that the format is supported.
+func (f Format) Validate() error {
+	switch f.BitDepth {
+	case 8, 16, 24, 32:
+	default:
+		return fmt.Errorf("unsupported bit depth: %d", f.BitDepth)
+	}
+
+	if f.Float && f.BitDepth != 32 {
+		return fmt.Errorf("float samples must have a bit depth of 32")
+	}
+
+	if f.Unsigned && (f.Float || f.BitDepth != 8) {
+		return fmt.Errorf("unsigned samples must have a bit depth of 8")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package lpcm
+
+import (
+	"fmt"
+	"math"
+)
+
+// Matrix is a channel remixing matrix.
+// Matrix[o][i] is the gain applied to input channel i
+// when computing output channel o.
+type Matrix [][]float32
+
+// DefaultMatrix returns a matrix that converts inChannelCount channels into outChannelCount channels.
+// Channels are supposed to follow the WAVE order (L, R, C, LFE, Ls, Rs).
+// Supported conversions are:
+//   - conversions between the same channel count
+//   - upmix from mono
+//   - upmix by adding silent channels
+//   - downmix from stereo and 5.1 into stereo and mono
+func DefaultMatrix(inChannelCount int, outChannelCount int) (Matrix, error) {
+	if inChannelCount <= 0 || outChannelCount <= 0 {
+		return nil, fmt.Errorf("invalid channel count")
+	}
+
+	m := make(Matrix, outChannelCount)
+	for o := range m {
+		m[o] = make([]float32, inChannelCount)
+	}
+
+	switch {
+	case inChannelCount == 1:
+		m[0][0] = 1
+		if outChannelCount >= 2 {
+			m[1][0] = 1
+		}
+
+	case inChannelCount <= outChannelCount:
+		for i := 0; i < inChannelCount; i++ {
+			m[i][i] = 1
+		}
+
+	case inChannelCount == 2 && outChannelCount == 1:
+		m[0][0] = 0.5
+		m[0][1] = 0.5
+
+	case inChannelCount == 6 && outChannelCount <= 2:
+		// ITU-R BS.775 downmix, normalized to avoid clipping
+		// and without LFE.
+		const (
+			front = 1 / (1 + 2*math.Sqrt2/2)
+			other = (math.Sqrt2 / 2) / (1 + 2*math.Sqrt2/2)
+		)
+
+		if outChannelCount == 2 {
+			m[0][0] = front
+			m[0][2] = other
+			m[0][4] = other
+			m[1][1] = front
+			m[1][2] = other
+			m[1][5] = other
+		} else {
+			m[0][0] = front / 2
+			m[0][1] = front / 2
+			m[0][2] = other
+			m[0][4] = other / 2
+			m[0][5] = other / 2
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %d to %d channels", inChannelCount, outChannelCount)
+	}
+
+	return m, nil
+}
+
+// Remix applies a matrix to interleaved float samples.
+// It returns the number of samples written into dst.
+func Remix(dst []float32, src []float32, m Matrix) (int, error) {
+	outChannelCount := len(m)
+	if outChannelCount == 0 {
+		return 0, fmt.Errorf("invalid matrix")
+	}
+
+	inChannelCount := len(m[0])
+	if inChannelCount == 0 {
+		return 0, fmt.Errorf("invalid matrix")
+	}
+
+	for _, row := range m[1:] {
+		if len(row) != inChannelCount {
+			return 0, fmt.Errorf("invalid matrix")
+		}
+	}
+
+	if (len(src) % inChannelCount) != 0 {
+		return 0, fmt.Errorf("source size is not a multiple of channel count")
+	}
+
+	frameCount := len(src) / inChannelCount
+	n := frameCount * outChannelCount
+
+	if len(dst) < n {
+		return 0, fmt.Errorf("destination is too small: got %d, needed %d", len(dst), n)
+	}
+
+	for f := 0; f < frameCount; f++ {
+		in := src[f*inChannelCount : (f+1)*inChannelCount]
+		out := dst[f*outChannelCount : (f+1)*outChannelCount]
+
+		for o, row := range m {
+			var v float32
+			for i, gain := range row {
+				v += in[i] * gain
+			}
+			out[o] = v
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package lpcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemix(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		in   int
+		out  int
+		src  []float32
+		dst  []float32
+	}{
+		{
+			"mono to stereo",
+			1,
+			2,
+			[]float32{0.5, -0.25},
+			[]float32{0.5, 0.5, -0.25, -0.25},
+		},
+		{
+			"stereo to mono",
+			2,
+			1,
+			[]float32{0.5, 0.25, -0.5, -0.25},
+			[]float32{0.375, -0.375},
+		},
+		{
+			"stereo to 5.1",
+			2,
+			6,
+			[]float32{0.5, 0.25},
+			[]float32{0.5, 0.25, 0, 0, 0, 0},
+		},
+		{
+			"5.1 to stereo",
+			6,
+			2,
+			[]float32{1, 0, 1, 1, 1, 0},
+			[]float32{1, 0.2928932},
+		},
+		{
+			"5.1 to mono",
+			6,
+			1,
+			[]float32{1, 1, 1, 1, 1, 1},
+			[]float32{1},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			m, err := DefaultMatrix(ca.in, ca.out)
+			require.NoError(t, err)
+
+			dst := make([]float32, len(ca.dst))
+			n, err := Remix(dst, ca.src, m)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.dst), n)
+			require.InDeltaSlice(t, ca.dst, dst, 0.00001)
+		})
+	}
+}
+
+func TestDefaultMatrixUnsupported(t *testing.T) {
+	_, err := DefaultMatrix(4, 2)
+	require.EqualError(t, err, "unsupported conversion from 4 to 2 channels")
+}
+
+func TestRemixNoAllocs(t *testing.T) {
+	m, err := DefaultMatrix(6, 2)
+	require.NoError(t, err)
+
+	src := make([]float32, 6*1024)
+	dst := make([]float32, 2*1024)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		Remix(dst, src, m) //nolint:errcheck
+	})
+	require.Equal(t, float64(0), allocs)
+}
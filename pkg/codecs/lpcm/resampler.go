@@ -0,0 +1,109 @@
+package lpcm
+
+import (
+	"fmt"
+)
+
+// Resampler is a sample rate converter that uses linear interpolation.
+// It works on interleaved float samples and keeps state between calls,
+// in order to process a continuous stream split into chunks.
+type Resampler struct {
+	InputSampleRate  int
+	OutputSampleRate int
+	ChannelCount     int
+
+	// previous input frame.
+	prev    []float32
+	hasPrev bool
+
+	// position of the next output frame, relative to prev,
+	// in units of 1/OutputSampleRate input frames.
+	pos int64
+}
+
+// Initialize initializes a Resampler.
+func (r *Resampler) Initialize() error {
+	if r.InputSampleRate <= 0 || r.OutputSampleRate <= 0 {
+		return fmt.Errorf("invalid sample rate")
+	}
+
+	if r.ChannelCount <= 0 {
+		return fmt.Errorf("invalid channel count")
+	}
+
+	r.prev = make([]float32, r.ChannelCount)
+	r.hasPrev = false
+
+	return nil
+}
+
+// MaxOutputSize returns the maximum number of samples
+// that can be produced from inputSize samples.
+func (r *Resampler) MaxOutputSize(inputSize int) int {
+	inFrames := int64(inputSize / r.ChannelCount)
+	outFrames := (inFrames*int64(r.OutputSampleRate))/int64(r.InputSampleRate) + 2
+	return int(outFrames) * r.ChannelCount
+}
+
+// Resample converts interleaved float samples.
+// dst must be at least MaxOutputSize(len(src)) long.
+// Output frames that depend on the next chunk are produced in the next call.
+// It returns the number of samples written into dst.
+func (r *Resampler) Resample(dst []float32, src []float32) (int, error) {
+	if (len(src) % r.ChannelCount) != 0 {
+		return 0, fmt.Errorf("source size is not a multiple of channel count")
+	}
+
+	if len(dst) < r.MaxOutputSize(len(src)) {
+		return 0, fmt.Errorf("destination is too small: got %d, needed %d",
+			len(dst), r.MaxOutputSize(len(src)))
+	}
+
+	inFrames := int64(len(src) / r.ChannelCount)
+	if inFrames == 0 {
+		return 0, nil
+	}
+
+	inRate := int64(r.InputSampleRate)
+	outRate := int64(r.OutputSampleRate)
+	cc := r.ChannelCount
+
+	if !r.hasPrev {
+		// start exactly at the first input frame
+		copy(r.prev, src[:cc])
+		r.pos = outRate
+		r.hasPrev = true
+	}
+
+	n := 0
+
+	for {
+		// index of the left frame, where -1 is prev
+		idx := r.pos/outRate - 1
+		if idx+1 >= inFrames {
+			break
+		}
+
+		frac := float32(r.pos%outRate) / float32(outRate)
+
+		var left []float32
+		if idx < 0 {
+			left = r.prev
+		} else {
+			left = src[idx*int64(cc) : (idx+1)*int64(cc)]
+		}
+		right := src[(idx+1)*int64(cc) : (idx+2)*int64(cc)]
+
+		for c := 0; c < cc; c++ {
+			dst[n+c] = left[c] + (right[c]-left[c])*frac
+		}
+		n += cc
+
+		r.pos += inRate
+	}
+
+	copy(r.prev, src[(inFrames-1)*int64(cc):])
+	r.pos -= inFrames * outRate
+
+	return n, nil
+}
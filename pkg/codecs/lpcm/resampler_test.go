@@ -0,0 +1,99 @@
+package lpcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResamplerUpsample(t *testing.T) {
+	r := &Resampler{
+		InputSampleRate:  8000,
+		OutputSampleRate: 16000,
+		ChannelCount:     1,
+	}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	dst := make([]float32, r.MaxOutputSize(4))
+	n, err := r.Resample(dst, []float32{0, 1, 0, -1})
+	require.NoError(t, err)
+	require.Equal(t, []float32{0, 0.5, 1, 0.5, 0, -0.5}, dst[:n])
+
+	// the last input frame is used when the next chunk is received
+	dst = make([]float32, r.MaxOutputSize(2))
+	n, err = r.Resample(dst, []float32{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, []float32{-1, -0.5, 0, 0.5}, dst[:n])
+}
+
+func TestResamplerDownsampleStereo(t *testing.T) {
+	r := &Resampler{
+		InputSampleRate:  48000,
+		OutputSampleRate: 16000,
+		ChannelCount:     2,
+	}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	dst := make([]float32, r.MaxOutputSize(12))
+	n, err := r.Resample(dst, []float32{
+		0, 10,
+		1, 11,
+		2, 12,
+		3, 13,
+		4, 14,
+		5, 15,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []float32{0, 10, 3, 13}, dst[:n])
+}
+
+func TestResamplerChunks(t *testing.T) {
+	src := make([]float32, 44100)
+	for i := range src {
+		src[i] = float32(i%100) / 100
+	}
+
+	resample := func(chunkSize int) []float32 {
+		r := &Resampler{
+			InputSampleRate:  44100,
+			OutputSampleRate: 48000,
+			ChannelCount:     1,
+		}
+		err := r.Initialize()
+		require.NoError(t, err)
+
+		var out []float32
+		for i := 0; i < len(src); i += chunkSize {
+			chunk := src[i:min(i+chunkSize, len(src))]
+			dst := make([]float32, r.MaxOutputSize(len(chunk)))
+			n, err := r.Resample(dst, chunk)
+			require.NoError(t, err)
+			out = append(out, dst[:n]...)
+		}
+		return out
+	}
+
+	whole := resample(len(src))
+	require.Equal(t, 47999, len(whole))
+	require.InDeltaSlice(t, whole, resample(441), 0.00001)
+}
+
+func TestResamplerNoAllocs(t *testing.T) {
+	r := &Resampler{
+		InputSampleRate:  44100,
+		OutputSampleRate: 48000,
+		ChannelCount:     2,
+	}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	src := make([]float32, 2*1024)
+	dst := make([]float32, r.MaxOutputSize(len(src)))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		r.Resample(dst, src) //nolint:errcheck
+	})
+	require.Equal(t, float64(0), allocs)
+}
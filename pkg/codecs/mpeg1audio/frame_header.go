@@ -9,7 +9,22 @@ var bitrates = [][][]int{
 	// MPEG-1
 	{
 		// layer 1
-		{},
+		{
+			32000,
+			64000,
+			96000,
+			128000,
+			160000,
+			192000,
+			224000,
+			256000,
+			288000,
+			320000,
+			352000,
+			384000,
+			416000,
+			448000,
+		},
 		// layer 2
 		{
 			32000,
@@ -45,10 +60,25 @@ var bitrates = [][][]int{
 			320000,
 		},
 	},
-	// MPEG-2
+	// MPEG-2 and MPEG-2.5
 	{
 		// layer 1
-		{},
+		{
+			32000,
+			48000,
+			56000,
+			64000,
+			80000,
+			96000,
+			112000,
+			128000,
+			144000,
+			160000,
+			176000,
+			192000,
+			224000,
+			256000,
+		},
 		// layer 2
 		{
 			8000,
@@ -99,6 +129,12 @@ var sampleRates = [][]int{
 		24000,
 		16000,
 	},
+	// MPEG-2.5
+	{
+		11025,
+		12000,
+		8000,
+	},
 }
 
 var samplesPerFrame = [][]int{
@@ -108,7 +144,7 @@ var samplesPerFrame = [][]int{
 		1152,
 		1152,
 	},
-	// MPEG-2
+	// MPEG-2 and MPEG-2.5
 	{
 		384,
 		1152,
@@ -127,15 +163,33 @@ const (
 	ChannelModeMono        ChannelMode = 3
 )
 
+// Emphasis is the de-emphasis that must be applied to a MPEG-1/2 audio frame.
+type Emphasis int
+
+// standard emphasis values.
+const (
+	EmphasisNone     Emphasis = 0
+	Emphasis5015     Emphasis = 1
+	EmphasisCCITTJ17 Emphasis = 3
+)
+
 // FrameHeader is the header of a MPEG-1/2 audio frame.
 // Specification: ISO 11172-3, 2.4.1.3
 type FrameHeader struct {
-	MPEG2       bool
+	MPEG2       bool // true for both MPEG-2 and MPEG-2.5
+	MPEG25      bool
 	Layer       uint8
 	Bitrate     int
 	SampleRate  int
 	Padding     bool
 	ChannelMode ChannelMode
+
+	// joint stereo tools, only meaningful when ChannelMode is ChannelModeJointStereo.
+	ModeExtension uint8
+
+	Copyright bool
+	Original  bool
+	Emphasis  Emphasis
 }
 
 // Unmarshal decodes a FrameHeader.
@@ -144,22 +198,32 @@ func (h *FrameHeader) Unmarshal(buf []byte) error {
 		return fmt.Errorf("not enough bytes")
 	}
 
-	syncWord := uint16(buf[0])<<4 | uint16(buf[1])>>4
-	if syncWord != 0x0FFF {
+	syncWord := uint16(buf[0])<<3 | uint16(buf[1])>>5
+	if syncWord != 0x07FF {
 		return fmt.Errorf("sync word not found: %x", syncWord)
 	}
 
-	h.MPEG2 = ((buf[1] >> 3) & 0x01) == 0
+	version := (buf[1] >> 3) & 0b11
 
-	var mpegIndex int
-	if h.MPEG2 {
-		mpegIndex = 1
-	} else {
-		mpegIndex = 0
+	switch version {
+	case 0b11:
+		h.MPEG2 = false
+		h.MPEG25 = false
+
+	case 0b10:
+		h.MPEG2 = true
+		h.MPEG25 = false
+
+	case 0b00:
+		h.MPEG2 = true
+		h.MPEG25 = true
+
+	default:
+		return fmt.Errorf("invalid MPEG version")
 	}
 
 	h.Layer = 4 - ((buf[1] >> 1) & 0b11)
-	if h.Layer <= 1 || h.Layer >= 4 {
+	if h.Layer < 1 || h.Layer >= 4 {
 		return fmt.Errorf("unsupported MPEG layer: %v", h.Layer)
 	}
 
@@ -167,36 +231,131 @@ func (h *FrameHeader) Unmarshal(buf []byte) error {
 	if bitrateIndex == 0 || bitrateIndex >= 15 {
 		return fmt.Errorf("invalid bitrate")
 	}
-	h.Bitrate = bitrates[mpegIndex][h.Layer-1][bitrateIndex-1]
+	h.Bitrate = bitrates[h.mpegIndex()][h.Layer-1][bitrateIndex-1]
 
 	sampleRateIndex := (buf[2] >> 2) & 0b11
 	if sampleRateIndex >= 3 {
 		return fmt.Errorf("invalid sample rate")
 	}
-	h.SampleRate = sampleRates[mpegIndex][sampleRateIndex]
+	h.SampleRate = sampleRates[h.sampleRateTable()][sampleRateIndex]
 
 	h.Padding = ((buf[2] >> 1) & 0b1) != 0
 	h.ChannelMode = ChannelMode(buf[3] >> 6)
+	h.ModeExtension = (buf[3] >> 4) & 0b11
+	h.Copyright = ((buf[3] >> 3) & 0b1) != 0
+	h.Original = ((buf[3] >> 2) & 0b1) != 0
+	h.Emphasis = Emphasis(buf[3] & 0b11)
+
+	return nil
+}
+
+func (h FrameHeader) mpegIndex() int {
+	if h.MPEG2 {
+		return 1
+	}
+	return 0
+}
+
+func (h FrameHeader) sampleRateTable() int {
+	switch {
+	case h.MPEG25:
+		return 2
+	case h.MPEG2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// marshalTo writes the header into buf, without CRC.
+func (h FrameHeader) marshalTo(buf []byte) error {
+	var version byte
+	switch {
+	case h.MPEG25:
+		version = 0b00
+	case h.MPEG2:
+		version = 0b10
+	default:
+		version = 0b11
+	}
+
+	if h.Layer < 1 || h.Layer >= 4 {
+		return fmt.Errorf("unsupported MPEG layer: %v", h.Layer)
+	}
+
+	bitrateIndex := -1
+	for i, v := range bitrates[h.mpegIndex()][h.Layer-1] {
+		if v == h.Bitrate {
+			bitrateIndex = i + 1
+			break
+		}
+	}
+	if bitrateIndex < 0 {
+		return fmt.Errorf("invalid bitrate: %v", h.Bitrate)
+	}
+
+	sampleRateIndex := -1
+	for i, v := range sampleRates[h.sampleRateTable()] {
+		if v == h.SampleRate {
+			sampleRateIndex = i
+			break
+		}
+	}
+	if sampleRateIndex < 0 {
+		return fmt.Errorf("invalid sample rate: %v", h.SampleRate)
+	}
+
+	buf[0] = 0xFF
+	buf[1] = 0xE0 | version<<3 | (4-h.Layer)<<1 | 1
+	buf[2] = byte(bitrateIndex)<<4 | byte(sampleRateIndex)<<2 | boolToUint8(h.Padding)<<1
+	buf[3] = byte(h.ChannelMode)<<6 | (h.ModeExtension&0b11)<<4 | boolToUint8(h.Copyright)<<3 |
+		boolToUint8(h.Original)<<2 | byte(h.Emphasis)&0b11
 
 	return nil
 }
 
+// sideInfoSize returns the size of the Layer III side information.
+func (h FrameHeader) sideInfoSize() int {
+	if !h.MPEG2 {
+		if h.ChannelMode == ChannelModeMono {
+			return 17
+		}
+		return 32
+	}
+
+	if h.ChannelMode == ChannelModeMono {
+		return 9
+	}
+	return 17
+}
+
 // FrameLen returns the length of the frame associated with the header.
 func (h FrameHeader) FrameLen() int {
+	var padding int
 	if h.Padding {
-		return (144 * h.Bitrate / h.SampleRate) + 1
+		padding = 1
+	}
+
+	switch {
+	case h.Layer == 1:
+		return (12*h.Bitrate/h.SampleRate + padding) * 4
+
+	case h.Layer == 3 && h.MPEG2:
+		return 72*h.Bitrate/h.SampleRate + padding
+
+	default:
+		return 144*h.Bitrate/h.SampleRate + padding
 	}
-	return (144 * h.Bitrate / h.SampleRate)
 }
 
 // SampleCount returns the number of samples contained into the frame.
 func (h FrameHeader) SampleCount() int {
-	var mpegIndex int
-	if h.MPEG2 {
-		mpegIndex = 1
-	} else {
-		mpegIndex = 0
-	}
+	return samplesPerFrame[h.mpegIndex()][h.Layer-1]
+}
 
-	return samplesPerFrame[mpegIndex][h.Layer-1]
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
 }
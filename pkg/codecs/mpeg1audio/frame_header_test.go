@@ -33,10 +33,12 @@ var casesFrameHeader = []struct {
 			0xff, 0xfb, 0x18, 0x64, 0x00,
 		},
 		FrameHeader{
-			Layer:       3,
-			Bitrate:     32000,
-			SampleRate:  32000,
-			ChannelMode: ChannelModeJointStereo,
+			Layer:         3,
+			Bitrate:       32000,
+			SampleRate:    32000,
+			ChannelMode:   ChannelModeJointStereo,
+			ModeExtension: 2,
+			Original:      true,
 		},
 		144,
 		1152,
@@ -51,6 +53,7 @@ var casesFrameHeader = []struct {
 			Bitrate:     80000,
 			SampleRate:  32000,
 			ChannelMode: ChannelModeMono,
+			Original:    true,
 		},
 		360,
 		1152,
@@ -66,6 +69,7 @@ var casesFrameHeader = []struct {
 			SampleRate:  44100,
 			Padding:     true,
 			ChannelMode: ChannelModeStereo,
+			Original:    true,
 		},
 		209,
 		1152,
@@ -76,10 +80,12 @@ var casesFrameHeader = []struct {
 			0xff, 0xfb, 0x14, 0x64, 0x00,
 		},
 		FrameHeader{
-			Layer:       3,
-			Bitrate:     32000,
-			SampleRate:  48000,
-			ChannelMode: ChannelModeJointStereo,
+			Layer:         3,
+			Bitrate:       32000,
+			SampleRate:    48000,
+			ChannelMode:   ChannelModeJointStereo,
+			ModeExtension: 2,
+			Original:      true,
 		},
 		96,
 		1152,
@@ -95,10 +101,59 @@ var casesFrameHeader = []struct {
 			Bitrate:     64000,
 			SampleRate:  16000,
 			ChannelMode: ChannelModeStereo,
+			Original:    true,
 		},
 		576,
 		1152,
 	},
+	{
+		"mpeg-1 layer 1 44.1k",
+		[]byte{
+			0xff, 0xff, 0xc0, 0x00, 0x00,
+		},
+		FrameHeader{
+			Layer:       1,
+			Bitrate:     384000,
+			SampleRate:  44100,
+			ChannelMode: ChannelModeStereo,
+		},
+		416,
+		384,
+	},
+	{
+		"mpeg-2 layer 3 22.05khz",
+		[]byte{
+			0xff, 0xf3, 0x80, 0x44, 0x00,
+		},
+		FrameHeader{
+			MPEG2:       true,
+			Layer:       3,
+			Bitrate:     64000,
+			SampleRate:  22050,
+			ChannelMode: ChannelModeJointStereo,
+			Original:    true,
+		},
+		208,
+		576,
+	},
+	{
+		"mpeg-2.5 layer 3 8khz",
+		[]byte{
+			0xff, 0xe3, 0x18, 0xc9, 0x00,
+		},
+		FrameHeader{
+			MPEG2:       true,
+			MPEG25:      true,
+			Layer:       3,
+			Bitrate:     8000,
+			SampleRate:  8000,
+			ChannelMode: ChannelModeMono,
+			Copyright:   true,
+			Emphasis:    Emphasis5015,
+		},
+		72,
+		576,
+	},
 }
 
 func TestFrameHeaderUnmarshal(t *testing.T) {
@@ -0,0 +1,156 @@
+package mpeg1audio
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	lameHeaderSize = 36
+
+	// delay introduced by the decoder, that must be added to the encoder delay
+	// when trimming decoded samples.
+	decoderDelay = 529
+)
+
+// lameCRCUpdate computes a CRC-16/ARC, that is used to protect the LAME header.
+func lameCRCUpdate(crc uint16, buf []byte) uint16 {
+	for _, b := range buf {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if (crc & 1) != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+func isLAMEHeader(buf []byte) bool {
+	if len(buf) < lameHeaderSize {
+		return false
+	}
+
+	switch string(buf[:4]) {
+	case "LAME", "Lavf", "Lavc":
+		return true
+	}
+	return false
+}
+
+// LAMEHeader is the LAME extension of a Xing/Info header.
+// Specification: LAME Mp3 Info Tag rev 1
+type LAMEHeader struct {
+	Encoder               string // encoder short version string, up to 9 characters
+	TagRevision           uint8
+	VBRMethod             uint8
+	Lowpass               uint8 // in units of 100 Hz
+	PeakSignalAmplitude   uint32
+	RadioReplayGain       uint16
+	AudiophileReplayGain  uint16
+	EncodingFlags         uint8
+	ATHType               uint8
+	Bitrate               uint8 // in kbit/s, 255 means 255 or more
+	EncoderDelay          uint16
+	Padding               uint16
+	NoiseShaping          uint8
+	StereoMode            uint8
+	UnwiseSettings        bool
+	SourceSampleFrequency uint8
+	MP3Gain               int8
+	SurroundInfo          uint8
+	Preset                uint16
+	MusicLength           uint32
+	MusicCRC              uint16
+}
+
+// unmarshal decodes a LAMEHeader.
+// The header CRC is not checked, since tools that rewrite the Xing fields often leave it stale.
+func (h *LAMEHeader) unmarshal(buf []byte) {
+	h.Encoder = strings.TrimRight(string(buf[:9]), "\x00")
+	h.TagRevision = buf[9] >> 4
+	h.VBRMethod = buf[9] & 0x0F
+	h.Lowpass = buf[10]
+	h.PeakSignalAmplitude = uint32(buf[11])<<24 | uint32(buf[12])<<16 | uint32(buf[13])<<8 | uint32(buf[14])
+	h.RadioReplayGain = uint16(buf[15])<<8 | uint16(buf[16])
+	h.AudiophileReplayGain = uint16(buf[17])<<8 | uint16(buf[18])
+	h.EncodingFlags = buf[19] >> 4
+	h.ATHType = buf[19] & 0x0F
+	h.Bitrate = buf[20]
+	h.EncoderDelay = uint16(buf[21])<<4 | uint16(buf[22])>>4
+	h.Padding = uint16(buf[22]&0x0F)<<8 | uint16(buf[23])
+	h.NoiseShaping = buf[24] & 0b11
+	h.StereoMode = (buf[24] >> 2) & 0b111
+	h.UnwiseSettings = ((buf[24] >> 5) & 0b1) != 0
+	h.SourceSampleFrequency = buf[24] >> 6
+	h.MP3Gain = int8(buf[25])
+	h.SurroundInfo = (buf[26] >> 3) & 0b111
+	h.Preset = uint16(buf[26]&0b111)<<8 | uint16(buf[27])
+	h.MusicLength = uint32(buf[28])<<24 | uint32(buf[29])<<16 | uint32(buf[30])<<8 | uint32(buf[31])
+	h.MusicCRC = uint16(buf[32])<<8 | uint16(buf[33])
+}
+
+// marshalTo writes the header into buf, except the header CRC,
+// that depends on the whole frame and is written by the caller.
+func (h LAMEHeader) marshalTo(buf []byte) error {
+	if len(h.Encoder) > 9 {
+		return fmt.Errorf("encoder string is too long")
+	}
+
+	if h.EncoderDelay > 0xFFF || h.Padding > 0xFFF {
+		return fmt.Errorf("invalid encoder delay or padding")
+	}
+
+	if h.Preset > 0x7FF {
+		return fmt.Errorf("invalid preset")
+	}
+
+	n := copy(buf, h.Encoder)
+	for ; n < 9; n++ {
+		buf[n] = 0
+	}
+
+	buf[9] = h.TagRevision<<4 | h.VBRMethod&0x0F
+	buf[10] = h.Lowpass
+	buf[11] = byte(h.PeakSignalAmplitude >> 24)
+	buf[12] = byte(h.PeakSignalAmplitude >> 16)
+	buf[13] = byte(h.PeakSignalAmplitude >> 8)
+	buf[14] = byte(h.PeakSignalAmplitude)
+	buf[15] = byte(h.RadioReplayGain >> 8)
+	buf[16] = byte(h.RadioReplayGain)
+	buf[17] = byte(h.AudiophileReplayGain >> 8)
+	buf[18] = byte(h.AudiophileReplayGain)
+	buf[19] = h.EncodingFlags<<4 | h.ATHType&0x0F
+	buf[20] = h.Bitrate
+	buf[21] = byte(h.EncoderDelay >> 4)
+	buf[22] = byte(h.EncoderDelay<<4) | byte(h.Padding>>8)
+	buf[23] = byte(h.Padding)
+	buf[24] = h.SourceSampleFrequency<<6 | boolToUint8(h.UnwiseSettings)<<5 |
+		(h.StereoMode&0b111)<<2 | h.NoiseShaping&0b11
+	buf[25] = byte(h.MP3Gain)
+	buf[26] = (h.SurroundInfo&0b111)<<3 | byte(h.Preset>>8)
+	buf[27] = byte(h.Preset)
+	buf[28] = byte(h.MusicLength >> 24)
+	buf[29] = byte(h.MusicLength >> 16)
+	buf[30] = byte(h.MusicLength >> 8)
+	buf[31] = byte(h.MusicLength)
+	buf[32] = byte(h.MusicCRC >> 8)
+	buf[33] = byte(h.MusicCRC)
+
+	return nil
+}
+
+// GaplessTrim returns the number of samples that must be discarded
+// at the start and at the end of the decoded stream in order to obtain gapless playback.
+func (h LAMEHeader) GaplessTrim() (int, int) {
+	start := int(h.EncoderDelay) + decoderDelay
+
+	end := int(h.Padding) - decoderDelay
+	if end < 0 {
+		end = 0
+	}
+
+	return start, end
+}
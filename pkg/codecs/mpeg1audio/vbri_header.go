@@ -0,0 +1,199 @@
+package mpeg1audio
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// the VBRI header is always located 32 bytes after the frame header.
+	vbriOffset     = 4 + 32
+	vbriHeaderSize = 26
+)
+
+// VBRIHeader is a Fraunhofer VBRI header,
+// stored inside the first frame of a MPEG-1/2 Layer III stream.
+// Specification: Fraunhofer VBRI header
+type VBRIHeader struct {
+	Version           uint16
+	Delay             uint16
+	Quality           uint16
+	ByteCount         uint32
+	FrameCount        uint32
+	TOCScaleFactor    uint16
+	TOCEntrySize      uint16 // size of each TOC entry, between 1 and 4 bytes
+	FramesPerTOCEntry uint16
+	TOC               []uint32 // byte size of each segment, divided by TOCScaleFactor
+}
+
+// Unmarshal decodes a VBRIHeader from the frame that contains it.
+func (h *VBRIHeader) Unmarshal(frame []byte) error {
+	var fh FrameHeader
+	err := fh.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	if fh.Layer != 3 {
+		return fmt.Errorf("only Layer III frames can contain a VBRI header")
+	}
+
+	if len(frame) < (vbriOffset + vbriHeaderSize) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	buf := frame[vbriOffset:]
+
+	if string(buf[:4]) != "VBRI" {
+		return fmt.Errorf("unable to find VBRI header")
+	}
+
+	h.Version = uint16(buf[4])<<8 | uint16(buf[5])
+	h.Delay = uint16(buf[6])<<8 | uint16(buf[7])
+	h.Quality = uint16(buf[8])<<8 | uint16(buf[9])
+	h.ByteCount = uint32(buf[10])<<24 | uint32(buf[11])<<16 | uint32(buf[12])<<8 | uint32(buf[13])
+	h.FrameCount = uint32(buf[14])<<24 | uint32(buf[15])<<16 | uint32(buf[16])<<8 | uint32(buf[17])
+	entryCount := int(uint16(buf[18])<<8 | uint16(buf[19]))
+	h.TOCScaleFactor = uint16(buf[20])<<8 | uint16(buf[21])
+	h.TOCEntrySize = uint16(buf[22])<<8 | uint16(buf[23])
+	h.FramesPerTOCEntry = uint16(buf[24])<<8 | uint16(buf[25])
+
+	if h.TOCEntrySize < 1 || h.TOCEntrySize > 4 {
+		return fmt.Errorf("invalid TOC entry size: %v", h.TOCEntrySize)
+	}
+
+	buf = buf[vbriHeaderSize:]
+	entrySize := int(h.TOCEntrySize)
+
+	if len(buf) < (entryCount * entrySize) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	h.TOC = make([]uint32, entryCount)
+
+	for i := range h.TOC {
+		var v uint32
+		for j := 0; j < entrySize; j++ {
+			v = v<<8 | uint32(buf[j])
+		}
+		h.TOC[i] = v
+		buf = buf[entrySize:]
+	}
+
+	return nil
+}
+
+// Marshal encodes a VBRIHeader into a frame with the given header.
+// The frame contains no audio and decodes to silence.
+func (h VBRIHeader) Marshal(fh FrameHeader) ([]byte, error) {
+	if fh.Layer != 3 {
+		return nil, fmt.Errorf("only Layer III frames can contain a VBRI header")
+	}
+
+	if h.TOCEntrySize < 1 || h.TOCEntrySize > 4 {
+		return nil, fmt.Errorf("invalid TOC entry size: %v", h.TOCEntrySize)
+	}
+
+	if len(h.TOC) > 0xFFFF {
+		return nil, fmt.Errorf("too many TOC entries")
+	}
+
+	entrySize := int(h.TOCEntrySize)
+
+	for _, v := range h.TOC {
+		if entrySize < 4 && v >= (1<<(8*entrySize)) {
+			return nil, fmt.Errorf("TOC entry %d does not fit into %d bytes", v, entrySize)
+		}
+	}
+
+	buf := make([]byte, fh.FrameLen())
+
+	if len(buf) < (vbriOffset + vbriHeaderSize + len(h.TOC)*entrySize) {
+		return nil, fmt.Errorf("frame is too small to contain the header")
+	}
+
+	err := fh.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	b := buf[vbriOffset:]
+
+	copy(b, "VBRI")
+	b[4] = byte(h.Version >> 8)
+	b[5] = byte(h.Version)
+	b[6] = byte(h.Delay >> 8)
+	b[7] = byte(h.Delay)
+	b[8] = byte(h.Quality >> 8)
+	b[9] = byte(h.Quality)
+	b[10] = byte(h.ByteCount >> 24)
+	b[11] = byte(h.ByteCount >> 16)
+	b[12] = byte(h.ByteCount >> 8)
+	b[13] = byte(h.ByteCount)
+	b[14] = byte(h.FrameCount >> 24)
+	b[15] = byte(h.FrameCount >> 16)
+	b[16] = byte(h.FrameCount >> 8)
+	b[17] = byte(h.FrameCount)
+	b[18] = byte(len(h.TOC) >> 8)
+	b[19] = byte(len(h.TOC))
+	b[20] = byte(h.TOCScaleFactor >> 8)
+	b[21] = byte(h.TOCScaleFactor)
+	b[22] = byte(h.TOCEntrySize >> 8)
+	b[23] = byte(h.TOCEntrySize)
+	b[24] = byte(h.FramesPerTOCEntry >> 8)
+	b[25] = byte(h.FramesPerTOCEntry)
+
+	b = b[vbriHeaderSize:]
+
+	for _, v := range h.TOC {
+		for j := entrySize - 1; j >= 0; j-- {
+			b[j] = byte(v)
+			v >>= 8
+		}
+		b = b[entrySize:]
+	}
+
+	return buf, nil
+}
+
+// SampleCount returns the number of samples of the stream.
+// fh is the header of the frame carrying the VBRI header.
+func (h VBRIHeader) SampleCount(fh FrameHeader) int64 {
+	return int64(h.FrameCount) * int64(fh.SampleCount())
+}
+
+// Duration returns the duration of the stream.
+// fh is the header of the frame carrying the VBRI header.
+func (h VBRIHeader) Duration(fh FrameHeader) time.Duration {
+	return samplesToDuration(h.SampleCount(fh), fh.SampleRate)
+}
+
+// SeekOffset returns the byte offset that corresponds to the given position,
+// expressed as a fraction of the duration between 0 and 1.
+// The offset is relative to the start of the frame carrying the VBRI header.
+func (h VBRIHeader) SeekOffset(position float64) (uint32, error) {
+	if len(h.TOC) == 0 || h.FramesPerTOCEntry == 0 {
+		return 0, fmt.Errorf("missing TOC")
+	}
+
+	if position < 0 {
+		position = 0
+	} else if position > 1 {
+		position = 1
+	}
+
+	target := position * float64(h.FrameCount) / float64(h.FramesPerTOCEntry)
+	i := int(target)
+
+	var offset float64
+
+	for j := 0; j < i && j < len(h.TOC); j++ {
+		offset += float64(h.TOC[j]) * float64(h.TOCScaleFactor)
+	}
+
+	if i < len(h.TOC) {
+		offset += float64(h.TOC[i]) * float64(h.TOCScaleFactor) * (target - float64(i))
+	}
+
+	return uint32(offset), nil
+}
@@ -0,0 +1,115 @@
+package mpeg1audio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVBRIHeader = []struct {
+	name string
+	fh   FrameHeader
+	enc  []byte
+	dec  VBRIHeader
+}{
+	{
+		"standard",
+		FrameHeader{
+			Layer:       3,
+			Bitrate:     32000,
+			SampleRate:  32000,
+			ChannelMode: ChannelModeStereo,
+		},
+		[]byte{
+			0xff, 0xfb, 0x18, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x56, 0x42, 0x52, 0x49,
+			0x00, 0x01, 0x03, 0xc8, 0x00, 0x4b, 0x00, 0x00,
+			0x38, 0x40, 0x00, 0x00, 0x00, 0x64, 0x00, 0x04,
+			0x00, 0x01, 0x00, 0x02, 0x00, 0x19, 0x0e, 0x10,
+			0x0b, 0xb8, 0x10, 0x68, 0x0e, 0x10, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		VBRIHeader{
+			Version:           1,
+			Delay:             0x3c8,
+			Quality:           75,
+			ByteCount:         14400,
+			FrameCount:        100,
+			TOCScaleFactor:    1,
+			TOCEntrySize:      2,
+			FramesPerTOCEntry: 25,
+			TOC:               []uint32{3600, 3000, 4200, 3600},
+		},
+	},
+}
+
+func TestVBRIHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesVBRIHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h VBRIHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestVBRIHeaderMarshal(t *testing.T) {
+	for _, ca := range casesVBRIHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			buf, err := ca.dec.Marshal(ca.fh)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, buf)
+		})
+	}
+}
+
+func TestVBRIHeaderDuration(t *testing.T) {
+	h := casesVBRIHeader[0].dec
+	require.Equal(t, int64(115200), h.SampleCount(casesVBRIHeader[0].fh))
+	require.Equal(t, 3600*time.Millisecond, h.Duration(casesVBRIHeader[0].fh))
+}
+
+func TestVBRIHeaderSeekOffset(t *testing.T) {
+	h := casesVBRIHeader[0].dec
+
+	for _, ca := range []struct {
+		position float64
+		offset   uint32
+	}{
+		{0, 0},
+		{0.125, 1800},
+		{0.5, 6600},
+		{1, 14400},
+	} {
+		offset, err := h.SeekOffset(ca.position)
+		require.NoError(t, err)
+		require.Equal(t, ca.offset, offset)
+	}
+}
+
+func FuzzVBRIHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesVBRIHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h VBRIHeader
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.SeekOffset(0.5) //nolint:errcheck
+		}
+	})
+}
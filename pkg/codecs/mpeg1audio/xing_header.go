@@ -0,0 +1,307 @@
+package mpeg1audio
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	xingFlagFrames  = 0x01
+	xingFlagBytes   = 0x02
+	xingFlagTOC     = 0x04
+	xingFlagQuality = 0x08
+
+	xingTOCSize = 100
+)
+
+func samplesToDuration(n int64, sampleRate int) time.Duration {
+	sr := int64(sampleRate)
+	secs := n / sr
+	rem := n % sr
+	return time.Duration(secs)*time.Second + time.Duration(rem)*time.Second/time.Duration(sr)
+}
+
+// xingOffset returns the position of the Xing header inside a Layer III frame.
+func xingOffset(fh FrameHeader, frame []byte) int {
+	n := 4 + fh.sideInfoSize()
+	if (frame[1] & 0b1) == 0 {
+		n += 2
+	}
+	return n
+}
+
+// XingHeader is a Xing or Info header,
+// stored inside the first frame of a MPEG-1/2 Layer III stream.
+// Specification: Xing VBR header, LAME Mp3 Info Tag rev 1
+type XingHeader struct {
+	Info          bool // true if the tag is "Info" (CBR), false if it is "Xing" (VBR)
+	HasFrameCount bool
+	FrameCount    uint32 // number of frames, excluding the one carrying the header
+	HasByteCount  bool
+	ByteCount     uint32 // size of the stream, including the frame carrying the header
+	TOC           []byte // seek table with 100 entries, nil if not present
+	HasQuality    bool
+	Quality       uint32
+	LAME          *LAMEHeader
+}
+
+// Unmarshal decodes a XingHeader from the frame that contains it.
+func (h *XingHeader) Unmarshal(frame []byte) error {
+	var fh FrameHeader
+	err := fh.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	if fh.Layer != 3 {
+		return fmt.Errorf("only Layer III frames can contain a Xing header")
+	}
+
+	pos := xingOffset(fh, frame)
+
+	if len(frame) < (pos + 8) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	switch string(frame[pos : pos+4]) {
+	case "Xing":
+		h.Info = false
+
+	case "Info":
+		h.Info = true
+
+	default:
+		return fmt.Errorf("unable to find Xing header")
+	}
+
+	flags := uint32(frame[pos+4])<<24 | uint32(frame[pos+5])<<16 | uint32(frame[pos+6])<<8 | uint32(frame[pos+7])
+	pos += 8
+
+	readUint32 := func() (uint32, error) {
+		if len(frame) < (pos + 4) {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		v := uint32(frame[pos])<<24 | uint32(frame[pos+1])<<16 | uint32(frame[pos+2])<<8 | uint32(frame[pos+3])
+		pos += 4
+		return v, nil
+	}
+
+	h.HasFrameCount = (flags & xingFlagFrames) != 0
+	if h.HasFrameCount {
+		h.FrameCount, err = readUint32()
+		if err != nil {
+			return err
+		}
+	} else {
+		h.FrameCount = 0
+	}
+
+	h.HasByteCount = (flags & xingFlagBytes) != 0
+	if h.HasByteCount {
+		h.ByteCount, err = readUint32()
+		if err != nil {
+			return err
+		}
+	} else {
+		h.ByteCount = 0
+	}
+
+	if (flags & xingFlagTOC) != 0 {
+		if len(frame) < (pos + xingTOCSize) {
+			return fmt.Errorf("not enough bytes")
+		}
+		h.TOC = append([]byte(nil), frame[pos:pos+xingTOCSize]...)
+		pos += xingTOCSize
+	} else {
+		h.TOC = nil
+	}
+
+	h.HasQuality = (flags & xingFlagQuality) != 0
+	if h.HasQuality {
+		h.Quality, err = readUint32()
+		if err != nil {
+			return err
+		}
+	} else {
+		h.Quality = 0
+	}
+
+	if isLAMEHeader(frame[pos:]) {
+		h.LAME = &LAMEHeader{}
+		h.LAME.unmarshal(frame[pos:])
+	} else {
+		h.LAME = nil
+	}
+
+	return nil
+}
+
+func (h XingHeader) marshalSize() int {
+	n := 8
+	if h.HasFrameCount {
+		n += 4
+	}
+	if h.HasByteCount {
+		n += 4
+	}
+	if h.TOC != nil {
+		n += xingTOCSize
+	}
+	if h.HasQuality {
+		n += 4
+	}
+	if h.LAME != nil {
+		n += lameHeaderSize
+	}
+	return n
+}
+
+// Marshal encodes a XingHeader into a frame with the given header.
+// The frame contains no audio and decodes to silence.
+func (h XingHeader) Marshal(fh FrameHeader) ([]byte, error) {
+	if fh.Layer != 3 {
+		return nil, fmt.Errorf("only Layer III frames can contain a Xing header")
+	}
+
+	if h.TOC != nil && len(h.TOC) != xingTOCSize {
+		return nil, fmt.Errorf("invalid TOC size")
+	}
+
+	buf := make([]byte, fh.FrameLen())
+
+	if len(buf) < (4 + fh.sideInfoSize() + h.marshalSize()) {
+		return nil, fmt.Errorf("frame is too small to contain the header")
+	}
+
+	err := fh.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := xingOffset(fh, buf)
+
+	if h.Info {
+		copy(buf[pos:], "Info")
+	} else {
+		copy(buf[pos:], "Xing")
+	}
+
+	var flags uint32
+	if h.HasFrameCount {
+		flags |= xingFlagFrames
+	}
+	if h.HasByteCount {
+		flags |= xingFlagBytes
+	}
+	if h.TOC != nil {
+		flags |= xingFlagTOC
+	}
+	if h.HasQuality {
+		flags |= xingFlagQuality
+	}
+
+	writeUint32 := func(v uint32) {
+		buf[pos] = byte(v >> 24)
+		buf[pos+1] = byte(v >> 16)
+		buf[pos+2] = byte(v >> 8)
+		buf[pos+3] = byte(v)
+		pos += 4
+	}
+
+	pos += 4
+	writeUint32(flags)
+
+	if h.HasFrameCount {
+		writeUint32(h.FrameCount)
+	}
+
+	if h.HasByteCount {
+		writeUint32(h.ByteCount)
+	}
+
+	if h.TOC != nil {
+		pos += copy(buf[pos:], h.TOC)
+	}
+
+	if h.HasQuality {
+		writeUint32(h.Quality)
+	}
+
+	if h.LAME != nil {
+		err = h.LAME.marshalTo(buf[pos:])
+		if err != nil {
+			return nil, err
+		}
+
+		crc := lameCRCUpdate(0, buf[:pos+lameHeaderSize-2])
+		buf[pos+lameHeaderSize-2] = byte(crc >> 8)
+		buf[pos+lameHeaderSize-1] = byte(crc)
+	}
+
+	return buf, nil
+}
+
+// SampleCount returns the number of samples of the stream,
+// excluding the encoder delay and padding when a LAME header is present.
+// fh is the header of the frame carrying the Xing header.
+func (h XingHeader) SampleCount(fh FrameHeader) (int64, error) {
+	if !h.HasFrameCount {
+		return 0, fmt.Errorf("missing frame count")
+	}
+
+	n := int64(h.FrameCount) * int64(fh.SampleCount())
+
+	if h.LAME != nil {
+		n -= int64(h.LAME.EncoderDelay) + int64(h.LAME.Padding)
+		if n < 0 {
+			n = 0
+		}
+	}
+
+	return n, nil
+}
+
+// Duration returns the exact duration of the stream.
+// fh is the header of the frame carrying the Xing header.
+func (h XingHeader) Duration(fh FrameHeader) (time.Duration, error) {
+	n, err := h.SampleCount(fh)
+	if err != nil {
+		return 0, err
+	}
+
+	return samplesToDuration(n, fh.SampleRate), nil
+}
+
+// SeekOffset returns the byte offset that corresponds to the given position,
+// expressed as a fraction of the duration between 0 and 1.
+// The offset is relative to the start of the frame carrying the Xing header.
+func (h XingHeader) SeekOffset(position float64) (uint32, error) {
+	if h.TOC == nil || !h.HasByteCount {
+		return 0, fmt.Errorf("missing TOC or byte count")
+	}
+
+	percent := position * 100
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+
+	i := int(percent)
+	if i > 99 {
+		i = 99
+	}
+
+	fa := float64(h.TOC[i])
+
+	var fb float64
+	if i < 99 {
+		fb = float64(h.TOC[i+1])
+	} else {
+		fb = 256
+	}
+
+	fx := fa + (fb-fa)*(percent-float64(i))
+
+	return uint32(fx / 256 * float64(h.ByteCount)), nil
+}
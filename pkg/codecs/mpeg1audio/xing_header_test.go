@@ -0,0 +1,201 @@
+package mpeg1audio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testXingTOC() []byte {
+	toc := make([]byte, 100)
+	for i := range toc {
+		toc[i] = byte(i * 256 / 100)
+	}
+	return toc
+}
+
+var casesXingHeader = []struct {
+	name string
+	fh   FrameHeader
+	enc  []byte
+	dec  XingHeader
+}{
+	{
+		"xing with lame",
+		FrameHeader{
+			Layer:       3,
+			Bitrate:     48000,
+			SampleRate:  32000,
+			ChannelMode: ChannelModeJointStereo,
+		},
+		[]byte{
+			0xff, 0xfb, 0x38, 0x40, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x58, 0x69, 0x6e, 0x67,
+			0x00, 0x00, 0x00, 0x0f, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x03, 0x4b, 0xc0, 0x00, 0x02, 0x05, 0x07,
+			0x0a, 0x0c, 0x0f, 0x11, 0x14, 0x17, 0x19, 0x1c,
+			0x1e, 0x21, 0x23, 0x26, 0x28, 0x2b, 0x2e, 0x30,
+			0x33, 0x35, 0x38, 0x3a, 0x3d, 0x40, 0x42, 0x45,
+			0x47, 0x4a, 0x4c, 0x4f, 0x51, 0x54, 0x57, 0x59,
+			0x5c, 0x5e, 0x61, 0x63, 0x66, 0x68, 0x6b, 0x6e,
+			0x70, 0x73, 0x75, 0x78, 0x7a, 0x7d, 0x80, 0x82,
+			0x85, 0x87, 0x8a, 0x8c, 0x8f, 0x91, 0x94, 0x97,
+			0x99, 0x9c, 0x9e, 0xa1, 0xa3, 0xa6, 0xa8, 0xab,
+			0xae, 0xb0, 0xb3, 0xb5, 0xb8, 0xba, 0xbd, 0xc0,
+			0xc2, 0xc5, 0xc7, 0xca, 0xcc, 0xcf, 0xd1, 0xd4,
+			0xd7, 0xd9, 0xdc, 0xde, 0xe1, 0xe3, 0xe6, 0xe8,
+			0xeb, 0xee, 0xf0, 0xf3, 0xf5, 0xf8, 0xfa, 0xfd,
+			0x00, 0x00, 0x00, 0x39, 0x4c, 0x41, 0x4d, 0x45,
+			0x33, 0x2e, 0x31, 0x30, 0x30, 0x14, 0xa0, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x15,
+			0x30, 0x24, 0x04, 0xec, 0x4c, 0x00, 0x01, 0xf4,
+			0x00, 0x03, 0x4b, 0xc0, 0x12, 0x34, 0xc4, 0xba,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		XingHeader{
+			HasFrameCount: true,
+			FrameCount:    1000,
+			HasByteCount:  true,
+			ByteCount:     216000,
+			TOC:           testXingTOC(),
+			HasQuality:    true,
+			Quality:       57,
+			LAME: &LAMEHeader{
+				Encoder:               "LAME3.100",
+				TagRevision:           1,
+				VBRMethod:             4,
+				Lowpass:               160,
+				EncodingFlags:         1,
+				ATHType:               5,
+				Bitrate:               48,
+				EncoderDelay:          576,
+				Padding:               1260,
+				StereoMode:            3,
+				SourceSampleFrequency: 1,
+				Preset:                500,
+				MusicLength:           216000,
+				MusicCRC:              0x1234,
+			},
+		},
+	},
+	{
+		"info mpeg-2.5",
+		FrameHeader{
+			MPEG2:       true,
+			MPEG25:      true,
+			Layer:       3,
+			Bitrate:     8000,
+			SampleRate:  8000,
+			ChannelMode: ChannelModeMono,
+		},
+		[]byte{
+			0xff, 0xe3, 0x18, 0xc0, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x49, 0x6e, 0x66,
+			0x6f, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xfa, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		XingHeader{
+			Info:          true,
+			HasFrameCount: true,
+			FrameCount:    250,
+		},
+	},
+}
+
+func TestXingHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesXingHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h XingHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestXingHeaderMarshal(t *testing.T) {
+	for _, ca := range casesXingHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			buf, err := ca.dec.Marshal(ca.fh)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, buf)
+		})
+	}
+}
+
+func TestXingHeaderDuration(t *testing.T) {
+	h := casesXingHeader[0].dec
+
+	n, err := h.SampleCount(casesXingHeader[0].fh)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000*1152-576-1260), n)
+
+	d, err := h.Duration(casesXingHeader[0].fh)
+	require.NoError(t, err)
+	require.Equal(t, 35942625*time.Microsecond, d)
+
+	start, end := h.LAME.GaplessTrim()
+	require.Equal(t, 1105, start)
+	require.Equal(t, 731, end)
+
+	h = casesXingHeader[1].dec
+
+	d, err = h.Duration(casesXingHeader[1].fh)
+	require.NoError(t, err)
+	require.Equal(t, 18*time.Second, d)
+}
+
+func TestXingHeaderSeekOffset(t *testing.T) {
+	h := casesXingHeader[0].dec
+
+	for _, ca := range []struct {
+		position float64
+		offset   uint32
+	}{
+		{0, 0},
+		{0.5, 108000},
+		{0.995, 214734},
+		{1, 216000},
+	} {
+		offset, err := h.SeekOffset(ca.position)
+		require.NoError(t, err)
+		require.Equal(t, ca.offset, offset)
+	}
+
+	_, err := casesXingHeader[1].dec.SeekOffset(0.5)
+	require.Error(t, err)
+}
+
+func FuzzXingHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesXingHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var h XingHeader
+		err := h.Unmarshal(b)
+		if err == nil {
+			var fh FrameHeader
+			err = fh.Unmarshal(b)
+			require.NoError(t, err)
+
+			if fh.Layer != 3 {
+				t.Errorf("unexpected layer")
+			}
+
+			h.Duration(fh)    //nolint:errcheck
+			h.SeekOffset(0.5) //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package mpeg1video
+
+import (
+	"fmt"
+)
+
+// Config is a decoded MPEG-1/2 Video configuration,
+// composed by a sequence header and, in case of MPEG-2, by a sequence extension.
+type Config struct {
+	SequenceHeader    SequenceHeader
+	SequenceExtension *SequenceExtension
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(config []byte) error {
+	err := c.SequenceHeader.Unmarshal(config)
+	if err != nil {
+		return err
+	}
+
+	c.SequenceExtension = nil
+
+	for i := 4; i < (len(config) - 4); i++ {
+		if config[i] == 0 && config[i+1] == 0 && config[i+2] == 1 {
+			startCode := StartCode(config[i+3])
+
+			switch startCode {
+			case ExtensionStartCode:
+				if i+4 < len(config) && (config[i+4]>>4) == extensionIDSequence {
+					var ext SequenceExtension
+					err = ext.Unmarshal(config[i:])
+					if err != nil {
+						return fmt.Errorf("invalid sequence extension: %w", err)
+					}
+					c.SequenceExtension = &ext
+					return nil
+				}
+
+			case SequenceHeaderStartCode, GroupOfPicturesStartCode, PictureStartCode:
+				return nil
+			}
+
+			i += 3
+		}
+	}
+
+	return nil
+}
+
+// IsMPEG2 checks whether the configuration belongs to a MPEG-2 stream.
+func (c Config) IsMPEG2() bool {
+	return c.SequenceExtension != nil
+}
+
+// Width returns the width of the video.
+func (c Config) Width() int {
+	if c.SequenceExtension != nil {
+		return int(c.SequenceExtension.HorizontalSizeExtension)<<12 | c.SequenceHeader.HorizontalSize
+	}
+	return c.SequenceHeader.HorizontalSize
+}
+
+// Height returns the height of the video.
+func (c Config) Height() int {
+	if c.SequenceExtension != nil {
+		return int(c.SequenceExtension.VerticalSizeExtension)<<12 | c.SequenceHeader.VerticalSize
+	}
+	return c.SequenceHeader.VerticalSize
+}
+
+// FrameRate returns the frame rate, as a fraction.
+func (c Config) FrameRate() (int, int) {
+	num, den := c.SequenceHeader.FrameRate()
+
+	if c.SequenceExtension != nil {
+		num *= int(c.SequenceExtension.FrameRateExtensionN) + 1
+		den *= int(c.SequenceExtension.FrameRateExtensionD) + 1
+	}
+
+	return num, den
+}
+
+// FPS returns the frames per second of the video.
+func (c Config) FPS() float64 {
+	num, den := c.FrameRate()
+	return float64(num) / float64(den)
+}
@@ -0,0 +1,90 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConfig = []struct {
+	name   string
+	byts   []byte
+	conf   Config
+	width  int
+	height int
+	fps    float64
+}{
+	{
+		"mpeg-1",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x16, 0x01, 0x20, 0x13,
+			0x02, 0xce, 0xe0, 0xa4,
+		},
+		Config{
+			SequenceHeader: SequenceHeader{
+				HorizontalSize:            352,
+				VerticalSize:              288,
+				AspectRatioInformation:    1,
+				FrameRateCode:             3,
+				BitRate:                   2875,
+				VBVBufferSize:             20,
+				ConstrainedParametersFlag: true,
+			},
+		},
+		352,
+		288,
+		25,
+	},
+	{
+		"mpeg-2",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x78, 0x04, 0x38, 0x35,
+			0xff, 0xff, 0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5,
+			0x14, 0x4a, 0x00, 0x01, 0x00, 0x00,
+		},
+		Config{
+			SequenceHeader: SequenceHeader{
+				HorizontalSize:         1920,
+				VerticalSize:           1080,
+				AspectRatioInformation: 3,
+				FrameRateCode:          5,
+				BitRate:                0x3ffff,
+				VBVBufferSize:          3,
+			},
+			SequenceExtension: &SequenceExtension{
+				ProfileAndLevelIndication: 0x44,
+				ProgressiveSequence:       true,
+				ChromaFormat:              1,
+			},
+		},
+		1920,
+		1080,
+		30,
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.conf, conf)
+			require.Equal(t, ca.conf.SequenceExtension != nil, conf.IsMPEG2())
+			require.Equal(t, ca.width, conf.Width())
+			require.Equal(t, ca.height, conf.Height())
+			require.Equal(t, ca.fps, conf.FPS())
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	for _, ca := range casesConfig {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var conf Config
+		conf.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,133 @@
+package mpeg1video
+
+import (
+	"fmt"
+)
+
+// maximum value of temporal_reference + 1.
+const temporalReferenceMax = 1024
+
+// DTSExtractor computes DTS from PTS.
+// Pictures are assumed to be in decode order, and their display order
+// is deduced from temporal_reference, that restarts at every GOP header.
+type DTSExtractor struct {
+	conf            *Config
+	decodeIndex     uint16
+	indexFilled     bool
+	reorderedFrames int64
+	prevDTSFilled   bool
+	prevDTS         int64
+}
+
+// NewDTSExtractor allocates a DTSExtractor.
+func NewDTSExtractor() *DTSExtractor {
+	return &DTSExtractor{}
+}
+
+func (d *DTSExtractor) extractInner(frame []byte, pts int64) (int64, error) {
+	var picture []byte
+	gopFound := false
+
+outer:
+	for i := 0; i < (len(frame) - 3); i++ {
+		if frame[i] == 0 && frame[i+1] == 0 && frame[i+2] == 1 {
+			switch StartCode(frame[i+3]) {
+			case SequenceHeaderStartCode:
+				var conf Config
+				err := conf.Unmarshal(frame[i:])
+				if err != nil {
+					return 0, fmt.Errorf("invalid sequence header: %w", err)
+				}
+
+				num, _ := conf.FrameRate()
+				if num == 0 {
+					return 0, fmt.Errorf("unsupported frame_rate_code: %d", conf.SequenceHeader.FrameRateCode)
+				}
+
+				d.conf = &conf
+
+			case GroupOfPicturesStartCode:
+				gopFound = true
+
+			case PictureStartCode:
+				picture = frame[i:]
+				break outer
+			}
+
+			i += 2
+		}
+	}
+
+	if d.conf == nil {
+		return 0, fmt.Errorf("sequence header not received yet")
+	}
+
+	if picture == nil {
+		return 0, fmt.Errorf("picture header not found")
+	}
+
+	var h PictureHeader
+	err := h.Unmarshal(picture)
+	if err != nil {
+		return 0, fmt.Errorf("invalid picture header: %w", err)
+	}
+
+	switch {
+	case gopFound:
+		d.decodeIndex = 0
+	case !d.indexFilled:
+		d.decodeIndex = h.TemporalReference
+	}
+	d.indexFilled = true
+
+	// difference between display index and decode index
+	diff := int64((h.TemporalReference - d.decodeIndex) % temporalReferenceMax)
+	if diff >= (temporalReferenceMax / 2) {
+		diff -= temporalReferenceMax
+	}
+
+	d.decodeIndex = (d.decodeIndex + 1) % temporalReferenceMax
+
+	// in MPEG-1/2, an I or P picture is transmitted before the B pictures that precede it in display order.
+	// Therefore when B pictures are present, the delay between decode and display order is one picture.
+	if diff > 0 && d.reorderedFrames == 0 {
+		d.reorderedFrames = 1
+	}
+
+	frames := diff + d.reorderedFrames
+	if frames < 0 {
+		return 0, fmt.Errorf("invalid temporal_reference: %d", h.TemporalReference)
+	}
+
+	num, den := d.conf.FrameRate()
+	dts := pts - frames*90000*int64(den)/int64(num)
+
+	// this happens when B pictures appear after the first I picture of a closed GOP
+	if d.prevDTSFilled && dts <= d.prevDTS && h.CodingType != PictureCodingTypeB {
+		return d.prevDTS + 90, nil
+	}
+
+	return dts, nil
+}
+
+// Extract extracts the DTS of a frame.
+func (d *DTSExtractor) Extract(frame []byte, pts int64) (int64, error) {
+	dts, err := d.extractInner(frame, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTS = dts
+	d.prevDTSFilled = true
+
+	return dts, err
+}
@@ -0,0 +1,159 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	frame []byte
+	dts   int64
+	pts   int64
+}
+
+var (
+	testSequenceHeader = []byte{
+		0x00, 0x00, 0x01, 0xb3, 0x16, 0x01, 0x20, 0x13,
+		0x02, 0xce, 0xe0, 0xa4,
+	}
+	testGOPClosed = []byte{0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x40}
+	testGOPOpen   = []byte{0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x00}
+)
+
+func testPicture(pre []byte, header []byte) []byte {
+	var ret []byte
+	ret = append(ret, pre...)
+	ret = append(ret, 0x00, 0x00, 0x01, 0x00)
+	return append(ret, header...)
+}
+
+func concat(bufs ...[]byte) []byte {
+	var ret []byte
+	for _, b := range bufs {
+		ret = append(ret, b...)
+	}
+	return ret
+}
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sample
+}{
+	{
+		"without B-frames",
+		[]sample{
+			{
+				testPicture(concat(testSequenceHeader, testGOPClosed), []byte{0x00, 0x0f, 0xff, 0xf8}),
+				90000,
+				90000,
+			},
+			{
+				testPicture(nil, []byte{0x00, 0x57, 0xff, 0xf8}),
+				93600,
+				93600,
+			},
+			{
+				testPicture(nil, []byte{0x00, 0x97, 0xff, 0xf8}),
+				97200,
+				97200,
+			},
+		},
+	},
+	{
+		"with B-frames",
+		[]sample{
+			{ // I, closed GOP
+				testPicture(concat(testSequenceHeader, testGOPClosed), []byte{0x00, 0x0f, 0xff, 0xf8}),
+				90000,
+				90000,
+			},
+			{ // P
+				testPicture(nil, []byte{0x00, 0xd7, 0xff, 0xf8}),
+				90090,
+				100800,
+			},
+			{ // B
+				testPicture(nil, []byte{0x00, 0x5f, 0xff, 0xf8}),
+				93600,
+				93600,
+			},
+			{ // B
+				testPicture(nil, []byte{0x00, 0x9f, 0xff, 0xf8}),
+				97200,
+				97200,
+			},
+			{ // P
+				testPicture(nil, []byte{0x01, 0x97, 0xff, 0xf8}),
+				100800,
+				111600,
+			},
+			{ // B
+				testPicture(nil, []byte{0x01, 0x1f, 0xff, 0xf8}),
+				104400,
+				104400,
+			},
+			{ // B
+				testPicture(nil, []byte{0x01, 0x5f, 0xff, 0xf8}),
+				108000,
+				108000,
+			},
+			{ // I, open GOP
+				testPicture(concat(testSequenceHeader, testGOPOpen), []byte{0x00, 0x8f, 0xff, 0xf8}),
+				111600,
+				122400,
+			},
+			{ // B
+				testPicture(nil, []byte{0x00, 0x1f, 0xff, 0xf8}),
+				115200,
+				115200,
+			},
+			{ // B
+				testPicture(nil, []byte{0x00, 0x5f, 0xff, 0xf8}),
+				118800,
+				118800,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := NewDTSExtractor()
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.frame, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+
+func TestDTSExtractorErrors(t *testing.T) {
+	ex := NewDTSExtractor()
+
+	_, err := ex.Extract(testPicture(nil, []byte{0x00, 0x0f, 0xff, 0xf8}), 0)
+	require.EqualError(t, err, "sequence header not received yet")
+
+	_, err = ex.Extract(concat(testSequenceHeader, testGOPClosed), 0)
+	require.EqualError(t, err, "picture header not found")
+}
+
+func FuzzDTSExtractor(f *testing.F) {
+	for _, ca := range casesDTSExtractor {
+		for _, sample := range ca.sequence {
+			f.Add(sample.frame, sample.pts)
+		}
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte, pts int64) {
+		ex := NewDTSExtractor()
+		_, err := ex.Extract(testPicture(concat(testSequenceHeader, testGOPClosed),
+			[]byte{0x00, 0x0f, 0xff, 0xf8}), 0)
+		if err != nil {
+			panic(err)
+		}
+		ex.Extract(b, pts) //nolint:errcheck
+	})
+}
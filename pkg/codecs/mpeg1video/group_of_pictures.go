@@ -0,0 +1,51 @@
+package mpeg1video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// GroupOfPictures is a group of pictures header.
+// Specification: ISO 13818-2, 6.2.2.6
+type GroupOfPictures struct {
+	DropFrameFlag bool
+	Hours         uint8
+	Minutes       uint8
+	Seconds       uint8
+	Pictures      uint8
+	ClosedGOP     bool
+	BrokenLink    bool
+}
+
+// Unmarshal decodes a GroupOfPictures.
+// The buffer must start with a group_start_code.
+func (g *GroupOfPictures) Unmarshal(buf []byte) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:4], []byte{0, 0, 1, byte(GroupOfPicturesStartCode)}) {
+		return fmt.Errorf("group_start_code not found")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 27)
+	if err != nil {
+		return err
+	}
+
+	g.DropFrameFlag = bits.ReadFlagUnsafe(buf, &pos)
+	g.Hours = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	g.Minutes = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+
+	if !bits.ReadFlagUnsafe(buf, &pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	g.Seconds = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	g.Pictures = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	g.ClosedGOP = bits.ReadFlagUnsafe(buf, &pos)
+	g.BrokenLink = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
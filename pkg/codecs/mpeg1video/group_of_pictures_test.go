@@ -0,0 +1,29 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupOfPicturesUnmarshal(t *testing.T) {
+	var g GroupOfPictures
+	err := g.Unmarshal([]byte{0x00, 0x00, 0x01, 0xb8, 0x04, 0x28, 0x62, 0x40})
+	require.NoError(t, err)
+	require.Equal(t, GroupOfPictures{
+		Hours:     1,
+		Minutes:   2,
+		Seconds:   3,
+		Pictures:  4,
+		ClosedGOP: true,
+	}, g)
+}
+
+func FuzzGroupOfPicturesUnmarshal(f *testing.F) {
+	f.Add([]byte{0x00, 0x00, 0x01, 0xb8, 0x04, 0x28, 0x62, 0x40})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var g GroupOfPictures
+		g.Unmarshal(b) //nolint:errcheck
+	})
+}
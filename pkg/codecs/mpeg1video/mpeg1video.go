@@ -0,0 +1,37 @@
+// Package mpeg1video contains utilities to work with MPEG-1/2 video codecs.
+package mpeg1video
+
+const (
+	// MaxFrameSize is the maximum size of a frame.
+	MaxFrameSize = 1 * 1024 * 1024
+)
+
+// StartCode is a MPEG-1/2 Video start code.
+// Specification: ISO 13818-2, Table 6-1
+type StartCode uint8
+
+// start codes.
+const (
+	PictureStartCode         StartCode = 0x00
+	SliceStartCodeFirst      StartCode = 0x01
+	SliceStartCodeLast       StartCode = 0xAF
+	UserDataStartCode        StartCode = 0xB2
+	SequenceHeaderStartCode  StartCode = 0xB3
+	SequenceErrorCode        StartCode = 0xB4
+	ExtensionStartCode       StartCode = 0xB5
+	SequenceEndCode          StartCode = 0xB7
+	GroupOfPicturesStartCode StartCode = 0xB8
+)
+
+// findStartCode returns the position of the first occurrence of a start code.
+func findStartCode(buf []byte, startCode StartCode) int {
+	for i := 0; i < (len(buf) - 3); i++ {
+		if buf[i] == 0 && buf[i+1] == 0 && buf[i+2] == 1 {
+			if StartCode(buf[i+3]) == startCode {
+				return i
+			}
+			i += 2
+		}
+	}
+	return -1
+}
@@ -0,0 +1,92 @@
+package mpeg1video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// PictureCodingType is the coding type of a picture.
+type PictureCodingType uint8
+
+// coding types.
+// Specification: ISO 13818-2, Table 6-12
+const (
+	PictureCodingTypeI PictureCodingType = 1
+	PictureCodingTypeP PictureCodingType = 2
+	PictureCodingTypeB PictureCodingType = 3
+	PictureCodingTypeD PictureCodingType = 4
+)
+
+// String implements fmt.Stringer.
+func (t PictureCodingType) String() string {
+	switch t {
+	case PictureCodingTypeI:
+		return "I"
+	case PictureCodingTypeP:
+		return "P"
+	case PictureCodingTypeB:
+		return "B"
+	case PictureCodingTypeD:
+		return "D"
+	}
+	return fmt.Sprintf("unknown (%d)", t)
+}
+
+// PictureHeader is a picture header.
+// Decoding stops after vbv_delay.
+// Specification: ISO 13818-2, 6.2.3
+type PictureHeader struct {
+	// display order of the picture, relative to the last GOP header.
+	TemporalReference uint16
+
+	CodingType PictureCodingType
+	VBVDelay   uint16
+}
+
+// Unmarshal decodes a PictureHeader.
+// The buffer must start with a picture_start_code.
+func (h *PictureHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:4], []byte{0, 0, 1, byte(PictureStartCode)}) {
+		return fmt.Errorf("picture_start_code not found")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 29)
+	if err != nil {
+		return err
+	}
+
+	h.TemporalReference = uint16(bits.ReadBitsUnsafe(buf, &pos, 10))
+	h.CodingType = PictureCodingType(bits.ReadBitsUnsafe(buf, &pos, 3))
+	h.VBVDelay = uint16(bits.ReadBitsUnsafe(buf, &pos, 16))
+
+	if h.CodingType < PictureCodingTypeI || h.CodingType > PictureCodingTypeD {
+		return fmt.Errorf("invalid picture_coding_type: %d", h.CodingType)
+	}
+
+	return nil
+}
+
+// FindPictureHeader returns the first picture header contained inside a frame, starting from its start code.
+func FindPictureHeader(frame []byte) []byte {
+	i := findStartCode(frame, PictureStartCode)
+	if i < 0 {
+		return nil
+	}
+	return frame[i:]
+}
+
+// IsRandomAccess checks whether a frame can be decoded independently,
+// that is, whether its first picture is intra-coded.
+func IsRandomAccess(frame []byte) bool {
+	var h PictureHeader
+	err := h.Unmarshal(FindPictureHeader(frame))
+	if err != nil {
+		return false
+	}
+	return h.CodingType == PictureCodingTypeI
+}
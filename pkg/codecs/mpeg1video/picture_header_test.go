@@ -0,0 +1,61 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPictureHeader = []struct {
+	name string
+	byts []byte
+	h    PictureHeader
+}{
+	{
+		"i",
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8},
+		PictureHeader{
+			TemporalReference: 2,
+			CodingType:        PictureCodingTypeI,
+			VBVDelay:          0xffff,
+		},
+	},
+	{
+		"b",
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x5f, 0xff, 0xf8},
+		PictureHeader{
+			TemporalReference: 1,
+			CodingType:        PictureCodingTypeB,
+			VBVDelay:          0xffff,
+		},
+	},
+}
+
+func TestPictureHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesPictureHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h PictureHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestIsRandomAccess(t *testing.T) {
+	require.True(t, IsRandomAccess(append([]byte{0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x00},
+		casesPictureHeader[0].byts...)))
+	require.False(t, IsRandomAccess(casesPictureHeader[1].byts))
+	require.False(t, IsRandomAccess([]byte{0x00, 0x00, 0x01, 0xb8}))
+}
+
+func FuzzPictureHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesPictureHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h PictureHeader
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,65 @@
+package mpeg1video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+const extensionIDSequence = 1
+
+// SequenceExtension is a sequence extension.
+// It is present in MPEG-2 streams only.
+// Specification: ISO 13818-2, 6.2.2.3
+type SequenceExtension struct {
+	ProfileAndLevelIndication uint8
+	ProgressiveSequence       bool
+	ChromaFormat              uint8
+	HorizontalSizeExtension   uint8
+	VerticalSizeExtension     uint8
+	BitRateExtension          uint16
+	VBVBufferSizeExtension    uint8
+	LowDelay                  bool
+	FrameRateExtensionN       uint8
+	FrameRateExtensionD       uint8
+}
+
+// Unmarshal decodes a SequenceExtension.
+// The buffer must start with an extension_start_code.
+func (e *SequenceExtension) Unmarshal(buf []byte) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:4], []byte{0, 0, 1, byte(ExtensionStartCode)}) {
+		return fmt.Errorf("extension_start_code not found")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 48)
+	if err != nil {
+		return err
+	}
+
+	extensionID := bits.ReadBitsUnsafe(buf, &pos, 4)
+	if extensionID != extensionIDSequence {
+		return fmt.Errorf("extension_start_code_identifier is not sequence_extension (%d)", extensionID)
+	}
+
+	e.ProfileAndLevelIndication = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+	e.ProgressiveSequence = bits.ReadFlagUnsafe(buf, &pos)
+	e.ChromaFormat = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.HorizontalSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.VerticalSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.BitRateExtension = uint16(bits.ReadBitsUnsafe(buf, &pos, 12))
+
+	if !bits.ReadFlagUnsafe(buf, &pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	e.VBVBufferSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+	e.LowDelay = bits.ReadFlagUnsafe(buf, &pos)
+	e.FrameRateExtensionN = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.FrameRateExtensionD = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceExtensionUnmarshal(t *testing.T) {
+	var e SequenceExtension
+	err := e.Unmarshal([]byte{0x00, 0x00, 0x01, 0xb5, 0x14, 0x8a, 0x00, 0x01, 0x00, 0x00})
+	require.NoError(t, err)
+	require.Equal(t, SequenceExtension{
+		ProfileAndLevelIndication: 0x48,
+		ProgressiveSequence:       true,
+		ChromaFormat:              1,
+	}, e)
+
+	// sequence_display_extension
+	err = e.Unmarshal([]byte{0x00, 0x00, 0x01, 0xb5, 0x23, 0x05, 0x05, 0x05, 0x1e, 0x02})
+	require.EqualError(t, err, "extension_start_code_identifier is not sequence_extension (2)")
+}
+
+func FuzzSequenceExtensionUnmarshal(f *testing.F) {
+	f.Add([]byte{0x00, 0x00, 0x01, 0xb5, 0x14, 0x8a, 0x00, 0x01, 0x00, 0x00})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var e SequenceExtension
+		e.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,115 @@
+package mpeg1video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// SequenceHeader is a sequence header.
+// Specification: ISO 11172-2, 2.4.2.3 and ISO 13818-2, 6.2.2.1
+type SequenceHeader struct {
+	HorizontalSize            int
+	VerticalSize              int
+	AspectRatioInformation    uint8
+	FrameRateCode             uint8
+	BitRate                   uint32 // in units of 400 bits/s
+	VBVBufferSize             uint16 // in units of 16 kbit
+	ConstrainedParametersFlag bool
+	IntraQuantiserMatrix      []byte // optional, in zigzag order
+	NonIntraQuantiserMatrix   []byte // optional, in zigzag order
+}
+
+func readQuantiserMatrix(buf []byte, pos *int) ([]byte, error) {
+	load, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if !load {
+		return nil, nil
+	}
+
+	err = bits.HasSpace(buf, *pos, 64*8)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make([]byte, 64)
+	for i := range m {
+		m[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+
+	return m, nil
+}
+
+// Unmarshal decodes a SequenceHeader.
+// The buffer must start with a sequence_header_code.
+func (h *SequenceHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:4], []byte{0, 0, 1, byte(SequenceHeaderStartCode)}) {
+		return fmt.Errorf("sequence_header_code not found")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 64)
+	if err != nil {
+		return err
+	}
+
+	h.HorizontalSize = int(bits.ReadBitsUnsafe(buf, &pos, 12))
+	h.VerticalSize = int(bits.ReadBitsUnsafe(buf, &pos, 12))
+	h.AspectRatioInformation = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameRateCode = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.BitRate = uint32(bits.ReadBitsUnsafe(buf, &pos, 18))
+
+	if !bits.ReadFlagUnsafe(buf, &pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.VBVBufferSize = uint16(bits.ReadBitsUnsafe(buf, &pos, 10))
+	h.ConstrainedParametersFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if h.HorizontalSize == 0 || h.VerticalSize == 0 {
+		return fmt.Errorf("invalid size: %dx%d", h.HorizontalSize, h.VerticalSize)
+	}
+
+	h.IntraQuantiserMatrix, err = readQuantiserMatrix(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	h.NonIntraQuantiserMatrix, err = readQuantiserMatrix(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FrameRate returns the frame rate, as a fraction.
+// Specification: ISO 13818-2, Table 6-4
+func (h SequenceHeader) FrameRate() (int, int) {
+	switch h.FrameRateCode {
+	case 1:
+		return 24000, 1001
+	case 2:
+		return 24, 1
+	case 3:
+		return 25, 1
+	case 4:
+		return 30000, 1001
+	case 5:
+		return 30, 1
+	case 6:
+		return 50, 1
+	case 7:
+		return 60000, 1001
+	case 8:
+		return 60, 1
+	default:
+		return 0, 1
+	}
+}
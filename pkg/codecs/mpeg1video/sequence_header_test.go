@@ -0,0 +1,53 @@
+package mpeg1video
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceHeaderUnmarshalQuantiserMatrix(t *testing.T) {
+	matrix := bytes.Repeat([]byte{16}, 64)
+
+	// load_intra_quantiser_matrix = 1, load_non_intra_quantiser_matrix = 0
+	byts := []byte{0x00, 0x00, 0x01, 0xb3, 0x16, 0x01, 0x20, 0x13, 0x02, 0xce, 0xe0, 0xa6}
+	for i := 0; i < 64; i++ {
+		byts[len(byts)-1] |= matrix[i] >> 7
+		byts = append(byts, matrix[i]<<1)
+	}
+
+	var h SequenceHeader
+	err := h.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, matrix, h.IntraQuantiserMatrix)
+	require.Equal(t, []byte(nil), h.NonIntraQuantiserMatrix)
+}
+
+func TestSequenceHeaderFrameRate(t *testing.T) {
+	for _, ca := range []struct {
+		code uint8
+		num  int
+		den  int
+	}{
+		{1, 24000, 1001},
+		{3, 25, 1},
+		{7, 60000, 1001},
+		{9, 0, 1},
+	} {
+		num, den := SequenceHeader{FrameRateCode: ca.code}.FrameRate()
+		require.Equal(t, ca.num, num)
+		require.Equal(t, ca.den, den)
+	}
+}
+
+func FuzzSequenceHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesConfig {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h SequenceHeader
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -2,21 +2,166 @@ package mpeg4audio
 
 import (
 	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
 )
 
+// ADTSCorruptedFrameError is returned when the CRC of an ADTS frame does not match.
+type ADTSCorruptedFrameError struct {
+	ExpectedCRC uint16
+	ComputedCRC uint16
+}
+
+// Error implements the error interface.
+func (e ADTSCorruptedFrameError) Error() string {
+	return fmt.Sprintf("corrupted ADTS frame: CRC is 0x%.4x, expected 0x%.4x", e.ComputedCRC, e.ExpectedCRC)
+}
+
+// CRC-16 with polynomial 0x8005, computed bit by bit.
+// Bits beyond the end of the buffer are considered zero.
+// Specification: ISO 11172-3, 2.4.3.1
+func adtsCRCUpdate(crc uint16, buf []byte, pos int, n int) uint16 {
+	for i := pos; i < (pos + n); i++ {
+		var bit uint16
+		if (i / 8) < len(buf) {
+			bit = uint16(buf[i/8]>>(7-(i%8))) & 0x01
+		}
+
+		if ((crc >> 15) ^ bit) != 0 {
+			crc = (crc << 1) ^ 0x8005
+		} else {
+			crc <<= 1
+		}
+	}
+	return crc
+}
+
+func newRawDataBlockParser(header []byte, block []byte) *rawDataBlockParser {
+	return &rawDataBlockParser{
+		objectType:      ObjectType((header[2] >> 6) + 1),
+		sampleRateIndex: int((header[2] >> 2) & 0x0F),
+		buf:             block,
+	}
+}
+
+// CRC of a raw_data_block.
+// Each syntactic element is protected by type, excluding its id_syn_ele.
+// An error is returned when the raw_data_block cannot be walked,
+// in this case the CRC cannot be computed.
+func adtsBlockCRCUpdate(crc uint16, header []byte, block []byte) (uint16, error) {
+	regions, err := newRawDataBlockParser(header, block).parse()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range regions {
+		n := r.end - r.start
+		if r.maxBits != 0 && n > r.maxBits {
+			n = r.maxBits
+		}
+
+		crc = adtsCRCUpdate(crc, block, r.start, n)
+
+		if r.maxBits != 0 {
+			crc = adtsCRCUpdate(crc, nil, 0, r.maxBits-n)
+		}
+	}
+
+	return crc, nil
+}
+
+// split raw_data_blocks of a frame without CRC, whose positions are not signaled.
+func adtsSplitBlocks(header []byte, payload []byte, blockCount int) ([][]byte, error) {
+	aus := make([][]byte, blockCount)
+	start := 0
+
+	for i := 0; i < (blockCount - 1); i++ {
+		p := newRawDataBlockParser(header, payload[start:])
+
+		_, err := p.parse()
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the end of raw_data_block: %w", err)
+		}
+
+		// raw_data_block is followed by byte_alignment()
+		end := start + (p.pos+7)/8
+
+		aus[i] = payload[start:end]
+		start = end
+	}
+
+	if start == len(payload) {
+		return nil, fmt.Errorf("invalid frame length")
+	}
+
+	aus[blockCount-1] = payload[start:]
+
+	return aus, nil
+}
+
 // ADTSPacket is an ADTS packet.
 // Specification: ISO 14496-3, Table 1.A.5
 type ADTSPacket struct {
 	Type         ObjectType
 	SampleRate   int
 	ChannelCount int
-	AU           []byte
+
+	// present when channel configuration is 0.
+	// In this case, the AU starts with the program_config_element.
+	ProgramConfigElement *ProgramConfigElement
+
+	// whether the frame is protected by a CRC.
+	// The CRC is not checked, and is not written,
+	// when the raw_data_block contains elements that cannot be walked.
+	HasCRC bool
+
+	AU []byte
+}
+
+func (p *ADTSPacket) unmarshalProgramConfigElement() error {
+	pos := 0
+
+	id, err := bits.ReadBits(p.AU, &pos, 3)
+	if err != nil {
+		return err
+	}
+
+	if id != rawDataBlockIDPCE {
+		return fmt.Errorf("program_config_element is missing")
+	}
+
+	p.ProgramConfigElement = &ProgramConfigElement{}
+	err = p.ProgramConfigElement.unmarshalFromPos(p.AU, &pos, 0)
+	if err != nil {
+		return err
+	}
+
+	p.ChannelCount = p.ProgramConfigElement.ChannelCount()
+	return nil
+}
+
+// size of the program_config_element that is prepended to the AU.
+func (p ADTSPacket) pceSize() int {
+	if p.ProgramConfigElement == nil ||
+		(len(p.AU) != 0 && (p.AU[0]>>5) == rawDataBlockIDPCE) {
+		return 0
+	}
+
+	n := 3 + p.ProgramConfigElement.marshalSizeBits(3)
+
+	ret := n / 8
+	if (n % 8) != 0 {
+		ret++
+	}
+
+	return ret
 }
 
 // ADTSPackets is a group of ADTS packets.
 type ADTSPackets []*ADTSPacket
 
 // Unmarshal decodes an ADTS stream into ADTS packets.
+// Frames that contain multiple raw_data_blocks are split into multiple packets.
 func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 	// refs: https://wiki.multimedia.cx/index.php/ADTS
 
@@ -33,36 +178,32 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 			return fmt.Errorf("invalid syncword")
 		}
 
-		protectionAbsent := buf[pos+1] & 0x01
-		if protectionAbsent != 1 {
-			return fmt.Errorf("CRC is not supported")
-		}
+		hasCRC := (buf[pos+1] & 0x01) == 0
 
-		pkt := &ADTSPacket{}
-
-		pkt.Type = ObjectType((buf[pos+2] >> 6) + 1)
-		switch pkt.Type {
-		case ObjectTypeAACLC:
+		typ := ObjectType((buf[pos+2] >> 6) + 1)
+		switch typ {
+		case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACLTP:
 		default:
-			return fmt.Errorf("unsupported audio type: %d", pkt.Type)
+			return fmt.Errorf("unsupported audio type: %d", typ)
 		}
 
 		sampleRateIndex := (buf[pos+2] >> 2) & 0x0F
-		switch {
-		case sampleRateIndex <= 12:
-			pkt.SampleRate = sampleRates[sampleRateIndex]
-
-		default:
+		if sampleRateIndex > 12 {
 			return fmt.Errorf("invalid sample rate index: %d", sampleRateIndex)
 		}
 
 		channelConfig := ((buf[pos+2] & 0x01) << 2) | ((buf[pos+3] >> 6) & 0x03)
+		var channelCount int
+
 		switch {
+		case channelConfig == 0:
+			// channel count is provided by the program_config_element
+
 		case channelConfig >= 1 && channelConfig <= 6:
-			pkt.ChannelCount = int(channelConfig)
+			channelCount = int(channelConfig)
 
 		case channelConfig == 7:
-			pkt.ChannelCount = 8
+			channelCount = 8
 
 		default:
 			return fmt.Errorf("invalid channel configuration: %d", channelConfig)
@@ -76,23 +217,50 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 			return fmt.Errorf("invalid FrameLen")
 		}
 
-		if frameLen > MaxAccessUnitSize {
-			return fmt.Errorf("access unit size (%d) is too big, maximum is %d", frameLen, MaxAccessUnitSize)
-		}
+		blockCount := int(buf[pos+6]&0x03) + 1
 
-		frameCount := buf[pos+6] & 0x03
-		if frameCount != 0 {
-			return fmt.Errorf("frame count greater than 1 is not supported")
+		if frameLen > (MaxAccessUnitSize * blockCount) {
+			return fmt.Errorf("access unit size (%d) is too big, maximum is %d", frameLen, MaxAccessUnitSize)
 		}
 
 		if len(buf[pos+7:]) < frameLen {
 			return fmt.Errorf("invalid frame length")
 		}
 
-		pkt.AU = buf[pos+7 : pos+7+frameLen]
+		aus, err := unmarshalADTSPayload(buf[pos:pos+7], buf[pos+7:pos+7+frameLen], hasCRC, blockCount)
+		if err != nil {
+			return err
+		}
+
 		pos += 7 + frameLen
 
-		*ps = append(*ps, pkt)
+		for i, au := range aus {
+			if len(au) > MaxAccessUnitSize {
+				return fmt.Errorf("access unit size (%d) is too big, maximum is %d", len(au), MaxAccessUnitSize)
+			}
+
+			pkt := &ADTSPacket{
+				Type:         typ,
+				SampleRate:   sampleRates[sampleRateIndex],
+				ChannelCount: channelCount,
+				HasCRC:       hasCRC,
+				AU:           au,
+			}
+
+			if channelConfig == 0 {
+				if i == 0 {
+					err = pkt.unmarshalProgramConfigElement()
+					if err != nil {
+						return fmt.Errorf("invalid program_config_element: %w", err)
+					}
+				} else {
+					pkt.ProgramConfigElement = (*ps)[len(*ps)-1].ProgramConfigElement
+					pkt.ChannelCount = (*ps)[len(*ps)-1].ChannelCount
+				}
+			}
+
+			*ps = append(*ps, pkt)
+		}
 
 		if (bl - pos) == 0 {
 			break
@@ -102,20 +270,107 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 	return nil
 }
 
+// unmarshalADTSPayload extracts raw_data_blocks from the part of the frame that follows the header,
+// and checks CRCs.
+// Specification: ISO 14496-3, Table 1.A.4
+func unmarshalADTSPayload(header []byte, payload []byte, hasCRC bool, blockCount int) ([][]byte, error) {
+	if !hasCRC {
+		if blockCount != 1 {
+			return adtsSplitBlocks(header, payload, blockCount)
+		}
+		return [][]byte{payload}, nil
+	}
+
+	if blockCount == 1 {
+		if len(payload) < 3 {
+			return nil, fmt.Errorf("invalid frame length")
+		}
+
+		expected := uint16(payload[0])<<8 | uint16(payload[1])
+		block := payload[2:]
+
+		crc := adtsCRCUpdate(0xFFFF, header, 0, 56)
+		crc, err := adtsBlockCRCUpdate(crc, header, block)
+
+		// skip the check when the CRC cannot be computed
+		if err == nil && crc != expected {
+			return nil, ADTSCorruptedFrameError{ExpectedCRC: expected, ComputedCRC: crc}
+		}
+
+		return [][]byte{block}, nil
+	}
+
+	// adts_header_error_check()
+	n := (blockCount-1)*2 + 2
+	if len(payload) < n {
+		return nil, fmt.Errorf("invalid frame length")
+	}
+
+	expected := uint16(payload[n-2])<<8 | uint16(payload[n-1])
+	crc := adtsCRCUpdate(0xFFFF, header, 0, 56)
+	crc = adtsCRCUpdate(crc, payload, 0, (n-2)*8)
+
+	if crc != expected {
+		return nil, ADTSCorruptedFrameError{ExpectedCRC: expected, ComputedCRC: crc}
+	}
+
+	blocks := payload[n:]
+	starts := make([]int, blockCount+1)
+
+	for i := 1; i < blockCount; i++ {
+		starts[i] = int(uint16(payload[(i-1)*2])<<8 | uint16(payload[(i-1)*2+1]))
+	}
+	starts[blockCount] = len(blocks)
+
+	aus := make([][]byte, blockCount)
+
+	for i := range aus {
+		// each raw_data_block is followed by adts_raw_data_block_error_check()
+		if starts[i+1] < (starts[i]+3) || starts[i+1] > len(blocks) {
+			return nil, fmt.Errorf("invalid raw_data_block position")
+		}
+
+		block := blocks[starts[i] : starts[i+1]-2]
+		expected = uint16(blocks[starts[i+1]-2])<<8 | uint16(blocks[starts[i+1]-1])
+
+		var err error
+		crc, err = adtsBlockCRCUpdate(0xFFFF, header, block)
+
+		// skip the check when the CRC cannot be computed
+		if err == nil && crc != expected {
+			return nil, ADTSCorruptedFrameError{ExpectedCRC: expected, ComputedCRC: crc}
+		}
+
+		aus[i] = block
+	}
+
+	return aus, nil
+}
+
 func (ps ADTSPackets) marshalSize() int {
 	n := 0
 	for _, pkt := range ps {
-		n += 7 + len(pkt.AU)
+		n += 7 + pkt.pceSize() + len(pkt.AU)
+		if pkt.HasCRC {
+			n += 2
+		}
 	}
 	return n
 }
 
 // Marshal encodes ADTS packets into an ADTS stream.
+// Each packet is encoded into a frame with a single raw_data_block.
 func (ps ADTSPackets) Marshal() ([]byte, error) {
 	buf := make([]byte, ps.marshalSize())
 	pos := 0
 
 	for _, pkt := range ps {
+		switch pkt.Type {
+		case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACLTP:
+		default:
+			return nil, fmt.Errorf("unsupported audio type: %d", pkt.Type)
+		}
+
 		sampleRateIndex, ok := reverseSampleRates[pkt.SampleRate]
 		if !ok {
 			return nil, fmt.Errorf("invalid sample rate: %d", pkt.SampleRate)
@@ -123,6 +378,9 @@ func (ps ADTSPackets) Marshal() ([]byte, error) {
 
 		var channelConfig int
 		switch {
+		case pkt.ProgramConfigElement != nil:
+			channelConfig = 0
+
 		case pkt.ChannelCount >= 1 && pkt.ChannelCount <= 6:
 			channelConfig = pkt.ChannelCount
 
@@ -133,21 +391,71 @@ func (ps ADTSPackets) Marshal() ([]byte, error) {
 			return nil, fmt.Errorf("invalid channel count (%d)", pkt.ChannelCount)
 		}
 
-		frameLen := len(pkt.AU) + 7
+		pceSize := pkt.pceSize()
+		header := buf[pos : pos+7]
+		pos += 7
 
-		fullness := 0x07FF // like ffmpeg does
+		crcPos := pos
+		if pkt.HasCRC {
+			pos += 2
+		}
 
-		buf[pos+0] = 0xFF
-		buf[pos+1] = 0xF1
-		buf[pos+2] = uint8((int(pkt.Type-1) << 6) | (sampleRateIndex << 2) | ((channelConfig >> 2) & 0x01))
-		buf[pos+3] = uint8((channelConfig&0x03)<<6 | (frameLen>>11)&0x03)
-		buf[pos+4] = uint8((frameLen >> 3) & 0xFF)
-		buf[pos+5] = uint8((frameLen&0x07)<<5 | ((fullness >> 6) & 0x1F))
-		buf[pos+6] = uint8((fullness & 0x3F) << 2)
-		pos += 7
+		blockStart := pos
+
+		if pceSize != 0 {
+			bpos := pos * 8
+			bits.WriteBitsUnsafe(buf, &bpos, rawDataBlockIDPCE, 3)
+			err := pkt.ProgramConfigElement.marshalTo(buf, &bpos, pos*8)
+			if err != nil {
+				return nil, err
+			}
+			pos += pceSize
+		}
 
 		pos += copy(buf[pos:], pkt.AU)
+
+		marshalADTSHeader(header, pkt.Type, sampleRateIndex, channelConfig, pkt.HasCRC, pos-crcPos)
+
+		if pkt.HasCRC {
+			crc := adtsCRCUpdate(0xFFFF, header, 0, 56)
+			crc, err := adtsBlockCRCUpdate(crc, header, buf[blockStart:pos])
+
+			if err == nil {
+				buf[crcPos] = byte(crc >> 8)
+				buf[crcPos+1] = byte(crc)
+			} else {
+				// the CRC cannot be computed, write the frame without it
+				pos = crcPos + copy(buf[crcPos:], buf[blockStart:pos])
+				marshalADTSHeader(header, pkt.Type, sampleRateIndex, channelConfig, false, pos-crcPos)
+			}
+		}
 	}
 
-	return buf, nil
+	return buf[:pos], nil
+}
+
+func marshalADTSHeader(
+	header []byte,
+	typ ObjectType,
+	sampleRateIndex int,
+	channelConfig int,
+	hasCRC bool,
+	payloadLen int,
+) {
+	frameLen := payloadLen + 7
+
+	var protectionAbsent uint8 = 1
+	if hasCRC {
+		protectionAbsent = 0
+	}
+
+	fullness := 0x07FF // like ffmpeg does
+
+	header[0] = 0xFF
+	header[1] = 0xF0 | protectionAbsent
+	header[2] = uint8((int(typ-1) << 6) | (sampleRateIndex << 2) | ((channelConfig >> 2) & 0x01))
+	header[3] = uint8((channelConfig&0x03)<<6 | (frameLen>>11)&0x03)
+	header[4] = uint8((frameLen >> 3) & 0xFF)
+	header[5] = uint8((frameLen&0x07)<<5 | ((fullness >> 6) & 0x1F))
+	header[6] = uint8((fullness & 0x3F) << 2)
 }
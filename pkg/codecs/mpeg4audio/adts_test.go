@@ -23,6 +23,84 @@ var casesADTS = []struct {
 			},
 		},
 	},
+	{
+		"aac main",
+		[]byte{0xff, 0xf1, 0x0c, 0x80, 0x1, 0x3f, 0xfc, 0xaa, 0xbb},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACMain,
+				SampleRate:   48000,
+				ChannelCount: 2,
+				AU:           []byte{0xaa, 0xbb},
+			},
+		},
+	},
+	{
+		"aac ltp",
+		[]byte{0xff, 0xf1, 0xcc, 0x80, 0x1, 0x3f, 0xfc, 0xaa, 0xbb},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACLTP,
+				SampleRate:   48000,
+				ChannelCount: 2,
+				AU:           []byte{0xaa, 0xbb},
+			},
+		},
+	},
+	{
+		"crc",
+		[]byte{
+			0xff, 0xf0, 0x5c, 0x40, 0x05, 0xdf, 0xfc, 0x13,
+			0xbb, 0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0x80,
+			0x85, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+			0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+			0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+			0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2f,
+		},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   22050,
+				ChannelCount: 1,
+				HasCRC:       true,
+				AU: []byte{
+					0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0x80, 0x85,
+					0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+					0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+					0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+					0x2d, 0x2d, 0x2d, 0x2d, 0x2f,
+				},
+			},
+		},
+	},
+	{
+		"program config element",
+		[]byte{
+			0xff, 0xf1, 0x4c, 0x00, 0x02, 0x3f, 0xfc, 0xa0,
+			0x99, 0x00, 0xa0, 0x00, 0x21, 0x10, 0x00, 0x21,
+			0x10,
+		},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 6,
+				ProgramConfigElement: &ProgramConfigElement{
+					ObjectType: 1,
+					SampleRate: 48000,
+					FrontChannelElements: []*ProgramConfigElementChannel{
+						{},
+						{IsCPE: true},
+					},
+					BackChannelElements: []*ProgramConfigElementChannel{
+						{IsCPE: true, TagSelect: 1},
+					},
+					LFEChannelElements: []uint8{0},
+				},
+				AU: []byte{0xa0, 0x99, 0x00, 0xa0, 0x00, 0x21, 0x10, 0x00, 0x21, 0x10},
+			},
+		},
+	},
 	{
 		"multiple",
 		[]byte{
@@ -68,6 +146,220 @@ func TestADTSMarshal(t *testing.T) {
 	}
 }
 
+func TestADTSMarshalProgramConfigElement(t *testing.T) {
+	byts, err := ADTSPackets{{
+		Type:       ObjectTypeAACLC,
+		SampleRate: 48000,
+		ProgramConfigElement: &ProgramConfigElement{
+			ObjectType: 1,
+			SampleRate: 48000,
+			FrontChannelElements: []*ProgramConfigElementChannel{
+				{},
+				{IsCPE: true},
+			},
+			BackChannelElements: []*ProgramConfigElementChannel{
+				{IsCPE: true, TagSelect: 1},
+			},
+			LFEChannelElements: []uint8{0},
+		},
+		AU: []byte{0x21, 0x10},
+	}}.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0xff, 0xf1, 0x4c, 0x00, 0x02, 0x3f, 0xfc, 0xa0,
+		0x99, 0x00, 0xa0, 0x00, 0x21, 0x10, 0x00, 0x21,
+		0x10,
+	}, byts)
+}
+
+func TestADTSUnmarshalMultipleBlocks(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		pkts ADTSPackets
+	}{
+		{
+			"crc",
+			// generated with libfdk-aac
+			[]byte{
+				0xff, 0xf0, 0x5c, 0x40, 0x0b, 0x82, 0xd1, 0x00,
+				0x23, 0x0c, 0x4f, 0x01, 0x40, 0x22, 0x80, 0xa3,
+				0x78, 0x60, 0x85, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2f, 0x7f, 0xa3, 0x01, 0x40,
+				0x22, 0x80, 0xa3, 0x78, 0xb8, 0x85, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2f, 0x7f, 0xa3,
+			},
+			ADTSPackets{
+				{
+					Type:         ObjectTypeAACLC,
+					SampleRate:   22050,
+					ChannelCount: 1,
+					HasCRC:       true,
+					AU: []byte{
+						0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0x60, 0x85,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2f,
+					},
+				},
+				{
+					Type:         ObjectTypeAACLC,
+					SampleRate:   22050,
+					ChannelCount: 1,
+					HasCRC:       true,
+					AU: []byte{
+						0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0xb8, 0x85,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2f,
+					},
+				},
+			},
+		},
+		{
+			"no crc",
+			[]byte{
+				0xff, 0xf1, 0x5c, 0x40, 0x0a, 0x82, 0xd1, 0x01,
+				0x40, 0x22, 0x80, 0xa3, 0x78, 0x60, 0x85, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2f,
+				0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0xb8, 0x85,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+				0x2d, 0x2d, 0x2d, 0x2f,
+			},
+			ADTSPackets{
+				{
+					Type:         ObjectTypeAACLC,
+					SampleRate:   22050,
+					ChannelCount: 1,
+					AU: []byte{
+						0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0x60, 0x85,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2f,
+					},
+				},
+				{
+					Type:         ObjectTypeAACLC,
+					SampleRate:   22050,
+					ChannelCount: 1,
+					AU: []byte{
+						0x01, 0x40, 0x22, 0x80, 0xa3, 0x78, 0xb8, 0x85,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+						0x2d, 0x2d, 0x2d, 0x2f,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var pkts ADTSPackets
+			err := pkts.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestADTSUnmarshalCRC(t *testing.T) {
+	// generated with libfdk-aac
+	for _, ca := range []struct {
+		name string
+		byts []byte
+	}{
+		{
+			"single channel element",
+			[]byte{
+				0xff, 0xf0, 0x5c, 0x40, 0x07, 0x02, 0xc4, 0x33,
+				0x35, 0x01, 0x24, 0x31, 0x07, 0xc0, 0x21, 0x10,
+				0x07, 0xcf, 0x75, 0xf1, 0xe7, 0x4d, 0x35, 0x71,
+				0x97, 0x58, 0x4d, 0x33, 0x6b, 0x9c, 0x6c, 0x74,
+				0x01, 0x39, 0x47, 0x82, 0x28, 0x8d, 0x02, 0xcd,
+				0x0b, 0x86, 0x81, 0x65, 0xb6, 0x34, 0x00, 0x0b,
+				0xa0, 0x34, 0x86, 0x91, 0x4a, 0x89, 0x40, 0x38,
+			},
+		},
+		{
+			"channel pair element",
+			[]byte{
+				0xff, 0xf0, 0x5c, 0x80, 0x0d, 0xe2, 0xc8, 0x10,
+				0x9a, 0x21, 0x19, 0x54, 0xd0, 0x24, 0x70, 0x02,
+				0x11, 0x25, 0x7c, 0x79, 0xd4, 0x97, 0x43, 0x9e,
+				0x0d, 0x2b, 0x34, 0x54, 0x6b, 0x27, 0x6b, 0x8b,
+				0xf2, 0x71, 0x58, 0xe3, 0xc9, 0x45, 0x35, 0xe6,
+				0x4a, 0x2e, 0x04, 0xf6, 0x00, 0x5c, 0x44, 0x03,
+				0x68, 0x14, 0x80, 0xa0, 0x94, 0x42, 0x40, 0x02,
+				0x3d, 0x80, 0x36, 0xd4, 0x03, 0xc3, 0x34, 0x09,
+				0x12, 0x81, 0xc5, 0x60, 0x21, 0x19, 0x3a, 0xfa,
+				0xf8, 0x29, 0x27, 0x33, 0x53, 0x1b, 0x6b, 0x27,
+				0x6b, 0x8b, 0xf2, 0x71, 0x58, 0xe3, 0xa9, 0x45,
+				0xa5, 0x38, 0xae, 0x00, 0xa0, 0x19, 0xc3, 0x41,
+				0x11, 0xa0, 0x2a, 0xcc, 0x2f, 0x6d, 0x00, 0x3b,
+				0x09, 0x58, 0xd0, 0x54, 0x01, 0xe1, 0xe0,
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var pkts ADTSPackets
+			err := pkts.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.byts[9:], pkts[0].AU)
+		})
+	}
+}
+
+func TestADTSUnmarshalCorruptedFrame(t *testing.T) {
+	var pkts ADTSPackets
+	err := pkts.Unmarshal([]byte{
+		0xff, 0xf0, 0x5c, 0x40, 0x05, 0xdf, 0xfc, 0x13,
+		0xbb, 0x01, 0x00, 0x22, 0x80, 0xa3, 0x78, 0x80,
+		0x85, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+		0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+		0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2d,
+		0x2d, 0x2d, 0x2d, 0x2d, 0x2d, 0x2f,
+	})
+
+	var cerr ADTSCorruptedFrameError
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, uint16(0x13bb), cerr.ExpectedCRC)
+}
+
+func TestADTSUnsupportedBlock(t *testing.T) {
+	// the CRC of raw_data_blocks that cannot be walked is not checked
+	var pkts ADTSPackets
+	err := pkts.Unmarshal([]byte{0xff, 0xf0, 0x4c, 0x80, 0x01, 0x7f, 0xfc, 0x6f, 0x2d, 0xaa, 0xbb})
+	require.NoError(t, err)
+	require.Equal(t, ADTSPackets{{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		HasCRC:       true,
+		AU:           []byte{0xaa, 0xbb},
+	}}, pkts)
+
+	// and is not written
+	byts, err := pkts.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0xf1, 0x4c, 0x80, 0x01, 0x3f, 0xfc, 0xaa, 0xbb}, byts)
+}
+
 func FuzzADTSUnmarshal(f *testing.F) {
 	for _, ca := range casesADTS {
 		f.Add(ca.byts)
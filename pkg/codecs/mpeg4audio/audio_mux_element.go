@@ -0,0 +1,277 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+func readBytesFromPos(buf []byte, pos *int, n int) ([]byte, error) {
+	err := bits.HasSpace(buf, *pos, n*8)
+	if err != nil {
+		return nil, err
+	}
+
+	if (*pos % 8) == 0 {
+		ret := buf[*pos/8 : *pos/8+n]
+		*pos += n * 8
+		return ret, nil
+	}
+
+	ret := make([]byte, n)
+	for i := range ret {
+		ret[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+	return ret, nil
+}
+
+func writeBytesToPos(buf []byte, pos *int, v []byte) {
+	if (*pos % 8) == 0 {
+		copy(buf[*pos/8:], v)
+		*pos += len(v) * 8
+		return
+	}
+
+	for _, b := range v {
+		bits.WriteBitsUnsafe(buf, pos, uint64(b), 8)
+	}
+}
+
+func writeFlag(buf []byte, pos *int, v bool) {
+	if v {
+		bits.WriteBitsUnsafe(buf, pos, 1, 1)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+	}
+}
+
+func layerPayloadSize(l *StreamMuxConfigLayer) (int, error) {
+	switch l.FrameLengthType {
+	case 0:
+		return -1, nil
+
+	case 1:
+		return int(l.FrameLength) + 20, nil
+
+	default:
+		return 0, fmt.Errorf("frameLengthType = %d is not supported", l.FrameLengthType)
+	}
+}
+
+// AudioMuxElement is an AudioMuxElement, the base unit of LATM.
+// Specification: ISO 14496-3, Table 1.41
+type AudioMuxElement struct {
+	// whether the element can contain a StreamMuxConfig.
+	// It is always true inside an AudioSyncStream.
+	// This is not part of the bitstream and must be set before decoding.
+	MuxConfigPresent bool
+
+	// whether the previous StreamMuxConfig is used.
+	UseSameStreamMux bool
+
+	// StreamMuxConfig.
+	// When it is not transmitted, it must be filled with
+	// the previous StreamMuxConfig before decoding.
+	StreamMuxConfig *StreamMuxConfig
+
+	// payloads, indexed by sub frame, program and layer.
+	Payloads [][][][]byte
+}
+
+// Unmarshal decodes an AudioMuxElement.
+func (e *AudioMuxElement) Unmarshal(buf []byte) error {
+	pos := 0
+
+	if e.MuxConfigPresent {
+		var err error
+		e.UseSameStreamMux, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if !e.UseSameStreamMux {
+			e.StreamMuxConfig = &StreamMuxConfig{}
+			err = e.StreamMuxConfig.unmarshalFromPos(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		e.UseSameStreamMux = false
+	}
+
+	if e.StreamMuxConfig == nil {
+		return fmt.Errorf("StreamMuxConfig is not available")
+	}
+
+	c := e.StreamMuxConfig
+	e.Payloads = make([][][][]byte, c.NumSubFrames+1)
+
+	for i := range e.Payloads {
+		// PayloadLengthInfo()
+
+		sizes := make([][]int, len(c.Programs))
+
+		for prog, p := range c.Programs {
+			sizes[prog] = make([]int, len(p.Layers))
+
+			for lay, l := range p.Layers {
+				size, err := layerPayloadSize(l)
+				if err != nil {
+					return err
+				}
+
+				if size < 0 {
+					size = 0
+					for {
+						tmp, err := bits.ReadBits(buf, &pos, 8)
+						if err != nil {
+							return err
+						}
+						size += int(tmp)
+
+						if tmp != 255 {
+							break
+						}
+					}
+				}
+
+				sizes[prog][lay] = size
+			}
+		}
+
+		// PayloadMux()
+
+		e.Payloads[i] = make([][][]byte, len(c.Programs))
+
+		for prog, p := range c.Programs {
+			e.Payloads[i][prog] = make([][]byte, len(p.Layers))
+
+			for lay := range p.Layers {
+				payload, err := readBytesFromPos(buf, &pos, sizes[prog][lay])
+				if err != nil {
+					return err
+				}
+
+				e.Payloads[i][prog][lay] = payload
+			}
+		}
+	}
+
+	if c.OtherDataPresent {
+		err := bits.HasSpace(buf, pos, int(c.OtherDataLenBits))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e AudioMuxElement) marshalSizeBits() (int, error) {
+	n := 0
+
+	if e.MuxConfigPresent {
+		n++
+
+		if !e.UseSameStreamMux {
+			n += e.StreamMuxConfig.marshalSizeBits()
+		}
+	}
+
+	c := e.StreamMuxConfig
+
+	if len(e.Payloads) != int(c.NumSubFrames+1) {
+		return 0, fmt.Errorf("payload count doesn't match sub frame count")
+	}
+
+	for _, subFrame := range e.Payloads {
+		if len(subFrame) != len(c.Programs) {
+			return 0, fmt.Errorf("payload count doesn't match program count")
+		}
+
+		for prog, p := range c.Programs {
+			if len(subFrame[prog]) != len(p.Layers) {
+				return 0, fmt.Errorf("payload count doesn't match layer count")
+			}
+
+			for lay, l := range p.Layers {
+				payload := subFrame[prog][lay]
+
+				size, err := layerPayloadSize(l)
+				if err != nil {
+					return 0, err
+				}
+
+				if size < 0 {
+					n += (len(payload)/255 + 1) * 8
+				} else if len(payload) != size {
+					return 0, fmt.Errorf("payload size doesn't match frame length")
+				}
+
+				n += len(payload) * 8
+			}
+		}
+	}
+
+	if c.OtherDataPresent {
+		n += int(c.OtherDataLenBits)
+	}
+
+	return n, nil
+}
+
+// Marshal encodes an AudioMuxElement.
+func (e AudioMuxElement) Marshal() ([]byte, error) {
+	if e.StreamMuxConfig == nil {
+		return nil, fmt.Errorf("StreamMuxConfig is not available")
+	}
+
+	n, err := e.marshalSizeBits()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	if e.MuxConfigPresent {
+		if e.UseSameStreamMux {
+			bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+		} else {
+			bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+
+			err = e.StreamMuxConfig.marshalTo(buf, &pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c := e.StreamMuxConfig
+
+	for _, subFrame := range e.Payloads {
+		for prog, p := range c.Programs {
+			for lay, l := range p.Layers {
+				if l.FrameLengthType == 0 {
+					size := len(subFrame[prog][lay])
+					for size >= 255 {
+						bits.WriteBitsUnsafe(buf, &pos, 255, 8)
+						size -= 255
+					}
+					bits.WriteBitsUnsafe(buf, &pos, uint64(size), 8)
+				}
+			}
+		}
+
+		for prog, p := range c.Programs {
+			for lay := range p.Layers {
+				writeBytesToPos(buf, &pos, subFrame[prog][lay])
+			}
+		}
+	}
+
+	// other data and byte alignment are left zeroed
+
+	return buf, nil
+}
@@ -0,0 +1,135 @@
+package mpeg4audio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testStreamMuxConfig = &StreamMuxConfig{
+	Programs: []*StreamMuxConfigProgram{{
+		Layers: []*StreamMuxConfigLayer{{
+			AudioSpecificConfig: &AudioSpecificConfig{
+				Type:         2,
+				SampleRate:   24000,
+				ChannelCount: 2,
+			},
+			LatmBufferFullness: 255,
+		}},
+	}},
+}
+
+var audioMuxElementCases = []struct {
+	name string
+	enc  []byte
+	dec  AudioMuxElement
+}{
+	{
+		"with config",
+		[]byte{
+			0x20, 0x00, 0x13, 0x10, 0x1f, 0xe0, 0x10, 0x08,
+			0x10,
+		},
+		AudioMuxElement{
+			MuxConfigPresent: true,
+			StreamMuxConfig:  testStreamMuxConfig,
+			Payloads:         [][][][]byte{{{{0x01, 0x02}}}},
+		},
+	},
+	{
+		"same config",
+		[]byte{0x80, 0xd5, 0x00},
+		AudioMuxElement{
+			MuxConfigPresent: true,
+			UseSameStreamMux: true,
+			StreamMuxConfig:  testStreamMuxConfig,
+			Payloads:         [][][][]byte{{{{0xaa}}}},
+		},
+	},
+	{
+		"same config, long payload",
+		append([]byte{0xff, 0x96}, bytes.Repeat([]byte{0x80}, 301)...),
+		AudioMuxElement{
+			MuxConfigPresent: true,
+			UseSameStreamMux: true,
+			StreamMuxConfig:  testStreamMuxConfig,
+			Payloads:         [][][][]byte{{{bytes.Repeat([]byte{0x01}, 300)}}},
+		},
+	},
+	{
+		"out of band config, fixed frame length",
+		append(bytes.Repeat([]byte{0x01}, 20), bytes.Repeat([]byte{0x02}, 20)...),
+		AudioMuxElement{
+			StreamMuxConfig: &StreamMuxConfig{
+				NumSubFrames: 1,
+				Programs: []*StreamMuxConfigProgram{{
+					Layers: []*StreamMuxConfigLayer{{
+						AudioSpecificConfig: &AudioSpecificConfig{
+							Type:         2,
+							SampleRate:   48000,
+							ChannelCount: 2,
+						},
+						FrameLengthType: 1,
+					}},
+				}},
+			},
+			Payloads: [][][][]byte{
+				{{bytes.Repeat([]byte{0x01}, 20)}},
+				{{bytes.Repeat([]byte{0x02}, 20)}},
+			},
+		},
+	},
+}
+
+func TestAudioMuxElementUnmarshal(t *testing.T) {
+	for _, ca := range audioMuxElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			dec := AudioMuxElement{
+				MuxConfigPresent: ca.dec.MuxConfigPresent,
+			}
+			if ca.dec.UseSameStreamMux || !ca.dec.MuxConfigPresent {
+				dec.StreamMuxConfig = ca.dec.StreamMuxConfig
+			}
+
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAudioMuxElementMarshal(t *testing.T) {
+	for _, ca := range audioMuxElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestAudioMuxElementUnmarshalMissingConfig(t *testing.T) {
+	dec := AudioMuxElement{
+		MuxConfigPresent: true,
+	}
+	err := dec.Unmarshal([]byte{0x80, 0xd5, 0x00})
+	require.EqualError(t, err, "StreamMuxConfig is not available")
+}
+
+func FuzzAudioMuxElementUnmarshal(f *testing.F) {
+	for _, ca := range audioMuxElementCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		e := AudioMuxElement{
+			MuxConfigPresent: true,
+			StreamMuxConfig:  testStreamMuxConfig,
+		}
+		err := e.Unmarshal(b)
+		if err == nil {
+			e.Marshal() //nolint:errcheck
+		}
+	})
+}
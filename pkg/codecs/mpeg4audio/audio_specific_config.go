@@ -23,6 +23,25 @@ type AudioSpecificConfig struct {
 	FrameLengthFlag    bool
 	DependsOnCoreCoder bool
 	CoreCoderDelay     uint16
+
+	// present when channel configuration is 0
+	ProgramConfigElement *ProgramConfigElement
+
+	// ER specific
+	AACSectionDataResilienceFlag     bool
+	AACScalefactorDataResilienceFlag bool
+	AACSpectralDataResilienceFlag    bool
+	EPConfig                         uint8
+
+	// ELD specific
+	LDSBRPresent      bool
+	LDSBRSamplingRate bool
+	LDSBRCRC          bool
+	LDSBRHeaders      []*SBRHeader
+	ELDExtensions     []*ELDExtension
+
+	// USAC specific
+	UsacConfig *UsacConfig
 }
 
 // Unmarshal decodes a Config.
@@ -33,14 +52,17 @@ func (c *AudioSpecificConfig) Unmarshal(buf []byte) error {
 
 // UnmarshalFromPos decodes a Config.
 func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
-	tmp, err := bits.ReadBits(buf, pos, 5)
+	start := *pos
+
+	var err error
+	c.Type, err = readObjectType(buf, pos)
 	if err != nil {
 		return err
 	}
-	c.Type = ObjectType(tmp)
 
-	switch c.Type {
-	case ObjectTypeAACLC, ObjectTypeSBR, ObjectTypePS:
+	switch {
+	case c.Type.isGA(), c.Type == ObjectTypeSBR, c.Type == ObjectTypePS,
+		c.Type == ObjectTypeERAACELD, c.Type == ObjectTypeUSAC:
 	default:
 		return fmt.Errorf("unsupported object type: %d", c.Type)
 	}
@@ -55,6 +77,7 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 		c.SampleRate = sampleRates[sampleRateIndex]
 
 	case sampleRateIndex == 0x0F:
+		var tmp uint64
 		tmp, err = bits.ReadBits(buf, pos, 24)
 		if err != nil {
 			return err
@@ -65,23 +88,26 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 		return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
 	}
 
-	channelConfig, err := bits.ReadBits(buf, pos, 4)
+	tmp, err := bits.ReadBits(buf, pos, 4)
 	if err != nil {
 		return err
 	}
+	channelConfig := int(tmp)
 
-	switch {
-	case channelConfig == 0:
-		return fmt.Errorf("not yet supported")
+	if c.Type != ObjectTypeUSAC {
+		switch {
+		case channelConfig == 0:
+			// channel count is provided by the program_config_element
 
-	case channelConfig >= 1 && channelConfig <= 6:
-		c.ChannelCount = int(channelConfig)
+		case channelConfig >= 1 && channelConfig <= 6:
+			c.ChannelCount = channelConfig
 
-	case channelConfig == 7:
-		c.ChannelCount = 8
+		case channelConfig == 7:
+			c.ChannelCount = 8
 
-	default:
-		return fmt.Errorf("invalid channel configuration (%d)", channelConfig)
+		default:
+			return fmt.Errorf("invalid channel configuration (%d)", channelConfig)
+		}
 	}
 
 	if c.Type == ObjectTypeSBR || c.Type == ObjectTypePS {
@@ -108,17 +134,60 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 			return fmt.Errorf("invalid extension sample rate index (%d)", extensionSamplingFrequencyIndex)
 		}
 
-		tmp, err = bits.ReadBits(buf, pos, 5)
+		c.Type, err = readObjectType(buf, pos)
 		if err != nil {
 			return err
 		}
-		c.Type = ObjectType(tmp)
 
-		if c.Type != ObjectTypeAACLC {
+		if !c.Type.isGA() {
 			return fmt.Errorf("unsupported object type: %d", c.Type)
 		}
 	}
 
+	switch {
+	case c.Type.isGA():
+		err = c.unmarshalGASpecificConfig(buf, pos, start, channelConfig)
+		if err != nil {
+			return err
+		}
+
+	case c.Type == ObjectTypeERAACELD:
+		if channelConfig == 0 {
+			return fmt.Errorf("ELD with channel configuration 0 is not supported")
+		}
+
+		err = c.unmarshalELDSpecificConfig(buf, pos, channelConfig)
+		if err != nil {
+			return err
+		}
+
+	default: // USAC
+		c.UsacConfig = &UsacConfig{}
+		err = c.UsacConfig.unmarshalFromPos(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		c.ChannelCount = c.UsacConfig.ChannelCount()
+	}
+
+	if c.Type.isER() {
+		tmp, err = bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		c.EPConfig = uint8(tmp)
+
+		if c.EPConfig >= 2 {
+			return fmt.Errorf("unsupported epConfig (%d)", c.EPConfig)
+		}
+	}
+
+	return nil
+}
+
+func (c *AudioSpecificConfig) unmarshalGASpecificConfig(buf []byte, pos *int, start int, channelConfig int) error {
+	var err error
 	c.FrameLengthFlag, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
@@ -130,6 +199,7 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 	}
 
 	if c.DependsOnCoreCoder {
+		var tmp uint64
 		tmp, err = bits.ReadBits(buf, pos, 14)
 		if err != nil {
 			return err
@@ -142,15 +212,50 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 		return err
 	}
 
+	if channelConfig == 0 {
+		c.ProgramConfigElement = &ProgramConfigElement{}
+		err = c.ProgramConfigElement.unmarshalFromPos(buf, pos, start)
+		if err != nil {
+			return err
+		}
+
+		c.ChannelCount = c.ProgramConfigElement.ChannelCount()
+	}
+
 	if extensionFlag {
-		return fmt.Errorf("unsupported")
+		if c.Type.isER() {
+			err = bits.HasSpace(buf, *pos, 3)
+			if err != nil {
+				return err
+			}
+
+			c.AACSectionDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+			c.AACScalefactorDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+			c.AACSpectralDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+		}
+
+		var extensionFlag3 bool
+		extensionFlag3, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if extensionFlag3 {
+			return fmt.Errorf("extensionFlag3 is not supported")
+		}
 	}
 
 	return nil
 }
 
 func (c AudioSpecificConfig) marshalSizeBits() int {
-	n := 5 + 4 + 2 + 1
+	n := 4
+
+	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
+		n += c.ExtensionType.marshalSizeBits()
+	} else {
+		n += c.Type.marshalSizeBits()
+	}
 
 	_, ok := reverseSampleRates[c.SampleRate]
 	if !ok {
@@ -166,11 +271,36 @@ func (c AudioSpecificConfig) marshalSizeBits() int {
 		} else {
 			n += 4
 		}
-		n += 5
+		n += c.Type.marshalSizeBits()
 	}
 
-	if c.DependsOnCoreCoder {
-		n += 14
+	switch {
+	case c.Type == ObjectTypeERAACELD:
+		n += c.marshalSizeBitsELDSpecificConfig()
+
+	case c.Type == ObjectTypeUSAC:
+		if c.UsacConfig != nil {
+			n += c.UsacConfig.marshalSizeBits()
+		}
+
+	default:
+		n += 3
+
+		if c.DependsOnCoreCoder {
+			n += 14
+		}
+
+		if c.ProgramConfigElement != nil {
+			n += c.ProgramConfigElement.marshalSizeBits(n)
+		}
+
+		if c.Type.isER() {
+			n += 4
+		}
+	}
+
+	if c.Type.isER() {
+		n += 2
 	}
 
 	return n
@@ -200,11 +330,63 @@ func (c AudioSpecificConfig) Marshal() ([]byte, error) {
 	return buf, nil
 }
 
+func (c AudioSpecificConfig) channelConfig() (int, error) {
+	if c.Type == ObjectTypeUSAC {
+		if c.UsacConfig == nil {
+			return 0, fmt.Errorf("UsacConfig is missing")
+		}
+
+		if c.UsacConfig.ChannelConfigurationIndex <= 7 {
+			return int(c.UsacConfig.ChannelConfigurationIndex), nil
+		}
+		return 0, nil
+	}
+
+	if c.ProgramConfigElement != nil {
+		if !c.Type.isGA() {
+			return 0, fmt.Errorf("program_config_element is not supported by object type %d", c.Type)
+		}
+		return 0, nil
+	}
+
+	switch {
+	case c.ChannelCount >= 1 && c.ChannelCount <= 6:
+		return c.ChannelCount, nil
+
+	case c.ChannelCount == 8:
+		return 7, nil
+
+	default:
+		return 0, fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
+	}
+}
+
 func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int) error {
+	start := *pos
+
+	switch {
+	case c.Type.isGA(), c.Type == ObjectTypeERAACELD, c.Type == ObjectTypeUSAC:
+	default:
+		return fmt.Errorf("unsupported object type: %d", c.Type)
+	}
+
+	if (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) && !c.Type.isGA() {
+		return fmt.Errorf("unsupported object type: %d", c.Type)
+	}
+
+	if c.EPConfig >= 2 {
+		return fmt.Errorf("unsupported epConfig (%d)", c.EPConfig)
+	}
+
+	channelConfig, err := c.channelConfig()
+	if err != nil {
+		return err
+	}
+
 	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.ExtensionType), 5)
+		c.ExtensionType.marshalTo(buf, pos)
 	} else {
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.Type), 5)
+		c.Type.marshalTo(buf, pos)
 	}
 
 	sampleRateIndex, ok := reverseSampleRates[c.SampleRate]
@@ -215,17 +397,6 @@ func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int) error {
 		bits.WriteBitsUnsafe(buf, pos, uint64(sampleRateIndex), 4)
 	}
 
-	var channelConfig int
-	switch {
-	case c.ChannelCount >= 1 && c.ChannelCount <= 6:
-		channelConfig = c.ChannelCount
-
-	case c.ChannelCount == 8:
-		channelConfig = 7
-
-	default:
-		return fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
-	}
 	bits.WriteBitsUnsafe(buf, pos, uint64(channelConfig), 4)
 
 	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
@@ -236,26 +407,59 @@ func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int) error {
 		} else {
 			bits.WriteBitsUnsafe(buf, pos, uint64(sampleRateIndex), 4)
 		}
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.Type), 5)
+		c.Type.marshalTo(buf, pos)
 	}
 
-	if c.FrameLengthFlag {
-		bits.WriteBitsUnsafe(buf, pos, 1, 1)
-	} else {
-		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+	switch {
+	case c.Type == ObjectTypeERAACELD:
+		err = c.marshalELDSpecificConfigTo(buf, pos, channelConfig)
+		if err != nil {
+			return err
+		}
+
+	case c.Type == ObjectTypeUSAC:
+		err = c.UsacConfig.marshalTo(buf, pos)
+		if err != nil {
+			return err
+		}
+
+	default:
+		err = c.marshalGASpecificConfigTo(buf, pos, start)
+		if err != nil {
+			return err
+		}
 	}
 
-	if c.DependsOnCoreCoder {
-		bits.WriteBitsUnsafe(buf, pos, 1, 1)
-	} else {
-		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+	if c.Type.isER() {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.EPConfig), 2)
 	}
 
+	return nil
+}
+
+func (c AudioSpecificConfig) marshalGASpecificConfigTo(buf []byte, pos *int, start int) error {
+	writeFlag(buf, pos, c.FrameLengthFlag)
+	writeFlag(buf, pos, c.DependsOnCoreCoder)
+
 	if c.DependsOnCoreCoder {
 		bits.WriteBitsUnsafe(buf, pos, uint64(c.CoreCoderDelay), 14)
 	}
 
-	*pos++ // extensionFlag
+	writeFlag(buf, pos, c.Type.isER()) // extensionFlag
+
+	if c.ProgramConfigElement != nil {
+		err := c.ProgramConfigElement.marshalTo(buf, pos, start)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Type.isER() {
+		writeFlag(buf, pos, c.AACSectionDataResilienceFlag)
+		writeFlag(buf, pos, c.AACScalefactorDataResilienceFlag)
+		writeFlag(buf, pos, c.AACSpectralDataResilienceFlag)
+		bits.WriteBitsUnsafe(buf, pos, 0, 1) // extensionFlag3
+	}
 
 	return nil
 }
@@ -76,6 +76,27 @@ var audioSpecificConfigCases = []struct {
 			ChannelCount: 8,
 		},
 	},
+	{
+		"aac-lc 48khz program config element",
+		[]byte{0x11, 0x80, 0x04, 0xc8, 0x05, 0x00, 0x01, 0x08, 0x80, 0x00},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACLC,
+			SampleRate:   48000,
+			ChannelCount: 6,
+			ProgramConfigElement: &ProgramConfigElement{
+				ObjectType: 1,
+				SampleRate: 48000,
+				FrontChannelElements: []*ProgramConfigElementChannel{
+					{},
+					{IsCPE: true},
+				},
+				BackChannelElements: []*ProgramConfigElementChannel{
+					{IsCPE: true, TagSelect: 1},
+				},
+				LFEChannelElements: []uint8{0},
+			},
+		},
+	},
 	{
 		"sbr (he-aac v1) 44.1khz mono",
 		[]byte{0x2b, 0x8a, 0x08, 0x00},
@@ -109,6 +130,151 @@ var audioSpecificConfigCases = []struct {
 			ExtensionType:       ObjectTypePS,
 		},
 	},
+	{
+		"aac main 48khz stereo",
+		[]byte{0x09, 0x90},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACMain,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+	},
+	{
+		"aac ltp 44.1khz mono",
+		[]byte{0x22, 0x08},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACLTP,
+			SampleRate:   44100,
+			ChannelCount: 1,
+		},
+	},
+	{
+		"er aac-lc 48khz stereo",
+		[]byte{0x89, 0x91, 0xe0},
+		AudioSpecificConfig{
+			Type:                             ObjectTypeERAACLC,
+			SampleRate:                       48000,
+			ChannelCount:                     2,
+			AACSectionDataResilienceFlag:     true,
+			AACScalefactorDataResilienceFlag: true,
+			AACSpectralDataResilienceFlag:    true,
+		},
+	},
+	{
+		"er aac-ld 48khz stereo",
+		[]byte{0xb9, 0x95, 0x00},
+		AudioSpecificConfig{
+			Type:            ObjectTypeERAACLD,
+			SampleRate:      48000,
+			ChannelCount:    2,
+			FrameLengthFlag: true,
+		},
+	},
+	{
+		"er aac-eld 16khz mono ld-sbr",
+		[]byte{0xf8, 0xf0, 0x21, 0x2c, 0x00, 0xbc, 0x00},
+		AudioSpecificConfig{
+			Type:         ObjectTypeERAACELD,
+			SampleRate:   16000,
+			ChannelCount: 1,
+			LDSBRPresent: true,
+			LDSBRHeaders: []*SBRHeader{{
+				AmpRes:       true,
+				StartFreq:    6,
+				HeaderExtra1: true,
+				FreqScale:    3,
+				AlterScale:   true,
+				NoiseBands:   2,
+			}},
+		},
+	},
+	{
+		"er aac-eld 48khz stereo extension",
+		[]byte{0xf8, 0xe6, 0x40, 0x12, 0xab, 0xcd, 0x00},
+		AudioSpecificConfig{
+			Type:         ObjectTypeERAACELD,
+			SampleRate:   48000,
+			ChannelCount: 2,
+			ELDExtensions: []*ELDExtension{{
+				Type: 1,
+				Data: []byte{0xab, 0xcd},
+			}},
+		},
+	},
+	{
+		"usac 48khz stereo",
+		[]byte{0xf9, 0x46, 0x43, 0x22, 0x15, 0xc0, 0x00},
+		AudioSpecificConfig{
+			Type:         ObjectTypeUSAC,
+			SampleRate:   48000,
+			ChannelCount: 2,
+			UsacConfig: &UsacConfig{
+				SampleRate:                48000,
+				CoreSbrFrameLengthIndex:   1,
+				ChannelConfigurationIndex: 2,
+				Elements: []*UsacElementConfig{
+					{
+						Type:         UsacElementTypeCPE,
+						NoiseFilling: true,
+					},
+					{
+						Type: UsacElementTypeEXT,
+					},
+				},
+			},
+		},
+	},
+	{
+		"usac 44.1khz stereo sbr mps212",
+		[]byte{
+			0xf9, 0x48, 0x44, 0x62, 0x15, 0x0a, 0xf5, 0x94,
+			0xe0, 0x34, 0x28, 0x10, 0x04, 0x0a, 0x11, 0x80,
+			0x81, 0x01, 0x80,
+		},
+		AudioSpecificConfig{
+			Type:         ObjectTypeUSAC,
+			SampleRate:   44100,
+			ChannelCount: 2,
+			UsacConfig: &UsacConfig{
+				SampleRate:                44100,
+				CoreSbrFrameLengthIndex:   3,
+				ChannelConfigurationIndex: 2,
+				Elements: []*UsacElementConfig{
+					{
+						Type:         UsacElementTypeCPE,
+						NoiseFilling: true,
+						SBRConfig: &UsacSBRConfig{
+							DefaultHeader: SBRHeader{
+								StartFreq:    5,
+								StopFreq:     7,
+								HeaderExtra1: true,
+								FreqScale:    2,
+								AlterScale:   true,
+								NoiseBands:   2,
+							},
+						},
+						StereoConfigIndex: 1,
+						Mps212Config: &UsacMps212Config{
+							FreqRes:         2,
+							FixedGainDMX:    3,
+							TempShapeConfig: 2,
+						},
+					},
+					{
+						Type:                    UsacElementTypeEXT,
+						ExtType:                 4,
+						ExtDefaultLengthPresent: true,
+						ExtDefaultLength:        3,
+						ExtConfig:               []byte{1, 2},
+					},
+				},
+				ConfigExtensions: []*UsacConfigExtension{{
+					Type: 2,
+					Data: []byte{1, 2, 3},
+				}},
+			},
+		},
+	},
 }
 
 func TestAudioSpecificConfigUnmarshal(t *testing.T) {
@@ -139,6 +305,29 @@ func TestAudioSpecificConfigMarshalErrors(t *testing.T) {
 		ChannelCount: 0,
 	}.Marshal()
 	require.Error(t, err)
+
+	_, err = AudioSpecificConfig{
+		Type:         ObjectTypeERAACELD,
+		SampleRate:   44100,
+		ChannelCount: 2,
+		LDSBRPresent: true,
+	}.Marshal()
+	require.Error(t, err)
+
+	_, err = AudioSpecificConfig{
+		Type:         ObjectTypeUSAC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+		UsacConfig: &UsacConfig{
+			SampleRate:                44100,
+			CoreSbrFrameLengthIndex:   3,
+			ChannelConfigurationIndex: 2,
+			Elements: []*UsacElementConfig{{
+				Type: UsacElementTypeCPE,
+			}},
+		},
+	}.Marshal()
+	require.Error(t, err)
 }
 
 func FuzzAudioSpecificConfigUnmarshal(f *testing.F) {
@@ -0,0 +1,77 @@
+package mpeg4audio
+
+import (
+	"fmt"
+)
+
+const (
+	audioSyncStreamSyncWord = 0x2B7
+
+	// maximum size of an AudioMuxElement inside an AudioSyncStream.
+	audioSyncStreamMaxElementSize = 0x1FFF
+)
+
+// AudioSyncStream is an AudioSyncStream (LOAS),
+// that is a sequence of AudioMuxElements with MuxConfigPresent set to true.
+// Specification: ISO 14496-3, Table 1.36
+type AudioSyncStream [][]byte
+
+// Unmarshal decodes an AudioSyncStream.
+func (s *AudioSyncStream) Unmarshal(buf []byte) error {
+	*s = (*s)[:0]
+
+	for {
+		if len(buf) < 3 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		syncWord := uint16(buf[0])<<3 | uint16(buf[1])>>5
+		if syncWord != audioSyncStreamSyncWord {
+			return fmt.Errorf("invalid sync word")
+		}
+
+		audioMuxLengthBytes := int(buf[1]&0x1F)<<8 | int(buf[2])
+		buf = buf[3:]
+
+		if len(buf) < audioMuxLengthBytes {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		*s = append(*s, buf[:audioMuxLengthBytes])
+		buf = buf[audioMuxLengthBytes:]
+
+		if len(buf) == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s AudioSyncStream) marshalSize() int {
+	n := 0
+	for _, el := range s {
+		n += 3 + len(el)
+	}
+	return n
+}
+
+// Marshal encodes an AudioSyncStream.
+func (s AudioSyncStream) Marshal() ([]byte, error) {
+	buf := make([]byte, s.marshalSize())
+	n := 0
+
+	for _, el := range s {
+		if len(el) > audioSyncStreamMaxElementSize {
+			return nil, fmt.Errorf("AudioMuxElement is too big")
+		}
+
+		buf[n] = byte(audioSyncStreamSyncWord >> 3)
+		buf[n+1] = byte((audioSyncStreamSyncWord&0x07)<<5) | byte(len(el)>>8)
+		buf[n+2] = byte(len(el))
+		n += 3
+		n += copy(buf[n:], el)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,62 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var audioSyncStreamCases = []struct {
+	name string
+	enc  []byte
+	dec  AudioSyncStream
+}{
+	{
+		"single",
+		[]byte{0x56, 0xe0, 0x03, 0x80, 0xd5, 0x00},
+		AudioSyncStream{{0x80, 0xd5, 0x00}},
+	},
+	{
+		"multiple",
+		[]byte{
+			0x56, 0xe0, 0x03, 0x80, 0xd5, 0x00, 0x56, 0xe0,
+			0x02, 0x01, 0x02,
+		},
+		AudioSyncStream{{0x80, 0xd5, 0x00}, {0x01, 0x02}},
+	},
+}
+
+func TestAudioSyncStreamUnmarshal(t *testing.T) {
+	for _, ca := range audioSyncStreamCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec AudioSyncStream
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAudioSyncStreamMarshal(t *testing.T) {
+	for _, ca := range audioSyncStreamCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzAudioSyncStreamUnmarshal(f *testing.F) {
+	for _, ca := range audioSyncStreamCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var s AudioSyncStream
+		err := s.Unmarshal(b)
+		if err == nil {
+			s.Marshal() //nolint:errcheck
+		}
+	})
+}
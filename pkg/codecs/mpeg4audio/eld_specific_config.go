@@ -0,0 +1,215 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+const (
+	eldExtTerm = 0
+)
+
+// ELDExtension is an extension of an ELDSpecificConfig.
+// Specification: ISO 14496-3, ELDSpecificConfig()
+type ELDExtension struct {
+	Type uint8
+	Data []byte
+}
+
+func (e *ELDExtension) unmarshalFromPos(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+	le := int(tmp)
+
+	if le == 15 {
+		tmp, err = bits.ReadBits(buf, pos, 8)
+		if err != nil {
+			return err
+		}
+		le += int(tmp)
+
+		if tmp == 255 {
+			tmp, err = bits.ReadBits(buf, pos, 16)
+			if err != nil {
+				return err
+			}
+			le += int(tmp)
+		}
+	}
+
+	e.Data, err = readBytesFromPos(buf, pos, le)
+	return err
+}
+
+func (e ELDExtension) marshalSizeBits() int {
+	n := 4 + 4
+	le := len(e.Data)
+
+	if le >= 15 {
+		n += 8
+		if (le - 15) >= 255 {
+			n += 16
+		}
+	}
+
+	return n + le*8
+}
+
+func (e ELDExtension) marshalTo(buf []byte, pos *int) error {
+	if e.Type == eldExtTerm || e.Type > 15 {
+		return fmt.Errorf("invalid ELD extension type (%d)", e.Type)
+	}
+
+	le := len(e.Data)
+	if le > (15 + 255 + 65535) {
+		return fmt.Errorf("ELD extension is too big")
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(e.Type), 4)
+
+	if le < 15 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(le), 4)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, 15, 4)
+		le -= 15
+
+		if le < 255 {
+			bits.WriteBitsUnsafe(buf, pos, uint64(le), 8)
+		} else {
+			bits.WriteBitsUnsafe(buf, pos, 255, 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(le-255), 16)
+		}
+	}
+
+	writeBytesToPos(buf, pos, e.Data)
+
+	return nil
+}
+
+// number of sbr_header() in a ld_sbr_header().
+// Specification: ISO 14496-3, ld_sbr_header()
+func ldSBRHeaderCount(channelConfig int) int {
+	switch channelConfig {
+	case 1, 2:
+		return 1
+
+	case 3:
+		return 2
+
+	case 4, 5, 6:
+		return 3
+
+	case 7:
+		return 4
+
+	default:
+		return 0
+	}
+}
+
+func (c *AudioSpecificConfig) unmarshalELDSpecificConfig(buf []byte, pos *int, channelConfig int) error {
+	err := bits.HasSpace(buf, *pos, 5)
+	if err != nil {
+		return err
+	}
+
+	c.FrameLengthFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACSectionDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACScalefactorDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACSpectralDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.LDSBRPresent = bits.ReadFlagUnsafe(buf, pos)
+
+	if c.LDSBRPresent {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		c.LDSBRSamplingRate = bits.ReadFlagUnsafe(buf, pos)
+		c.LDSBRCRC = bits.ReadFlagUnsafe(buf, pos)
+
+		c.LDSBRHeaders = make([]*SBRHeader, ldSBRHeaderCount(channelConfig))
+
+		for i := range c.LDSBRHeaders {
+			c.LDSBRHeaders[i] = &SBRHeader{}
+			err = c.LDSBRHeaders[i].unmarshalFromPos(buf, pos, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		var extType uint64
+		extType, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		if extType == eldExtTerm {
+			break
+		}
+
+		ext := &ELDExtension{Type: uint8(extType)}
+		err = ext.unmarshalFromPos(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		c.ELDExtensions = append(c.ELDExtensions, ext)
+	}
+
+	return nil
+}
+
+func (c AudioSpecificConfig) marshalSizeBitsELDSpecificConfig() int {
+	n := 5
+
+	if c.LDSBRPresent {
+		n += 2
+		for _, h := range c.LDSBRHeaders {
+			n += h.marshalSizeBits(false)
+		}
+	}
+
+	for _, ext := range c.ELDExtensions {
+		n += ext.marshalSizeBits()
+	}
+
+	return n + 4
+}
+
+func (c AudioSpecificConfig) marshalELDSpecificConfigTo(buf []byte, pos *int, channelConfig int) error {
+	writeFlag(buf, pos, c.FrameLengthFlag)
+	writeFlag(buf, pos, c.AACSectionDataResilienceFlag)
+	writeFlag(buf, pos, c.AACScalefactorDataResilienceFlag)
+	writeFlag(buf, pos, c.AACSpectralDataResilienceFlag)
+	writeFlag(buf, pos, c.LDSBRPresent)
+
+	if c.LDSBRPresent {
+		if len(c.LDSBRHeaders) != ldSBRHeaderCount(channelConfig) {
+			return fmt.Errorf("invalid LD-SBR header count (%d)", len(c.LDSBRHeaders))
+		}
+
+		writeFlag(buf, pos, c.LDSBRSamplingRate)
+		writeFlag(buf, pos, c.LDSBRCRC)
+
+		for _, h := range c.LDSBRHeaders {
+			h.marshalTo(buf, pos, false)
+		}
+	}
+
+	for _, ext := range c.ELDExtensions {
+		err := ext.marshalTo(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, eldExtTerm, 4)
+
+	return nil
+}
@@ -1,12 +1,75 @@
 package mpeg4audio
 
+import (
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
 // ObjectType is a MPEG-4 Audio object type.
 // Specification: ISO 14496-3, Table 1.17
 type ObjectType int
 
 // supported types.
 const (
-	ObjectTypeAACLC ObjectType = 2
-	ObjectTypeSBR   ObjectType = 5
-	ObjectTypePS    ObjectType = 29
+	ObjectTypeAACMain  ObjectType = 1
+	ObjectTypeAACLC    ObjectType = 2
+	ObjectTypeAACLTP   ObjectType = 4
+	ObjectTypeSBR      ObjectType = 5
+	ObjectTypeERAACLC  ObjectType = 17
+	ObjectTypeERAACLTP ObjectType = 19
+	ObjectTypeERAACLD  ObjectType = 23
+	ObjectTypePS       ObjectType = 29
+	ObjectTypeERAACELD ObjectType = 39
+	ObjectTypeUSAC     ObjectType = 42
 )
+
+// isGA returns whether the object type uses a GASpecificConfig.
+func (t ObjectType) isGA() bool {
+	switch t {
+	case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACLTP,
+		ObjectTypeERAACLC, ObjectTypeERAACLTP, ObjectTypeERAACLD:
+		return true
+	}
+	return false
+}
+
+// isER returns whether the object type is an Error Resilient one.
+func (t ObjectType) isER() bool {
+	switch t {
+	case ObjectTypeERAACLC, ObjectTypeERAACLTP, ObjectTypeERAACLD, ObjectTypeERAACELD:
+		return true
+	}
+	return false
+}
+
+func readObjectType(buf []byte, pos *int) (ObjectType, error) {
+	tmp, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return 0, err
+	}
+
+	if tmp == 31 {
+		tmp, err = bits.ReadBits(buf, pos, 6)
+		if err != nil {
+			return 0, err
+		}
+		tmp += 32
+	}
+
+	return ObjectType(tmp), nil
+}
+
+func (t ObjectType) marshalSizeBits() int {
+	if t >= 31 {
+		return 11
+	}
+	return 5
+}
+
+func (t ObjectType) marshalTo(buf []byte, pos *int) {
+	if t >= 31 {
+		bits.WriteBitsUnsafe(buf, pos, 31, 5)
+		bits.WriteBitsUnsafe(buf, pos, uint64(t-32), 6)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, uint64(t), 5)
+	}
+}
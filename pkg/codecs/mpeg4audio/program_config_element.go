@@ -0,0 +1,383 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// ChannelPosition is the position of a speaker.
+type ChannelPosition int
+
+// channel positions.
+const (
+	ChannelPositionUnknown ChannelPosition = iota
+	ChannelPositionFrontCenter
+	ChannelPositionFrontLeft
+	ChannelPositionFrontRight
+	ChannelPositionFrontLeftOfCenter
+	ChannelPositionFrontRightOfCenter
+	ChannelPositionSideLeft
+	ChannelPositionSideRight
+	ChannelPositionBackLeft
+	ChannelPositionBackRight
+	ChannelPositionBackCenter
+	ChannelPositionLFE
+)
+
+// ProgramConfigElementChannel is a front, side or back channel element of a program_config_element.
+type ProgramConfigElementChannel struct {
+	IsCPE     bool
+	TagSelect uint8
+}
+
+// ProgramConfigElementCC is a coupling channel element of a program_config_element.
+type ProgramConfigElementCC struct {
+	IsIndSw   bool
+	TagSelect uint8
+}
+
+// ProgramConfigElement is a program_config_element.
+// Specification: ISO 14496-3, Table 4.2
+type ProgramConfigElement struct {
+	ElementInstanceTag uint8
+	ObjectType         uint8 // 2 bits
+	SampleRate         int
+
+	FrontChannelElements []*ProgramConfigElementChannel
+	SideChannelElements  []*ProgramConfigElementChannel
+	BackChannelElements  []*ProgramConfigElementChannel
+	LFEChannelElements   []uint8
+	AssocDataElements    []uint8
+	CCElements           []*ProgramConfigElementCC
+
+	MonoMixdownPresent         bool
+	MonoMixdownElementNumber   uint8
+	StereoMixdownPresent       bool
+	StereoMixdownElementNumber uint8
+	MatrixMixdownIdxPresent    bool
+	MatrixMixdownIdx           uint8
+	PseudoSurroundEnable       bool
+
+	Comment []byte
+}
+
+func readChannelElements(buf []byte, pos *int, n uint64) []*ProgramConfigElementChannel {
+	if n == 0 {
+		return nil
+	}
+
+	ret := make([]*ProgramConfigElementChannel, n)
+	for i := range ret {
+		ret[i] = &ProgramConfigElementChannel{
+			IsCPE:     bits.ReadFlagUnsafe(buf, pos),
+			TagSelect: uint8(bits.ReadBitsUnsafe(buf, pos, 4)),
+		}
+	}
+	return ret
+}
+
+func readTagSelects(buf []byte, pos *int, n uint64) []uint8 {
+	if n == 0 {
+		return nil
+	}
+
+	ret := make([]uint8, n)
+	for i := range ret {
+		ret[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	}
+	return ret
+}
+
+// Unmarshal decodes a ProgramConfigElement.
+func (p *ProgramConfigElement) Unmarshal(buf []byte) error {
+	pos := 0
+	return p.unmarshalFromPos(buf, &pos, 0)
+}
+
+// unmarshalFromPos decodes a ProgramConfigElement.
+// byte_alignment() is performed relative to alignRef.
+func (p *ProgramConfigElement) unmarshalFromPos(buf []byte, pos *int, alignRef int) error {
+	err := bits.HasSpace(buf, *pos, 4+2+4+4+4+4+2+3+4+3)
+	if err != nil {
+		return err
+	}
+
+	p.ElementInstanceTag = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	p.ObjectType = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+
+	sampleRateIndex := bits.ReadBitsUnsafe(buf, pos, 4)
+	if sampleRateIndex > 12 {
+		return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
+	}
+	p.SampleRate = sampleRates[sampleRateIndex]
+
+	numFront := bits.ReadBitsUnsafe(buf, pos, 4)
+	numSide := bits.ReadBitsUnsafe(buf, pos, 4)
+	numBack := bits.ReadBitsUnsafe(buf, pos, 4)
+	numLFE := bits.ReadBitsUnsafe(buf, pos, 2)
+	numAssocData := bits.ReadBitsUnsafe(buf, pos, 3)
+	numValidCC := bits.ReadBitsUnsafe(buf, pos, 4)
+
+	p.MonoMixdownPresent = bits.ReadFlagUnsafe(buf, pos)
+	if p.MonoMixdownPresent {
+		err = bits.HasSpace(buf, *pos, 4)
+		if err != nil {
+			return err
+		}
+		p.MonoMixdownElementNumber = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	}
+
+	p.StereoMixdownPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+	if p.StereoMixdownPresent {
+		err = bits.HasSpace(buf, *pos, 4)
+		if err != nil {
+			return err
+		}
+		p.StereoMixdownElementNumber = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	}
+
+	p.MatrixMixdownIdxPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+	if p.MatrixMixdownIdxPresent {
+		err = bits.HasSpace(buf, *pos, 3)
+		if err != nil {
+			return err
+		}
+		p.MatrixMixdownIdx = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		p.PseudoSurroundEnable = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	err = bits.HasSpace(buf, *pos, int((numFront+numSide+numBack+numValidCC)*5+(numLFE+numAssocData)*4))
+	if err != nil {
+		return err
+	}
+
+	p.FrontChannelElements = readChannelElements(buf, pos, numFront)
+	p.SideChannelElements = readChannelElements(buf, pos, numSide)
+	p.BackChannelElements = readChannelElements(buf, pos, numBack)
+	p.LFEChannelElements = readTagSelects(buf, pos, numLFE)
+	p.AssocDataElements = readTagSelects(buf, pos, numAssocData)
+
+	if numValidCC != 0 {
+		p.CCElements = make([]*ProgramConfigElementCC, numValidCC)
+		for i := range p.CCElements {
+			p.CCElements[i] = &ProgramConfigElementCC{
+				IsIndSw:   bits.ReadFlagUnsafe(buf, pos),
+				TagSelect: uint8(bits.ReadBitsUnsafe(buf, pos, 4)),
+			}
+		}
+	}
+
+	*pos += (8 - (*pos-alignRef)%8) % 8
+
+	commentLen, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	if commentLen != 0 {
+		p.Comment, err = readBytesFromPos(buf, pos, int(commentLen))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChannelCount returns the channel count.
+func (p ProgramConfigElement) ChannelCount() int {
+	n := len(p.LFEChannelElements)
+
+	for _, els := range [][]*ProgramConfigElementChannel{
+		p.FrontChannelElements,
+		p.SideChannelElements,
+		p.BackChannelElements,
+	} {
+		for _, el := range els {
+			if el.IsCPE {
+				n += 2
+			} else {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// ChannelPositions returns the speaker layout, in the order in which channels are decoded.
+func (p ProgramConfigElement) ChannelPositions() []ChannelPosition {
+	ret := make([]ChannelPosition, 0, p.ChannelCount())
+
+	lastFrontCPE := -1
+	for i, el := range p.FrontChannelElements {
+		if el.IsCPE {
+			lastFrontCPE = i
+		}
+	}
+
+	// front CPEs are ordered from the center to the outside.
+	for i, el := range p.FrontChannelElements {
+		switch {
+		case !el.IsCPE:
+			ret = append(ret, ChannelPositionFrontCenter)
+
+		case i == lastFrontCPE:
+			ret = append(ret, ChannelPositionFrontLeft, ChannelPositionFrontRight)
+
+		default:
+			ret = append(ret, ChannelPositionFrontLeftOfCenter, ChannelPositionFrontRightOfCenter)
+		}
+	}
+
+	for _, el := range p.SideChannelElements {
+		if el.IsCPE {
+			ret = append(ret, ChannelPositionSideLeft, ChannelPositionSideRight)
+		} else {
+			ret = append(ret, ChannelPositionUnknown)
+		}
+	}
+
+	for _, el := range p.BackChannelElements {
+		if el.IsCPE {
+			ret = append(ret, ChannelPositionBackLeft, ChannelPositionBackRight)
+		} else {
+			ret = append(ret, ChannelPositionBackCenter)
+		}
+	}
+
+	for range p.LFEChannelElements {
+		ret = append(ret, ChannelPositionLFE)
+	}
+
+	return ret
+}
+
+// marshalSizeBits returns the size of the element when it starts at
+// the given bit offset from the byte_alignment() reference.
+func (p ProgramConfigElement) marshalSizeBits(offset int) int {
+	n := 4 + 2 + 4 + 4 + 4 + 4 + 2 + 3 + 4 + 3
+
+	if p.MonoMixdownPresent {
+		n += 4
+	}
+	if p.StereoMixdownPresent {
+		n += 4
+	}
+	if p.MatrixMixdownIdxPresent {
+		n += 3
+	}
+
+	n += (len(p.FrontChannelElements) + len(p.SideChannelElements) + len(p.BackChannelElements) +
+		len(p.CCElements)) * 5
+	n += (len(p.LFEChannelElements) + len(p.AssocDataElements)) * 4
+
+	n += (8 - (offset+n)%8) % 8
+
+	return n + 8 + len(p.Comment)*8
+}
+
+func (p ProgramConfigElement) marshalSize() int {
+	n := p.marshalSizeBits(0)
+
+	ret := n / 8
+	if (n % 8) != 0 {
+		ret++
+	}
+
+	return ret
+}
+
+// Marshal encodes a ProgramConfigElement.
+func (p ProgramConfigElement) Marshal() ([]byte, error) {
+	buf := make([]byte, p.marshalSize())
+	pos := 0
+
+	err := p.marshalTo(buf, &pos, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (p ProgramConfigElement) marshalTo(buf []byte, pos *int, alignRef int) error {
+	sampleRateIndex, ok := reverseSampleRates[p.SampleRate]
+	if !ok {
+		return fmt.Errorf("invalid sample rate: %d", p.SampleRate)
+	}
+
+	if len(p.FrontChannelElements) > 15 || len(p.SideChannelElements) > 15 ||
+		len(p.BackChannelElements) > 15 || len(p.LFEChannelElements) > 3 ||
+		len(p.AssocDataElements) > 7 || len(p.CCElements) > 15 {
+		return fmt.Errorf("too many elements")
+	}
+
+	if len(p.Comment) > 255 {
+		return fmt.Errorf("comment is too long")
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.ElementInstanceTag), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.ObjectType), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(sampleRateIndex), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.FrontChannelElements)), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.SideChannelElements)), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.BackChannelElements)), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.LFEChannelElements)), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.AssocDataElements)), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.CCElements)), 4)
+
+	writeFlag(buf, pos, p.MonoMixdownPresent)
+	if p.MonoMixdownPresent {
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.MonoMixdownElementNumber), 4)
+	}
+
+	writeFlag(buf, pos, p.StereoMixdownPresent)
+	if p.StereoMixdownPresent {
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.StereoMixdownElementNumber), 4)
+	}
+
+	writeFlag(buf, pos, p.MatrixMixdownIdxPresent)
+	if p.MatrixMixdownIdxPresent {
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.MatrixMixdownIdx), 2)
+		writeFlag(buf, pos, p.PseudoSurroundEnable)
+	}
+
+	for _, els := range [][]*ProgramConfigElementChannel{
+		p.FrontChannelElements,
+		p.SideChannelElements,
+		p.BackChannelElements,
+	} {
+		for _, el := range els {
+			writeFlag(buf, pos, el.IsCPE)
+			bits.WriteBitsUnsafe(buf, pos, uint64(el.TagSelect), 4)
+		}
+	}
+
+	for _, tag := range p.LFEChannelElements {
+		bits.WriteBitsUnsafe(buf, pos, uint64(tag), 4)
+	}
+
+	for _, tag := range p.AssocDataElements {
+		bits.WriteBitsUnsafe(buf, pos, uint64(tag), 4)
+	}
+
+	for _, el := range p.CCElements {
+		writeFlag(buf, pos, el.IsIndSw)
+		bits.WriteBitsUnsafe(buf, pos, uint64(el.TagSelect), 4)
+	}
+
+	*pos += (8 - (*pos-alignRef)%8) % 8
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.Comment)), 8)
+	writeBytesToPos(buf, pos, p.Comment)
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var programConfigElementCases = []struct {
+	name      string
+	enc       []byte
+	dec       ProgramConfigElement
+	positions []ChannelPosition
+}{
+	{
+		"5.1",
+		[]byte{0x04, 0xc8, 0x05, 0x00, 0x01, 0x08, 0x80, 0x00},
+		ProgramConfigElement{
+			ObjectType: 1,
+			SampleRate: 48000,
+			FrontChannelElements: []*ProgramConfigElementChannel{
+				{},
+				{IsCPE: true},
+			},
+			BackChannelElements: []*ProgramConfigElementChannel{
+				{IsCPE: true, TagSelect: 1},
+			},
+			LFEChannelElements: []uint8{0},
+		},
+		[]ChannelPosition{
+			ChannelPositionFrontCenter,
+			ChannelPositionFrontLeft,
+			ChannelPositionFrontRight,
+			ChannelPositionBackLeft,
+			ChannelPositionBackRight,
+			ChannelPositionLFE,
+		},
+	},
+	{
+		"7.1 with matrix mixdown and comment",
+		[]byte{
+			0x25, 0x0c, 0x45, 0x02, 0x68, 0x21, 0x19, 0x04,
+			0x26, 0x04, 0x74, 0x65, 0x73, 0x74,
+		},
+		ProgramConfigElement{
+			ElementInstanceTag: 2,
+			ObjectType:         1,
+			SampleRate:         44100,
+			FrontChannelElements: []*ProgramConfigElementChannel{
+				{},
+				{IsCPE: true},
+				{IsCPE: true, TagSelect: 1},
+			},
+			SideChannelElements: []*ProgramConfigElementChannel{
+				{IsCPE: true, TagSelect: 2},
+			},
+			BackChannelElements: []*ProgramConfigElementChannel{
+				{TagSelect: 1},
+			},
+			LFEChannelElements: []uint8{0},
+			CCElements: []*ProgramConfigElementCC{
+				{IsIndSw: true, TagSelect: 3},
+			},
+			MatrixMixdownIdxPresent: true,
+			MatrixMixdownIdx:        2,
+			PseudoSurroundEnable:    true,
+			Comment:                 []byte("test"),
+		},
+		[]ChannelPosition{
+			ChannelPositionFrontCenter,
+			ChannelPositionFrontLeftOfCenter,
+			ChannelPositionFrontRightOfCenter,
+			ChannelPositionFrontLeft,
+			ChannelPositionFrontRight,
+			ChannelPositionSideLeft,
+			ChannelPositionSideRight,
+			ChannelPositionBackCenter,
+			ChannelPositionLFE,
+		},
+	},
+}
+
+func TestProgramConfigElementUnmarshal(t *testing.T) {
+	for _, ca := range programConfigElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec ProgramConfigElement
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, len(ca.positions), dec.ChannelCount())
+			require.Equal(t, ca.positions, dec.ChannelPositions())
+		})
+	}
+}
+
+func TestProgramConfigElementMarshal(t *testing.T) {
+	for _, ca := range programConfigElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzProgramConfigElementUnmarshal(f *testing.F) {
+	for _, ca := range programConfigElementCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pce ProgramConfigElement
+		err := pce.Unmarshal(b)
+		if err == nil {
+			pce.Marshal() //nolint:errcheck
+		}
+	})
+}
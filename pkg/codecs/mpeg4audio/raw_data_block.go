@@ -0,0 +1,817 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// syntactic elements of a raw_data_block.
+// Specification: ISO 14496-3, Table 4.85
+const (
+	rawDataBlockIDSCE = 0
+	rawDataBlockIDCPE = 1
+	rawDataBlockIDCCE = 2
+	rawDataBlockIDLFE = 3
+	rawDataBlockIDDSE = 4
+	rawDataBlockIDPCE = 5
+	rawDataBlockIDFIL = 6
+	rawDataBlockIDEND = 7
+)
+
+const (
+	windowSequenceEightShort = 2
+
+	codebookZero       = 0
+	codebookEsc        = 11
+	codebookReserved   = 12
+	codebookNoise      = 13
+	codebookIntensity2 = 14
+	codebookIntensity  = 15
+)
+
+// Specification: ISO 14496-3, Tables 4.129 - 4.147
+var (
+	swbOffset1024At96 = []int{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52,
+		56, 64, 72, 80, 88, 96, 108, 120, 132, 144, 156, 172, 188, 212,
+		240, 276, 320, 384, 448, 512, 576, 640, 704, 768, 832, 896, 960, 1024,
+	}
+	swbOffset1024At64 = []int{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44,
+		48, 52, 56, 64, 72, 80, 88, 100, 112, 124, 140, 156,
+		172, 192, 216, 240, 268, 304, 344, 384, 424, 464, 504, 544,
+		584, 624, 664, 704, 744, 784, 824, 864, 904, 944, 984, 1024,
+	}
+	swbOffset1024At48 = []int{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 48, 56,
+		64, 72, 80, 88, 96, 108, 120, 132, 144, 160, 176, 196, 216,
+		240, 264, 292, 320, 352, 384, 416, 448, 480, 512, 544, 576, 608,
+		640, 672, 704, 736, 768, 800, 832, 864, 896, 928, 1024,
+	}
+	swbOffset1024At32 = []int{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 48, 56,
+		64, 72, 80, 88, 96, 108, 120, 132, 144, 160, 176, 196, 216,
+		240, 264, 292, 320, 352, 384, 416, 448, 480, 512, 544, 576, 608,
+		640, 672, 704, 736, 768, 800, 832, 864, 896, 928, 960, 992, 1024,
+	}
+	swbOffset1024At24 = []int{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44,
+		52, 60, 68, 76, 84, 92, 100, 108, 116, 124, 136, 148,
+		160, 172, 188, 204, 220, 240, 260, 284, 308, 336, 364, 396,
+		432, 468, 508, 552, 600, 652, 704, 768, 832, 896, 960, 1024,
+	}
+	swbOffset1024At16 = []int{
+		0, 8, 16, 24, 32, 40, 48, 56, 64, 72, 80, 88, 100, 112, 124,
+		136, 148, 160, 172, 184, 196, 212, 228, 244, 260, 280, 300, 320, 344, 368,
+		396, 424, 456, 492, 532, 572, 616, 664, 716, 772, 832, 896, 960, 1024,
+	}
+	swbOffset1024At8 = []int{
+		0, 12, 24, 36, 48, 60, 72, 84, 96, 108, 120, 132, 144, 156,
+		172, 188, 204, 220, 236, 252, 268, 288, 308, 328, 348, 372, 396, 420,
+		448, 476, 508, 544, 580, 620, 664, 712, 764, 820, 880, 944, 1024,
+	}
+
+	swbOffset128At96 = []int{0, 4, 8, 12, 16, 20, 24, 32, 40, 48, 64, 92, 128}
+	swbOffset128At48 = []int{0, 4, 8, 12, 16, 20, 28, 36, 44, 56, 68, 80, 96, 112, 128}
+	swbOffset128At24 = []int{0, 4, 8, 12, 16, 20, 24, 28, 36, 44, 52, 64, 76, 92, 108, 128}
+	swbOffset128At16 = []int{0, 4, 8, 12, 16, 20, 24, 28, 32, 40, 48, 60, 72, 88, 108, 128}
+	swbOffset128At8  = []int{0, 4, 8, 12, 16, 20, 24, 28, 36, 44, 52, 60, 72, 88, 108, 128}
+)
+
+// scalefactor band offsets of long windows, indexed by sampling frequency index.
+var swbOffsetsLong = [][]int{
+	swbOffset1024At96,
+	swbOffset1024At96,
+	swbOffset1024At64,
+	swbOffset1024At48,
+	swbOffset1024At48,
+	swbOffset1024At32,
+	swbOffset1024At24,
+	swbOffset1024At24,
+	swbOffset1024At16,
+	swbOffset1024At16,
+	swbOffset1024At16,
+	swbOffset1024At8,
+	swbOffset1024At8,
+}
+
+// scalefactor band offsets of short windows, indexed by sampling frequency index.
+var swbOffsetsShort = [][]int{
+	swbOffset128At96,
+	swbOffset128At96,
+	swbOffset128At96,
+	swbOffset128At48,
+	swbOffset128At48,
+	swbOffset128At48,
+	swbOffset128At24,
+	swbOffset128At24,
+	swbOffset128At16,
+	swbOffset128At16,
+	swbOffset128At16,
+	swbOffset128At8,
+	swbOffset128At8,
+}
+
+// maximum number of scalefactor bands that use prediction, indexed by sampling frequency index.
+// Specification: ISO 13818-7, Table 8.6
+var predSFBMax = []int{33, 33, 38, 40, 40, 40, 41, 41, 37, 37, 37, 34, 34}
+
+type icsInfo struct {
+	eightShort   bool
+	maxSFB       int
+	groupLengths []int
+}
+
+// rawDataBlockRegion is a part of a raw_data_block protected by the CRC.
+type rawDataBlockRegion struct {
+	start int
+	end   int
+	// maximum number of protected bits, or zero if the whole region is protected.
+	// Shorter regions are padded with zeros.
+	maxBits int
+}
+
+// rawDataBlockParser walks through a raw_data_block
+// in order to find the boundaries of syntactic elements.
+// Specification: ISO 14496-3, 4.4.2.1
+type rawDataBlockParser struct {
+	objectType      ObjectType
+	sampleRateIndex int
+	buf             []byte
+	pos             int
+}
+
+func (p *rawDataBlockParser) readBits(n int) (uint64, error) {
+	return bits.ReadBits(p.buf, &p.pos, n)
+}
+
+func (p *rawDataBlockParser) skipBits(n int) error {
+	err := bits.HasSpace(p.buf, p.pos, n)
+	if err != nil {
+		return err
+	}
+	p.pos += n
+	return nil
+}
+
+func (p *rawDataBlockParser) swbOffsets(info *icsInfo) []int {
+	if info.eightShort {
+		return swbOffsetsShort[p.sampleRateIndex]
+	}
+	return swbOffsetsLong[p.sampleRateIndex]
+}
+
+// Specification: ISO 14496-3, Table 4.6
+func (p *rawDataBlockParser) readICSInfo() (*icsInfo, error) {
+	tmp, err := p.readBits(4)
+	if err != nil {
+		return nil, err
+	}
+
+	// ics_reserved_bit, window_shape
+	windowSequence := (tmp >> 1) & 0x03
+
+	info := &icsInfo{
+		eightShort: windowSequence == windowSequenceEightShort,
+	}
+
+	if info.eightShort {
+		tmp, err = p.readBits(11)
+		if err != nil {
+			return nil, err
+		}
+
+		info.maxSFB = int(tmp >> 7)
+		info.groupLengths = []int{1}
+
+		for i := 6; i >= 0; i-- {
+			if ((tmp >> i) & 0x01) != 0 {
+				info.groupLengths[len(info.groupLengths)-1]++
+			} else {
+				info.groupLengths = append(info.groupLengths, 1)
+			}
+		}
+	} else {
+		tmp, err = p.readBits(7)
+		if err != nil {
+			return nil, err
+		}
+
+		info.maxSFB = int(tmp >> 1)
+		info.groupLengths = []int{1}
+
+		predictorDataPresent := (tmp & 0x01) != 0
+		if predictorDataPresent {
+			if p.objectType != ObjectTypeAACMain {
+				return nil, fmt.Errorf("unsupported predictor data")
+			}
+
+			var predictorReset uint64
+			predictorReset, err = p.readBits(1)
+			if err != nil {
+				return nil, err
+			}
+
+			n := min(info.maxSFB, predSFBMax[p.sampleRateIndex])
+			if predictorReset != 0 {
+				n += 5
+			}
+
+			err = p.skipBits(n)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if info.maxSFB > (len(p.swbOffsets(info)) - 1) {
+		return nil, fmt.Errorf("invalid max_sfb: %d", info.maxSFB)
+	}
+
+	return info, nil
+}
+
+// Specification: ISO 14496-3, Table 4.52
+func (p *rawDataBlockParser) readSectionData(info *icsInfo) ([][]uint8, error) {
+	sectBits := 5
+	if info.eightShort {
+		sectBits = 3
+	}
+	sectEscVal := uint64(1<<sectBits) - 1
+
+	sfbCB := make([][]uint8, len(info.groupLengths))
+
+	for g := range sfbCB {
+		sfbCB[g] = make([]uint8, info.maxSFB)
+		k := 0
+
+		for k < info.maxSFB {
+			cb, err := p.readBits(4)
+			if err != nil {
+				return nil, err
+			}
+
+			if cb == codebookReserved {
+				return nil, fmt.Errorf("invalid codebook")
+			}
+
+			sectLen := 0
+
+			for {
+				var incr uint64
+				incr, err = p.readBits(sectBits)
+				if err != nil {
+					return nil, err
+				}
+
+				sectLen += int(incr)
+
+				if incr != sectEscVal {
+					break
+				}
+			}
+
+			if (k + sectLen) > info.maxSFB {
+				return nil, fmt.Errorf("invalid section length")
+			}
+
+			for sfb := k; sfb < (k + sectLen); sfb++ {
+				sfbCB[g][sfb] = uint8(cb)
+			}
+			k += sectLen
+		}
+	}
+
+	return sfbCB, nil
+}
+
+// Specification: ISO 14496-3, Table 4.53
+func (p *rawDataBlockParser) readScaleFactorData(sfbCB [][]uint8) error {
+	noisePCMFlag := true
+
+	for _, cbs := range sfbCB {
+		for _, cb := range cbs {
+			switch cb {
+			case codebookZero:
+
+			case codebookNoise:
+				if noisePCMFlag {
+					noisePCMFlag = false
+
+					err := p.skipBits(9)
+					if err != nil {
+						return err
+					}
+				} else {
+					_, err := huffmanScalefactorTree.decode(p.buf, &p.pos)
+					if err != nil {
+						return err
+					}
+				}
+
+			default:
+				_, err := huffmanScalefactorTree.decode(p.buf, &p.pos)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Specification: ISO 14496-3, Tables 4.7, 4.48
+func (p *rawDataBlockParser) readPulseData(info *icsInfo) error {
+	if info.eightShort {
+		return fmt.Errorf("pulse data is not allowed in short windows")
+	}
+
+	numberPulse, err := p.readBits(2)
+	if err != nil {
+		return err
+	}
+
+	return p.skipBits(6 + int(numberPulse+1)*(5+4))
+}
+
+// Specification: ISO 14496-3, Table 4.48
+func (p *rawDataBlockParser) readTNSData(info *icsInfo) error {
+	numWindows := 1
+	nFiltBits := 2
+	lengthBits := 6
+	orderBits := 5
+
+	if info.eightShort {
+		numWindows = 8
+		nFiltBits = 1
+		lengthBits = 4
+		orderBits = 3
+	}
+
+	for w := 0; w < numWindows; w++ {
+		nFilt, err := p.readBits(nFiltBits)
+		if err != nil {
+			return err
+		}
+
+		if nFilt == 0 {
+			continue
+		}
+
+		coefRes, err := p.readBits(1)
+		if err != nil {
+			return err
+		}
+
+		for filt := 0; filt < int(nFilt); filt++ {
+			var tmp uint64
+			tmp, err = p.readBits(lengthBits + orderBits)
+			if err != nil {
+				return err
+			}
+
+			order := int(tmp & (1<<orderBits - 1))
+			if order == 0 {
+				continue
+			}
+
+			var coefCompress uint64
+			coefCompress, err = p.readBits(2)
+			if err != nil {
+				return err
+			}
+
+			coefBits := 3 + int(coefRes) - int(coefCompress&0x01)
+
+			err = p.skipBits(order * coefBits)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Specification: ISO 14496-3, Table 4.56
+func (p *rawDataBlockParser) readEscape() error {
+	n := 0
+
+	for {
+		bit, err := p.readBits(1)
+		if err != nil {
+			return err
+		}
+
+		if bit == 0 {
+			break
+		}
+
+		n++
+		if n > 8 {
+			return fmt.Errorf("invalid escape sequence")
+		}
+	}
+
+	return p.skipBits(n + 4)
+}
+
+// Specification: ISO 14496-3, Table 4.56
+func (p *rawDataBlockParser) readSpectralData(info *icsInfo, sfbCB [][]uint8) error {
+	swbOffsets := p.swbOffsets(info)
+
+	for g, cbs := range sfbCB {
+		for sfb, cb := range cbs {
+			if cb == codebookZero || cb >= codebookNoise {
+				continue
+			}
+
+			tree := huffmanSpectrumTrees[cb-1]
+			unsigned := cb == 3 || cb == 4 || cb >= 7
+			width := (swbOffsets[sfb+1] - swbOffsets[sfb]) * info.groupLengths[g]
+
+			var values [4]int
+			var dim int
+
+			if cb < 5 {
+				dim = 4
+			} else {
+				dim = 2
+			}
+
+			for k := 0; k < width; k += dim {
+				idx, err := tree.decode(p.buf, &p.pos)
+				if err != nil {
+					return err
+				}
+
+				switch cb {
+				case 1, 2, 3, 4:
+					values = [4]int{idx / 27, (idx / 9) % 3, (idx / 3) % 3, idx % 3}
+
+				case 5, 6:
+					values = [4]int{idx / 9, idx % 9}
+
+				case 7, 8:
+					values = [4]int{idx / 8, idx % 8}
+
+				case 9, 10:
+					values = [4]int{idx / 13, idx % 13}
+
+				default:
+					values = [4]int{idx / 17, idx % 17}
+				}
+
+				if unsigned {
+					signs := 0
+					for _, v := range values[:dim] {
+						if v != 0 {
+							signs++
+						}
+					}
+
+					err = p.skipBits(signs)
+					if err != nil {
+						return err
+					}
+				}
+
+				if cb == codebookEsc {
+					for _, v := range values[:dim] {
+						if v == 16 {
+							err = p.readEscape()
+							if err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Specification: ISO 14496-3, Table 4.50
+func (p *rawDataBlockParser) readICS(info *icsInfo) (*icsInfo, [][]uint8, error) {
+	// global_gain
+	err := p.skipBits(8)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info == nil {
+		info, err = p.readICSInfo()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sfbCB, err := p.readSectionData(info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.readScaleFactorData(sfbCB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmp, err := p.readBits(1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tmp != 0 {
+		err = p.readPulseData(info)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tmp, err = p.readBits(1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tmp != 0 {
+		err = p.readTNSData(info)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tmp, err = p.readBits(1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tmp != 0 {
+		return nil, nil, fmt.Errorf("unsupported gain control data")
+	}
+
+	err = p.readSpectralData(info, sfbCB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, sfbCB, nil
+}
+
+// Specification: ISO 14496-3, Table 4.5
+func (p *rawDataBlockParser) readChannelPairElement() (int, error) {
+	tmp, err := p.readBits(5)
+	if err != nil {
+		return 0, err
+	}
+
+	// element_instance_tag
+	commonWindow := (tmp & 0x01) != 0
+
+	var info *icsInfo
+
+	if commonWindow {
+		info, err = p.readICSInfo()
+		if err != nil {
+			return 0, err
+		}
+
+		var msMaskPresent uint64
+		msMaskPresent, err = p.readBits(2)
+		if err != nil {
+			return 0, err
+		}
+
+		switch msMaskPresent {
+		case 1:
+			err = p.skipBits(len(info.groupLengths) * info.maxSFB)
+			if err != nil {
+				return 0, err
+			}
+
+		case 3:
+			return 0, fmt.Errorf("invalid ms_mask_present")
+		}
+	}
+
+	_, _, err = p.readICS(info)
+	if err != nil {
+		return 0, err
+	}
+
+	secondStart := p.pos
+
+	_, _, err = p.readICS(info)
+	if err != nil {
+		return 0, err
+	}
+
+	return secondStart, nil
+}
+
+// Specification: ISO 14496-3, Table 4.8
+func (p *rawDataBlockParser) readCouplingChannelElement() error {
+	tmp, err := p.readBits(8)
+	if err != nil {
+		return err
+	}
+
+	// element_instance_tag
+	indSwCCEFlag := ((tmp >> 3) & 0x01) != 0
+	numCoupledElements := int(tmp & 0x07)
+	numGainElementLists := 0
+
+	for c := 0; c <= numCoupledElements; c++ {
+		numGainElementLists++
+
+		tmp, err = p.readBits(5)
+		if err != nil {
+			return err
+		}
+
+		ccTargetIsCPE := (tmp >> 4) != 0
+		if ccTargetIsCPE {
+			tmp, err = p.readBits(2)
+			if err != nil {
+				return err
+			}
+
+			if tmp == 0x03 {
+				numGainElementLists++
+			}
+		}
+	}
+
+	// cc_domain, gain_element_sign, gain_element_scale
+	err = p.skipBits(4)
+	if err != nil {
+		return err
+	}
+
+	_, sfbCB, err := p.readICS(nil)
+	if err != nil {
+		return err
+	}
+
+	for c := 1; c < numGainElementLists; c++ {
+		commonGainElementPresent := true
+
+		if !indSwCCEFlag {
+			tmp, err = p.readBits(1)
+			if err != nil {
+				return err
+			}
+			commonGainElementPresent = (tmp != 0)
+		}
+
+		if commonGainElementPresent {
+			_, err = huffmanScalefactorTree.decode(p.buf, &p.pos)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, cbs := range sfbCB {
+			for _, cb := range cbs {
+				if cb != codebookZero {
+					_, err = huffmanScalefactorTree.decode(p.buf, &p.pos)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Specification: ISO 14496-3, Table 4.10
+func (p *rawDataBlockParser) readDataStreamElement() error {
+	tmp, err := p.readBits(13)
+	if err != nil {
+		return err
+	}
+
+	// element_instance_tag
+	dataByteAlignFlag := ((tmp >> 8) & 0x01) != 0
+	count := int(tmp & 0xFF)
+
+	if count == 255 {
+		tmp, err = p.readBits(8)
+		if err != nil {
+			return err
+		}
+		count += int(tmp)
+	}
+
+	if dataByteAlignFlag {
+		p.pos += (8 - p.pos%8) % 8
+	}
+
+	return p.skipBits(count * 8)
+}
+
+// Specification: ISO 14496-3, Table 4.11
+func (p *rawDataBlockParser) readFillElement() error {
+	count, err := p.readBits(4)
+	if err != nil {
+		return err
+	}
+
+	if count == 15 {
+		var escCount uint64
+		escCount, err = p.readBits(8)
+		if err != nil {
+			return err
+		}
+		count += escCount - 1
+	}
+
+	return p.skipBits(int(count) * 8)
+}
+
+// parse walks through the raw_data_block
+// and returns the regions that are protected by the CRC.
+// Specification: ISO 13818-7, crc_check
+func (p *rawDataBlockParser) parse() ([]rawDataBlockRegion, error) {
+	if p.sampleRateIndex >= len(swbOffsetsLong) {
+		return nil, fmt.Errorf("invalid sampling frequency index: %d", p.sampleRateIndex)
+	}
+
+	var regions []rawDataBlockRegion
+
+	for {
+		id, err := p.readBits(3)
+		if err != nil {
+			return nil, err
+		}
+
+		start := p.pos
+
+		switch id {
+		case rawDataBlockIDSCE, rawDataBlockIDLFE:
+			// element_instance_tag
+			err = p.skipBits(4)
+			if err != nil {
+				return nil, err
+			}
+
+			_, _, err = p.readICS(nil)
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions, rawDataBlockRegion{start: start, end: p.pos, maxBits: 192})
+
+		case rawDataBlockIDCPE:
+			var secondStart int
+			secondStart, err = p.readChannelPairElement()
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions,
+				rawDataBlockRegion{start: start, end: p.pos, maxBits: 192},
+				rawDataBlockRegion{start: secondStart, end: p.pos, maxBits: 128})
+
+		case rawDataBlockIDCCE:
+			err = p.readCouplingChannelElement()
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions, rawDataBlockRegion{start: start, end: p.pos, maxBits: 192})
+
+		case rawDataBlockIDDSE:
+			err = p.readDataStreamElement()
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions, rawDataBlockRegion{start: start, end: p.pos})
+
+		case rawDataBlockIDPCE:
+			var pce ProgramConfigElement
+			err = pce.unmarshalFromPos(p.buf, &p.pos, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions, rawDataBlockRegion{start: start, end: p.pos})
+
+		case rawDataBlockIDFIL:
+			err = p.readFillElement()
+			if err != nil {
+				return nil, err
+			}
+
+		default: // rawDataBlockIDEND
+			return regions, nil
+		}
+	}
+}
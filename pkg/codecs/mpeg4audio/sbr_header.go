@@ -0,0 +1,119 @@
+package mpeg4audio
+
+import (
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// SBRHeader is a sbr_header or a SbrDfltHeader.
+// Specification: ISO 14496-3, Table 4.63
+type SBRHeader struct {
+	AmpRes        bool  // not present in SbrDfltHeader
+	StartFreq     uint8 // 4 bits
+	StopFreq      uint8 // 4 bits
+	XoverBand     uint8 // 3 bits, not present in SbrDfltHeader
+	HeaderExtra1  bool
+	HeaderExtra2  bool
+	FreqScale     uint8 // 2 bits
+	AlterScale    bool
+	NoiseBands    uint8 // 2 bits
+	LimiterBands  uint8 // 2 bits
+	LimiterGains  uint8 // 2 bits
+	InterpolFreq  bool
+	SmoothingMode bool
+}
+
+func (h *SBRHeader) unmarshalFromPos(buf []byte, pos *int, dflt bool) error {
+	n := 10
+	if !dflt {
+		n += 1 + 3 + 2
+	}
+
+	err := bits.HasSpace(buf, *pos, n)
+	if err != nil {
+		return err
+	}
+
+	if !dflt {
+		h.AmpRes = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	h.StartFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	h.StopFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+
+	if !dflt {
+		h.XoverBand = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+		*pos += 2 // bs_reserved
+	}
+
+	h.HeaderExtra1 = bits.ReadFlagUnsafe(buf, pos)
+	h.HeaderExtra2 = bits.ReadFlagUnsafe(buf, pos)
+
+	if h.HeaderExtra1 {
+		err = bits.HasSpace(buf, *pos, 5)
+		if err != nil {
+			return err
+		}
+
+		h.FreqScale = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.AlterScale = bits.ReadFlagUnsafe(buf, pos)
+		h.NoiseBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	}
+
+	if h.HeaderExtra2 {
+		err = bits.HasSpace(buf, *pos, 6)
+		if err != nil {
+			return err
+		}
+
+		h.LimiterBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.LimiterGains = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.InterpolFreq = bits.ReadFlagUnsafe(buf, pos)
+		h.SmoothingMode = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	return nil
+}
+
+func (h SBRHeader) marshalSizeBits(dflt bool) int {
+	n := 10
+	if !dflt {
+		n += 1 + 3 + 2
+	}
+	if h.HeaderExtra1 {
+		n += 5
+	}
+	if h.HeaderExtra2 {
+		n += 6
+	}
+	return n
+}
+
+func (h SBRHeader) marshalTo(buf []byte, pos *int, dflt bool) {
+	if !dflt {
+		writeFlag(buf, pos, h.AmpRes)
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.StartFreq), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.StopFreq), 4)
+
+	if !dflt {
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.XoverBand), 3)
+		bits.WriteBitsUnsafe(buf, pos, 0, 2)
+	}
+
+	writeFlag(buf, pos, h.HeaderExtra1)
+	writeFlag(buf, pos, h.HeaderExtra2)
+
+	if h.HeaderExtra1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.FreqScale), 2)
+		writeFlag(buf, pos, h.AlterScale)
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.NoiseBands), 2)
+	}
+
+	if h.HeaderExtra2 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.LimiterBands), 2)
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.LimiterGains), 2)
+		writeFlag(buf, pos, h.InterpolFreq)
+		writeFlag(buf, pos, h.SmoothingMode)
+	}
+}
@@ -35,24 +35,27 @@ type StreamMuxConfig struct {
 // Unmarshal decodes a StreamMuxConfig.
 func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 	pos := 0
+	return c.unmarshalFromPos(buf, &pos)
+}
 
-	err := bits.HasSpace(buf, pos, 12)
+func (c *StreamMuxConfig) unmarshalFromPos(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 12)
 	if err != nil {
 		return err
 	}
 
-	audioMuxVersion := bits.ReadFlagUnsafe(buf, &pos)
+	audioMuxVersion := bits.ReadFlagUnsafe(buf, pos)
 	if audioMuxVersion {
 		return fmt.Errorf("audioMuxVersion = 1 is not supported")
 	}
 
-	allStreamsSameTimeFraming := bits.ReadFlagUnsafe(buf, &pos)
+	allStreamsSameTimeFraming := bits.ReadFlagUnsafe(buf, pos)
 	if !allStreamsSameTimeFraming {
 		return fmt.Errorf("allStreamsSameTimeFraming = 0 is not supported")
 	}
 
-	c.NumSubFrames = uint(bits.ReadBitsUnsafe(buf, &pos, 6))
-	numProgram := uint(bits.ReadBitsUnsafe(buf, &pos, 4))
+	c.NumSubFrames = uint(bits.ReadBitsUnsafe(buf, pos, 6))
+	numProgram := uint(bits.ReadBitsUnsafe(buf, pos, 4))
 
 	c.Programs = make([]*StreamMuxConfigProgram, numProgram+1)
 
@@ -61,7 +64,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		c.Programs[prog] = p
 
 		var numLayer uint64
-		numLayer, err = bits.ReadBits(buf, &pos, 3)
+		numLayer, err = bits.ReadBits(buf, pos, 3)
 		if err != nil {
 			return err
 		}
@@ -77,7 +80,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 			if prog == 0 && lay == 0 {
 				useSameConfig = false
 			} else {
-				useSameConfig, err = bits.ReadFlag(buf, &pos)
+				useSameConfig, err = bits.ReadFlag(buf, pos)
 				if err != nil {
 					return err
 				}
@@ -85,14 +88,14 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 
 			if !useSameConfig {
 				l.AudioSpecificConfig = &AudioSpecificConfig{}
-				err = l.AudioSpecificConfig.UnmarshalFromPos(buf, &pos)
+				err = l.AudioSpecificConfig.UnmarshalFromPos(buf, pos)
 				if err != nil {
 					return err
 				}
 			}
 
 			var tmp uint64
-			tmp, err = bits.ReadBits(buf, &pos, 3)
+			tmp, err = bits.ReadBits(buf, pos, 3)
 			if err != nil {
 				// support truncated configs
 				l.LatmBufferFullness = 255
@@ -104,28 +107,28 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 
 			switch l.FrameLengthType {
 			case 0:
-				tmp, err = bits.ReadBits(buf, &pos, 8)
+				tmp, err = bits.ReadBits(buf, pos, 8)
 				if err != nil {
 					return err
 				}
 				l.LatmBufferFullness = uint(tmp)
 
 			case 1:
-				tmp, err = bits.ReadBits(buf, &pos, 9)
+				tmp, err = bits.ReadBits(buf, pos, 9)
 				if err != nil {
 					return err
 				}
 				l.FrameLength = uint(tmp)
 
 			case 4, 5, 3:
-				tmp, err = bits.ReadBits(buf, &pos, 6)
+				tmp, err = bits.ReadBits(buf, pos, 6)
 				if err != nil {
 					return err
 				}
 				l.CELPframeLengthTableIndex = uint(tmp)
 
 			case 6, 7:
-				l.HVXCframeLengthTableIndex, err = bits.ReadFlag(buf, &pos)
+				l.HVXCframeLengthTableIndex, err = bits.ReadFlag(buf, pos)
 				if err != nil {
 					return err
 				}
@@ -133,7 +136,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		}
 	}
 
-	c.OtherDataPresent, err = bits.ReadFlag(buf, &pos)
+	c.OtherDataPresent, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
@@ -142,13 +145,13 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		for {
 			c.OtherDataLenBits *= 256
 
-			err = bits.HasSpace(buf, pos, 9)
+			err = bits.HasSpace(buf, *pos, 9)
 			if err != nil {
 				return err
 			}
 
-			otherDataLenEsc := bits.ReadFlagUnsafe(buf, &pos)
-			otherDataLenTmp := uint32(bits.ReadBitsUnsafe(buf, &pos, 8))
+			otherDataLenEsc := bits.ReadFlagUnsafe(buf, pos)
+			otherDataLenTmp := uint32(bits.ReadBitsUnsafe(buf, pos, 8))
 			c.OtherDataLenBits += otherDataLenTmp
 
 			if !otherDataLenEsc {
@@ -157,13 +160,13 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		}
 	}
 
-	c.CRCCheckPresent, err = bits.ReadFlag(buf, &pos)
+	c.CRCCheckPresent, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
 	if c.CRCCheckPresent {
-		tmp, err := bits.ReadBits(buf, &pos, 8)
+		tmp, err := bits.ReadBits(buf, pos, 8)
 		if err != nil {
 			return err
 		}
@@ -173,7 +176,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 	return nil
 }
 
-func (c StreamMuxConfig) marshalSize() int {
+func (c StreamMuxConfig) marshalSizeBits() int {
 	n := 12
 
 	for prog, p := range c.Programs {
@@ -226,6 +229,12 @@ func (c StreamMuxConfig) marshalSize() int {
 		n += 8
 	}
 
+	return n
+}
+
+func (c StreamMuxConfig) marshalSize() int {
+	n := c.marshalSizeBits()
+
 	ret := n / 8
 	if (n % 8) != 0 {
 		ret++
@@ -239,54 +248,63 @@ func (c StreamMuxConfig) Marshal() ([]byte, error) {
 	buf := make([]byte, c.marshalSize())
 	pos := 0
 
-	bits.WriteBitsUnsafe(buf, &pos, 0, 1) // audioMuxVersion
-	bits.WriteBitsUnsafe(buf, &pos, 1, 1) // allStreamsSameTimeFraming
-	bits.WriteBitsUnsafe(buf, &pos, uint64(c.NumSubFrames), 6)
-	bits.WriteBitsUnsafe(buf, &pos, uint64(len(c.Programs)-1), 4)
+	err := c.marshalTo(buf, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (c StreamMuxConfig) marshalTo(buf []byte, pos *int) error {
+	bits.WriteBitsUnsafe(buf, pos, 0, 1) // audioMuxVersion
+	bits.WriteBitsUnsafe(buf, pos, 1, 1) // allStreamsSameTimeFraming
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.NumSubFrames), 6)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(c.Programs)-1), 4)
 
 	for prog, p := range c.Programs {
-		bits.WriteBitsUnsafe(buf, &pos, uint64(len(p.Layers)-1), 3)
+		bits.WriteBitsUnsafe(buf, pos, uint64(len(p.Layers)-1), 3)
 
 		for lay, l := range p.Layers {
 			if prog != 0 || lay != 0 {
 				if l.AudioSpecificConfig != nil {
-					bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+					bits.WriteBitsUnsafe(buf, pos, 0, 1)
 				} else {
-					bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+					bits.WriteBitsUnsafe(buf, pos, 1, 1)
 				}
 			}
 
 			if l.AudioSpecificConfig != nil {
-				err := l.AudioSpecificConfig.marshalTo(buf, &pos)
+				err := l.AudioSpecificConfig.marshalTo(buf, pos)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 
-			bits.WriteBitsUnsafe(buf, &pos, uint64(l.FrameLengthType), 3)
+			bits.WriteBitsUnsafe(buf, pos, uint64(l.FrameLengthType), 3)
 
 			switch l.FrameLengthType {
 			case 0:
-				bits.WriteBitsUnsafe(buf, &pos, uint64(l.LatmBufferFullness), 8)
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.LatmBufferFullness), 8)
 
 			case 1:
-				bits.WriteBitsUnsafe(buf, &pos, uint64(l.FrameLength), 9)
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.FrameLength), 9)
 
 			case 4, 5, 3:
-				bits.WriteBitsUnsafe(buf, &pos, uint64(l.CELPframeLengthTableIndex), 6)
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.CELPframeLengthTableIndex), 6)
 
 			case 6, 7:
 				if l.HVXCframeLengthTableIndex {
-					bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+					bits.WriteBitsUnsafe(buf, pos, 1, 1)
 				} else {
-					bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+					bits.WriteBitsUnsafe(buf, pos, 0, 1)
 				}
 			}
 		}
 	}
 
 	if c.OtherDataPresent {
-		bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+		bits.WriteBitsUnsafe(buf, pos, 1, 1)
 
 		var lenBytes []byte
 		tmp := c.OtherDataLenBits
@@ -303,22 +321,22 @@ func (c StreamMuxConfig) Marshal() ([]byte, error) {
 		}
 
 		for i := len(lenBytes) - 1; i > 0; i-- {
-			bits.WriteBitsUnsafe(buf, &pos, 1, 1)
-			bits.WriteBitsUnsafe(buf, &pos, uint64(lenBytes[i]), 8)
+			bits.WriteBitsUnsafe(buf, pos, 1, 1)
+			bits.WriteBitsUnsafe(buf, pos, uint64(lenBytes[i]), 8)
 		}
 
-		bits.WriteBitsUnsafe(buf, &pos, 0, 1)
-		bits.WriteBitsUnsafe(buf, &pos, uint64(lenBytes[0]), 8)
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+		bits.WriteBitsUnsafe(buf, pos, uint64(lenBytes[0]), 8)
 	} else {
-		bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
 	}
 
 	if c.CRCCheckPresent {
-		bits.WriteBitsUnsafe(buf, &pos, 1, 1)
-		bits.WriteBitsUnsafe(buf, &pos, uint64(c.CRCCheckSum), 8)
+		bits.WriteBitsUnsafe(buf, pos, 1, 1)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.CRCCheckSum), 8)
 	} else {
-		bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
 	}
 
-	return buf, nil
+	return nil
 }
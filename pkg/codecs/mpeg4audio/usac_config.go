@@ -0,0 +1,725 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+var usacSampleRates = map[uint64]int{
+	0x00: 96000,
+	0x01: 88200,
+	0x02: 64000,
+	0x03: 48000,
+	0x04: 44100,
+	0x05: 32000,
+	0x06: 24000,
+	0x07: 22050,
+	0x08: 16000,
+	0x09: 12000,
+	0x0a: 11025,
+	0x0b: 8000,
+	0x0c: 7350,
+	0x0f: 57600,
+	0x10: 51200,
+	0x11: 40000,
+	0x12: 38400,
+	0x13: 34150,
+	0x14: 28800,
+	0x15: 25600,
+	0x16: 20000,
+	0x17: 19200,
+	0x18: 17075,
+	0x19: 14400,
+	0x1a: 12800,
+	0x1b: 9600,
+}
+
+var reverseUsacSampleRates = func() map[int]uint64 {
+	ret := make(map[int]uint64, len(usacSampleRates))
+	for k, v := range usacSampleRates {
+		ret[v] = k
+	}
+	return ret
+}()
+
+// channel count of channelConfigurationIndex.
+// Specification: ISO 23001-8, ChannelConfiguration
+var usacChannelCounts = []int{0, 1, 2, 3, 4, 5, 6, 8, 2, 3, 4, 7, 8, 24, 8}
+
+func readEscapedValue(buf []byte, pos *int, n1 int, n2 int, n3 int) (uint64, error) {
+	v, err := bits.ReadBits(buf, pos, n1)
+	if err != nil {
+		return 0, err
+	}
+
+	if v == (1<<n1)-1 {
+		var v2 uint64
+		v2, err = bits.ReadBits(buf, pos, n2)
+		if err != nil {
+			return 0, err
+		}
+		v += v2
+
+		if v2 == (1<<n2)-1 && n3 != 0 {
+			var v3 uint64
+			v3, err = bits.ReadBits(buf, pos, n3)
+			if err != nil {
+				return 0, err
+			}
+			v += v3
+		}
+	}
+
+	return v, nil
+}
+
+func escapedValueSizeBits(v uint64, n1 int, n2 int, n3 int) int {
+	if v < (1<<n1)-1 {
+		return n1
+	}
+	v -= (1 << n1) - 1
+
+	if v < (1<<n2)-1 {
+		return n1 + n2
+	}
+
+	return n1 + n2 + n3
+}
+
+func writeEscapedValue(buf []byte, pos *int, v uint64, n1 int, n2 int, n3 int) error {
+	if v >= (1<<n1)-1+(1<<n2)-1+(1<<n3) {
+		return fmt.Errorf("value is too big (%d)", v)
+	}
+
+	if v < (1<<n1)-1 {
+		bits.WriteBitsUnsafe(buf, pos, v, n1)
+		return nil
+	}
+	bits.WriteBitsUnsafe(buf, pos, (1<<n1)-1, n1)
+	v -= (1 << n1) - 1
+
+	if v < (1<<n2)-1 {
+		bits.WriteBitsUnsafe(buf, pos, v, n2)
+		return nil
+	}
+	bits.WriteBitsUnsafe(buf, pos, (1<<n2)-1, n2)
+	v -= (1 << n2) - 1
+
+	if n3 != 0 {
+		bits.WriteBitsUnsafe(buf, pos, v, n3)
+	}
+	return nil
+}
+
+// UsacElementType is the type of a USAC element.
+type UsacElementType int
+
+// element types.
+const (
+	UsacElementTypeSCE UsacElementType = 0
+	UsacElementTypeCPE UsacElementType = 1
+	UsacElementTypeLFE UsacElementType = 2
+	UsacElementTypeEXT UsacElementType = 3
+)
+
+// UsacSBRConfig is a SbrConfig.
+// Specification: ISO 23003-3, SbrConfig()
+type UsacSBRConfig struct {
+	HarmonicSBR   bool
+	InterTES      bool
+	PVC           bool
+	DefaultHeader SBRHeader
+}
+
+// UsacMps212Config is a Mps212Config.
+// Specification: ISO 23003-3, Mps212Config()
+type UsacMps212Config struct {
+	FreqRes              uint8 // 3 bits
+	FixedGainDMX         uint8 // 3 bits
+	TempShapeConfig      uint8 // 2 bits
+	DecorrConfig         uint8 // 2 bits
+	HighRateMode         bool
+	PhaseCoding          bool
+	OttBandsPhasePresent bool
+	OttBandsPhase        uint8 // 5 bits
+	ResidualBands        uint8 // 5 bits
+	PseudoLR             bool
+	EnvQuantMode         bool
+}
+
+func (c *UsacMps212Config) unmarshalFromPos(buf []byte, pos *int, stereoConfigIndex uint8) error {
+	err := bits.HasSpace(buf, *pos, 13)
+	if err != nil {
+		return err
+	}
+
+	c.FreqRes = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.FixedGainDMX = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.TempShapeConfig = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	c.DecorrConfig = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	c.HighRateMode = bits.ReadFlagUnsafe(buf, pos)
+	c.PhaseCoding = bits.ReadFlagUnsafe(buf, pos)
+	c.OttBandsPhasePresent = bits.ReadFlagUnsafe(buf, pos)
+
+	if c.OttBandsPhasePresent {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+		c.OttBandsPhase = uint8(tmp)
+	}
+
+	if stereoConfigIndex > 1 {
+		err = bits.HasSpace(buf, *pos, 6)
+		if err != nil {
+			return err
+		}
+
+		c.ResidualBands = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+		c.PseudoLR = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	if c.TempShapeConfig == 2 {
+		c.EnvQuantMode, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c UsacMps212Config) marshalSizeBits(stereoConfigIndex uint8) int {
+	n := 13
+	if c.OttBandsPhasePresent {
+		n += 5
+	}
+	if stereoConfigIndex > 1 {
+		n += 6
+	}
+	if c.TempShapeConfig == 2 {
+		n++
+	}
+	return n
+}
+
+func (c UsacMps212Config) marshalTo(buf []byte, pos *int, stereoConfigIndex uint8) {
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.FreqRes), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.FixedGainDMX), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.TempShapeConfig), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.DecorrConfig), 2)
+	writeFlag(buf, pos, c.HighRateMode)
+	writeFlag(buf, pos, c.PhaseCoding)
+	writeFlag(buf, pos, c.OttBandsPhasePresent)
+
+	if c.OttBandsPhasePresent {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.OttBandsPhase), 5)
+	}
+
+	if stereoConfigIndex > 1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.ResidualBands), 5)
+		writeFlag(buf, pos, c.PseudoLR)
+	}
+
+	if c.TempShapeConfig == 2 {
+		writeFlag(buf, pos, c.EnvQuantMode)
+	}
+}
+
+// UsacElementConfig is the configuration of a USAC element.
+// Specification: ISO 23003-3, UsacDecoderConfig()
+type UsacElementConfig struct {
+	Type UsacElementType
+
+	// SCE / CPE specific
+	TwMDCT       bool
+	NoiseFilling bool
+	SBRConfig    *UsacSBRConfig // present when sbrRatioIndex > 0
+
+	// CPE specific
+	StereoConfigIndex uint8
+	Mps212Config      *UsacMps212Config // present when StereoConfigIndex > 0
+
+	// EXT specific
+	ExtType                 uint32
+	ExtDefaultLengthPresent bool
+	ExtDefaultLength        uint32
+	ExtPayloadFrag          bool
+	ExtConfig               []byte
+}
+
+func (e *UsacElementConfig) unmarshalFromPos(buf []byte, pos *int, sbrRatioIndex int) error {
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+	e.Type = UsacElementType(tmp)
+
+	switch e.Type {
+	case UsacElementTypeSCE, UsacElementTypeCPE:
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		e.TwMDCT = bits.ReadFlagUnsafe(buf, pos)
+		e.NoiseFilling = bits.ReadFlagUnsafe(buf, pos)
+
+		if sbrRatioIndex > 0 {
+			err = bits.HasSpace(buf, *pos, 3)
+			if err != nil {
+				return err
+			}
+
+			e.SBRConfig = &UsacSBRConfig{}
+			e.SBRConfig.HarmonicSBR = bits.ReadFlagUnsafe(buf, pos)
+			e.SBRConfig.InterTES = bits.ReadFlagUnsafe(buf, pos)
+			e.SBRConfig.PVC = bits.ReadFlagUnsafe(buf, pos)
+
+			err = e.SBRConfig.DefaultHeader.unmarshalFromPos(buf, pos, true)
+			if err != nil {
+				return err
+			}
+
+			if e.Type == UsacElementTypeCPE {
+				tmp, err = bits.ReadBits(buf, pos, 2)
+				if err != nil {
+					return err
+				}
+				e.StereoConfigIndex = uint8(tmp)
+			}
+		}
+
+		if e.StereoConfigIndex > 0 {
+			e.Mps212Config = &UsacMps212Config{}
+			err = e.Mps212Config.unmarshalFromPos(buf, pos, e.StereoConfigIndex)
+			if err != nil {
+				return err
+			}
+		}
+
+	case UsacElementTypeEXT:
+		tmp, err = readEscapedValue(buf, pos, 4, 8, 16)
+		if err != nil {
+			return err
+		}
+		e.ExtType = uint32(tmp)
+
+		var configLength uint64
+		configLength, err = readEscapedValue(buf, pos, 4, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		e.ExtDefaultLengthPresent, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if e.ExtDefaultLengthPresent {
+			tmp, err = readEscapedValue(buf, pos, 8, 16, 0)
+			if err != nil {
+				return err
+			}
+			e.ExtDefaultLength = uint32(tmp) + 1
+		}
+
+		e.ExtPayloadFrag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if configLength != 0 {
+			e.ExtConfig, err = readBytesFromPos(buf, pos, int(configLength))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e UsacElementConfig) marshalSizeBits() int {
+	n := 2
+
+	switch e.Type {
+	case UsacElementTypeSCE, UsacElementTypeCPE:
+		n += 2
+
+		if e.SBRConfig != nil {
+			n += 3 + e.SBRConfig.DefaultHeader.marshalSizeBits(true)
+
+			if e.Type == UsacElementTypeCPE {
+				n += 2
+			}
+		}
+
+		if e.Mps212Config != nil {
+			n += e.Mps212Config.marshalSizeBits(e.StereoConfigIndex)
+		}
+
+	case UsacElementTypeEXT:
+		n += escapedValueSizeBits(uint64(e.ExtType), 4, 8, 16)
+		n += escapedValueSizeBits(uint64(len(e.ExtConfig)), 4, 8, 16)
+		n++
+
+		if e.ExtDefaultLengthPresent && e.ExtDefaultLength != 0 {
+			n += escapedValueSizeBits(uint64(e.ExtDefaultLength-1), 8, 16, 0)
+		}
+
+		n += 1 + len(e.ExtConfig)*8
+	}
+
+	return n
+}
+
+func (e UsacElementConfig) marshalTo(buf []byte, pos *int, sbrRatioIndex int) error {
+	bits.WriteBitsUnsafe(buf, pos, uint64(e.Type), 2)
+
+	switch e.Type {
+	case UsacElementTypeSCE, UsacElementTypeCPE:
+		if (e.SBRConfig != nil) != (sbrRatioIndex > 0) {
+			return fmt.Errorf("SBRConfig presence does not match coreSbrFrameLengthIndex")
+		}
+
+		if e.StereoConfigIndex > 0 && (e.Type != UsacElementTypeCPE || e.SBRConfig == nil) {
+			return fmt.Errorf("invalid stereo config index (%d)", e.StereoConfigIndex)
+		}
+
+		if (e.Mps212Config != nil) != (e.StereoConfigIndex > 0) {
+			return fmt.Errorf("Mps212Config presence does not match StereoConfigIndex")
+		}
+
+		writeFlag(buf, pos, e.TwMDCT)
+		writeFlag(buf, pos, e.NoiseFilling)
+
+		if e.SBRConfig != nil {
+			writeFlag(buf, pos, e.SBRConfig.HarmonicSBR)
+			writeFlag(buf, pos, e.SBRConfig.InterTES)
+			writeFlag(buf, pos, e.SBRConfig.PVC)
+			e.SBRConfig.DefaultHeader.marshalTo(buf, pos, true)
+
+			if e.Type == UsacElementTypeCPE {
+				bits.WriteBitsUnsafe(buf, pos, uint64(e.StereoConfigIndex), 2)
+			}
+		}
+
+		if e.Mps212Config != nil {
+			e.Mps212Config.marshalTo(buf, pos, e.StereoConfigIndex)
+		}
+
+	case UsacElementTypeLFE:
+
+	case UsacElementTypeEXT:
+		err := writeEscapedValue(buf, pos, uint64(e.ExtType), 4, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		err = writeEscapedValue(buf, pos, uint64(len(e.ExtConfig)), 4, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		writeFlag(buf, pos, e.ExtDefaultLengthPresent)
+
+		if e.ExtDefaultLengthPresent {
+			if e.ExtDefaultLength == 0 {
+				return fmt.Errorf("invalid default length")
+			}
+
+			err = writeEscapedValue(buf, pos, uint64(e.ExtDefaultLength-1), 8, 16, 0)
+			if err != nil {
+				return err
+			}
+		}
+
+		writeFlag(buf, pos, e.ExtPayloadFrag)
+		writeBytesToPos(buf, pos, e.ExtConfig)
+
+	default:
+		return fmt.Errorf("invalid element type (%d)", e.Type)
+	}
+
+	return nil
+}
+
+// UsacConfigExtension is an extension of a UsacConfig.
+// Specification: ISO 23003-3, UsacConfigExtension()
+type UsacConfigExtension struct {
+	Type uint32
+	Data []byte
+}
+
+// UsacConfig is a UsacConfig.
+// Specification: ISO 23003-3, UsacConfig()
+type UsacConfig struct {
+	SampleRate                int
+	CoreSbrFrameLengthIndex   uint8
+	ChannelConfigurationIndex uint8
+	OutputChannelPositions    []uint8 // present when ChannelConfigurationIndex is 0
+	Elements                  []*UsacElementConfig
+	ConfigExtensions          []*UsacConfigExtension
+}
+
+// sbrRatioIndex of coreSbrFrameLengthIndex.
+// Specification: ISO 23003-3, coreSbrFrameLengthIndex
+func (c UsacConfig) sbrRatioIndex() (int, error) {
+	switch c.CoreSbrFrameLengthIndex {
+	case 0, 1:
+		return 0, nil
+
+	case 2:
+		return 2, nil
+
+	case 3:
+		return 3, nil
+
+	case 4:
+		return 1, nil
+
+	default:
+		return 0, fmt.Errorf("invalid coreSbrFrameLengthIndex (%d)", c.CoreSbrFrameLengthIndex)
+	}
+}
+
+// ChannelCount returns the output channel count.
+func (c UsacConfig) ChannelCount() int {
+	if c.ChannelConfigurationIndex == 0 {
+		return len(c.OutputChannelPositions)
+	}
+	if int(c.ChannelConfigurationIndex) < len(usacChannelCounts) {
+		return usacChannelCounts[c.ChannelConfigurationIndex]
+	}
+	return 0
+}
+
+func (c *UsacConfig) unmarshalFromPos(buf []byte, pos *int) error {
+	sampleRateIndex, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+
+	if sampleRateIndex == 0x1F {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+		c.SampleRate = int(tmp)
+	} else {
+		var ok bool
+		c.SampleRate, ok = usacSampleRates[sampleRateIndex]
+		if !ok {
+			return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
+		}
+	}
+
+	err = bits.HasSpace(buf, *pos, 8)
+	if err != nil {
+		return err
+	}
+
+	c.CoreSbrFrameLengthIndex = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.ChannelConfigurationIndex = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+	sbrRatioIndex, err := c.sbrRatioIndex()
+	if err != nil {
+		return err
+	}
+
+	if c.ChannelConfigurationIndex == 0 {
+		var numOutChannels uint64
+		numOutChannels, err = readEscapedValue(buf, pos, 5, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, *pos, int(numOutChannels)*5)
+		if err != nil {
+			return err
+		}
+
+		c.OutputChannelPositions = make([]uint8, numOutChannels)
+		for i := range c.OutputChannelPositions {
+			c.OutputChannelPositions[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+		}
+	} else if int(c.ChannelConfigurationIndex) >= len(usacChannelCounts) {
+		return fmt.Errorf("invalid channel configuration index (%d)", c.ChannelConfigurationIndex)
+	}
+
+	numElements, err := readEscapedValue(buf, pos, 4, 8, 16)
+	if err != nil {
+		return err
+	}
+	numElements++
+
+	// each element takes at least 2 bits
+	err = bits.HasSpace(buf, *pos, int(numElements)*2)
+	if err != nil {
+		return err
+	}
+
+	c.Elements = make([]*UsacElementConfig, numElements)
+
+	for i := range c.Elements {
+		c.Elements[i] = &UsacElementConfig{}
+		err = c.Elements[i].unmarshalFromPos(buf, pos, sbrRatioIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	extensionPresent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if extensionPresent {
+		var numExtensions uint64
+		numExtensions, err = readEscapedValue(buf, pos, 2, 4, 8)
+		if err != nil {
+			return err
+		}
+		numExtensions++
+
+		for i := uint64(0); i < numExtensions; i++ {
+			ext := &UsacConfigExtension{}
+
+			var tmp uint64
+			tmp, err = readEscapedValue(buf, pos, 4, 8, 16)
+			if err != nil {
+				return err
+			}
+			ext.Type = uint32(tmp)
+
+			tmp, err = readEscapedValue(buf, pos, 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			ext.Data, err = readBytesFromPos(buf, pos, int(tmp))
+			if err != nil {
+				return err
+			}
+
+			c.ConfigExtensions = append(c.ConfigExtensions, ext)
+		}
+	}
+
+	return nil
+}
+
+func (c UsacConfig) marshalSizeBits() int {
+	n := 5
+
+	if _, ok := reverseUsacSampleRates[c.SampleRate]; !ok {
+		n += 24
+	}
+
+	n += 3 + 5
+
+	if c.ChannelConfigurationIndex == 0 {
+		n += escapedValueSizeBits(uint64(len(c.OutputChannelPositions)), 5, 8, 16)
+		n += len(c.OutputChannelPositions) * 5
+	}
+
+	n += escapedValueSizeBits(uint64(len(c.Elements)-1), 4, 8, 16)
+
+	for _, e := range c.Elements {
+		n += e.marshalSizeBits()
+	}
+
+	n++
+
+	if len(c.ConfigExtensions) != 0 {
+		n += escapedValueSizeBits(uint64(len(c.ConfigExtensions)-1), 2, 4, 8)
+
+		for _, ext := range c.ConfigExtensions {
+			n += escapedValueSizeBits(uint64(ext.Type), 4, 8, 16)
+			n += escapedValueSizeBits(uint64(len(ext.Data)), 4, 8, 16)
+			n += len(ext.Data) * 8
+		}
+	}
+
+	return n
+}
+
+func (c UsacConfig) marshalTo(buf []byte, pos *int) error {
+	sbrRatioIndex, err := c.sbrRatioIndex()
+	if err != nil {
+		return err
+	}
+
+	if int(c.ChannelConfigurationIndex) >= len(usacChannelCounts) {
+		return fmt.Errorf("invalid channel configuration index (%d)", c.ChannelConfigurationIndex)
+	}
+
+	if len(c.Elements) == 0 {
+		return fmt.Errorf("no elements provided")
+	}
+
+	sampleRateIndex, ok := reverseUsacSampleRates[c.SampleRate]
+	if !ok {
+		bits.WriteBitsUnsafe(buf, pos, 0x1F, 5)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.SampleRate), 24)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, sampleRateIndex, 5)
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.CoreSbrFrameLengthIndex), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.ChannelConfigurationIndex), 5)
+
+	if c.ChannelConfigurationIndex == 0 {
+		err = writeEscapedValue(buf, pos, uint64(len(c.OutputChannelPositions)), 5, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range c.OutputChannelPositions {
+			bits.WriteBitsUnsafe(buf, pos, uint64(p), 5)
+		}
+	}
+
+	err = writeEscapedValue(buf, pos, uint64(len(c.Elements)-1), 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range c.Elements {
+		err = e.marshalTo(buf, pos, sbrRatioIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(c.ConfigExtensions) != 0 {
+		bits.WriteBitsUnsafe(buf, pos, 1, 1)
+
+		err = writeEscapedValue(buf, pos, uint64(len(c.ConfigExtensions)-1), 2, 4, 8)
+		if err != nil {
+			return err
+		}
+
+		for _, ext := range c.ConfigExtensions {
+			err = writeEscapedValue(buf, pos, uint64(ext.Type), 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			err = writeEscapedValue(buf, pos, uint64(len(ext.Data)), 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			writeBytesToPos(buf, pos, ext.Data)
+		}
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+	}
+
+	return nil
+}
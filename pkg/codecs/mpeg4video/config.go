@@ -0,0 +1,41 @@
+package mpeg4video
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Config is a decoded MPEG-4 Video configuration,
+// composed by a visual object sequence header, a video object header
+// and a video object layer header.
+type Config struct {
+	ProfileAndLevelIndication uint8
+	VideoObjectLayer          VideoObjectLayer
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(config []byte) error {
+	if !bytes.HasPrefix(config, []byte{0, 0, 1, byte(VisualObjectSequenceStartCode)}) {
+		return fmt.Errorf("doesn't start with visual_object_sequence_start_code")
+	}
+
+	if len(config) < 5 {
+		return fmt.Errorf("profile_and_level_indication not found")
+	}
+
+	c.ProfileAndLevelIndication = config[4]
+
+	for i := 4; i < (len(config) - 4); i++ {
+		if bytes.Equal(config[i:i+3], []byte{0, 0, 1}) {
+			startCode := StartCode(config[i+3])
+
+			if startCode >= VideoObjectLayerStartCodeFirst && startCode <= VideoObjectLayerStartCodeLast {
+				return c.VideoObjectLayer.Unmarshal(config[i:])
+			}
+
+			i += 3
+		}
+	}
+
+	return fmt.Errorf("video object layer not found")
+}
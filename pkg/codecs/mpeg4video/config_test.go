@@ -0,0 +1,74 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConfig = []struct {
+	name string
+	byts []byte
+	conf Config
+}{
+	{
+		"1920x1080",
+		casesIsValidConfig[0].byts,
+		Config{
+			ProfileAndLevelIndication: 1,
+			VideoObjectLayer: VideoObjectLayer{
+				VideoObjectTypeIndication:  1,
+				VerID:                      1,
+				AspectRatioInfo:            1,
+				VOLControlParameters:       true,
+				ChromaFormat:               1,
+				LowDelay:                   true,
+				Shape:                      VideoObjectLayerShapeRectangular,
+				VOPTimeIncrementResolution: 30,
+				Width:                      1920,
+				Height:                     1080,
+			},
+		},
+	},
+	{
+		"384x288",
+		casesIsValidConfig[1].byts,
+		Config{
+			ProfileAndLevelIndication: 1,
+			VideoObjectLayer: VideoObjectLayer{
+				VideoObjectTypeIndication:  1,
+				VerID:                      1,
+				AspectRatioInfo:            1,
+				VOLControlParameters:       true,
+				ChromaFormat:               1,
+				LowDelay:                   true,
+				Shape:                      VideoObjectLayerShapeRectangular,
+				VOPTimeIncrementResolution: 25,
+				Width:                      384,
+				Height:                     288,
+			},
+		},
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.conf, conf)
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	for _, ca := range casesConfig {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var conf Config
+		conf.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,115 @@
+package mpeg4video
+
+import (
+	"fmt"
+)
+
+// DTSExtractor computes DTS from PTS.
+// When B-VOPs are present, an I-VOP, P-VOP or S-VOP is transmitted before the B-VOPs
+// that precede it in display order, therefore it is decoded when the previous one is displayed.
+// Reordering is enabled when the video object layer allows B-VOPs explicitly
+// (low_delay is zero), or when the first B-VOP is received.
+type DTSExtractor struct {
+	prevAnchorFilled bool
+	prevAnchorPTS    int64
+	prevDTSFilled    bool
+	prevDTS          int64
+	reordering       bool
+	pauseDTS         bool
+}
+
+// NewDTSExtractor allocates a DTSExtractor.
+func NewDTSExtractor() *DTSExtractor {
+	return &DTSExtractor{}
+}
+
+func (d *DTSExtractor) extractInner(frame []byte, pts int64) (int64, bool, error) {
+	var vop []byte
+
+outer:
+	for i := 0; i < (len(frame) - 3); i++ {
+		if frame[i] == 0 && frame[i+1] == 0 && frame[i+2] == 1 {
+			startCode := StartCode(frame[i+3])
+
+			switch {
+			case startCode >= VideoObjectLayerStartCodeFirst && startCode <= VideoObjectLayerStartCodeLast:
+				var vol VideoObjectLayer
+				err := vol.Unmarshal(frame[i:])
+				if err != nil {
+					return 0, false, fmt.Errorf("invalid video object layer: %w", err)
+				}
+
+				if vol.VOLControlParameters {
+					// B-VOPs are not allowed in low delay streams.
+					d.reordering = !vol.LowDelay
+				}
+
+			case startCode == VOPStartCode:
+				vop = frame[i:]
+				break outer
+			}
+
+			i += 2
+		}
+	}
+
+	if len(vop) < 5 {
+		return 0, false, fmt.Errorf("VOP not found")
+	}
+
+	isB := VOPCodingType(vop[4]>>6) == VOPCodingTypeB
+
+	if isB {
+		// this happens with the first B-VOPs of a stream that does not declare them,
+		// since the DTS of the previous anchor VOP is already equal to its PTS.
+		if !d.reordering {
+			d.reordering = true
+			d.pauseDTS = true
+		}
+
+		if d.pauseDTS && d.prevDTSFilled {
+			return d.prevDTS + 90, true, nil
+		}
+
+		return pts, false, nil
+	}
+
+	d.pauseDTS = false
+
+	dts := pts
+	if d.reordering && d.prevAnchorFilled {
+		dts = d.prevAnchorPTS
+	}
+
+	d.prevAnchorFilled = true
+	d.prevAnchorPTS = pts
+
+	// this happens with the second anchor VOP, since the DTS of the first one is equal to its PTS
+	if d.prevDTSFilled && dts <= d.prevDTS {
+		return d.prevDTS + 90, false, nil
+	}
+
+	return dts, false, nil
+}
+
+// Extract extracts the DTS of a frame.
+func (d *DTSExtractor) Extract(frame []byte, pts int64) (int64, error) {
+	dts, skipChecks, err := d.extractInner(frame, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if !skipChecks && dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTS = dts
+	d.prevDTSFilled = true
+
+	return dts, err
+}
@@ -0,0 +1,188 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	frame []byte
+	dts   int64
+	pts   int64
+}
+
+var (
+	testVOPI = []byte{0x00, 0x00, 0x01, 0xb6, 0x11, 0xe0}
+	testVOPP = []byte{0x00, 0x00, 0x01, 0xb6, 0x54, 0x18}
+	testVOPB = []byte{0x00, 0x00, 0x01, 0xb6, 0x94, 0x18}
+)
+
+// same as casesIsValidConfig[0], with low_delay set to zero.
+var testConfigBFrames = func() []byte {
+	buf := append([]byte(nil), casesIsValidConfig[0].byts...)
+	buf[22] &^= 0x80
+	return buf
+}()
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sample
+}{
+	{
+		"low delay",
+		[]sample{
+			{
+				append(append([]byte(nil), casesIsValidConfig[0].byts...), testVOPI...),
+				90000,
+				90000,
+			},
+			{
+				testVOPP,
+				93000,
+				93000,
+			},
+			{
+				testVOPP,
+				96000,
+				96000,
+			},
+		},
+	},
+	{
+		"no B-frames",
+		[]sample{
+			{
+				testVOPI,
+				90000,
+				90000,
+			},
+			{
+				testVOPP,
+				93000,
+				93000,
+			},
+			{
+				testVOPP,
+				96000,
+				96000,
+			},
+			{
+				testVOPP,
+				99000,
+				99000,
+			},
+		},
+	},
+	{
+		"B-frames declared in VOL",
+		[]sample{
+			{
+				append(append([]byte(nil), testConfigBFrames...), testVOPI...),
+				90000,
+				90000,
+			},
+			{
+				testVOPP,
+				90090,
+				99000,
+			},
+			{
+				testVOPB,
+				93000,
+				93000,
+			},
+			{
+				testVOPB,
+				96000,
+				96000,
+			},
+			{
+				testVOPP,
+				99000,
+				108000,
+			},
+			{
+				testVOPB,
+				102000,
+				102000,
+			},
+			{
+				testVOPB,
+				105000,
+				105000,
+			},
+		},
+	},
+	{
+		"B-frames not declared",
+		[]sample{
+			{
+				testVOPI,
+				90000,
+				90000,
+			},
+			{
+				testVOPP,
+				99000,
+				99000,
+			},
+			{
+				testVOPB,
+				99090,
+				93000,
+			},
+			{
+				testVOPB,
+				99180,
+				96000,
+			},
+			{
+				testVOPP,
+				99270,
+				108000,
+			},
+			{
+				testVOPB,
+				102000,
+				102000,
+			},
+			{
+				testVOPB,
+				105000,
+				105000,
+			},
+			{
+				testVOPP,
+				108000,
+				117000,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := NewDTSExtractor()
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.frame, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+
+func FuzzDTSExtractor(f *testing.F) {
+	for _, ca := range casesDTSExtractor {
+		for _, sample := range ca.sequence {
+			f.Add(sample.frame, sample.pts)
+		}
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte, pts int64) {
+		ex := NewDTSExtractor()
+		ex.Extract(b, pts) //nolint:errcheck
+	})
+}
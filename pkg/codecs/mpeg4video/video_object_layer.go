@@ -0,0 +1,302 @@
+package mpeg4video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// VideoObjectLayerShape is the shape of a video object layer.
+type VideoObjectLayerShape uint8
+
+// shapes.
+const (
+	VideoObjectLayerShapeRectangular VideoObjectLayerShape = 0
+	VideoObjectLayerShapeBinary      VideoObjectLayerShape = 1
+	VideoObjectLayerShapeBinaryOnly  VideoObjectLayerShape = 2
+	VideoObjectLayerShapeGrayscale   VideoObjectLayerShape = 3
+)
+
+const aspectRatioInfoExtendedPAR = 0x0F
+
+// VideoObjectLayer is a video object layer header.
+// Decoding stops after the interlaced flag.
+// Specification: ISO 14496-2, 6.2.3
+type VideoObjectLayer struct {
+	RandomAccessibleVOL        bool
+	VideoObjectTypeIndication  uint8
+	VerID                      uint8
+	AspectRatioInfo            uint8
+	PARWidth                   uint8 // used when AspectRatioInfo is extended_PAR
+	PARHeight                  uint8 // used when AspectRatioInfo is extended_PAR
+	VOLControlParameters       bool
+	ChromaFormat               uint8 // used when VOLControlParameters is true
+	LowDelay                   bool  // used when VOLControlParameters is true
+	Shape                      VideoObjectLayerShape
+	VOPTimeIncrementResolution uint16
+	FixedVOPRate               bool
+	FixedVOPTimeIncrement      uint16 // used when FixedVOPRate is true
+	Width                      int    // used when Shape is rectangular
+	Height                     int    // used when Shape is rectangular
+	Interlaced                 bool
+}
+
+func skipMarker(buf []byte, pos *int) error {
+	marker, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+	if !marker {
+		return fmt.Errorf("invalid marker bit")
+	}
+	return nil
+}
+
+func (l *VideoObjectLayer) skipVBVParameters(buf []byte, pos *int) error {
+	// first_half_bit_rate, latter_half_bit_rate, first_half_vbv_buffer_size
+	for i := 0; i < 3; i++ {
+		_, err := bits.ReadBits(buf, pos, 15)
+		if err != nil {
+			return err
+		}
+
+		err = skipMarker(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	// latter_half_vbv_buffer_size, first_half_vbv_occupancy
+	_, err := bits.ReadBits(buf, pos, 3+11)
+	if err != nil {
+		return err
+	}
+
+	err = skipMarker(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	// latter_half_vbv_occupancy
+	_, err = bits.ReadBits(buf, pos, 15)
+	if err != nil {
+		return err
+	}
+
+	return skipMarker(buf, pos)
+}
+
+// Unmarshal decodes a VideoObjectLayer.
+// The buffer must start with a video_object_layer_start_code.
+func (l *VideoObjectLayer) Unmarshal(buf []byte) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:3], []byte{0, 0, 1}) ||
+		StartCode(buf[3]) < VideoObjectLayerStartCodeFirst || StartCode(buf[3]) > VideoObjectLayerStartCodeLast {
+		return fmt.Errorf("video_object_layer_start_code not found")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 1+8+1)
+	if err != nil {
+		return err
+	}
+
+	l.RandomAccessibleVOL = bits.ReadFlagUnsafe(buf, &pos)
+	l.VideoObjectTypeIndication = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+
+	isObjectLayerIdentifier := bits.ReadFlagUnsafe(buf, &pos)
+
+	if isObjectLayerIdentifier {
+		tmp, err := bits.ReadBits(buf, &pos, 4+3)
+		if err != nil {
+			return err
+		}
+		l.VerID = uint8(tmp >> 3)
+	} else {
+		l.VerID = 1
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 4)
+	if err != nil {
+		return err
+	}
+	l.AspectRatioInfo = uint8(tmp)
+
+	if l.AspectRatioInfo == aspectRatioInfoExtendedPAR {
+		tmp, err = bits.ReadBits(buf, &pos, 16)
+		if err != nil {
+			return err
+		}
+		l.PARWidth = uint8(tmp >> 8)
+		l.PARHeight = uint8(tmp)
+	} else {
+		l.PARWidth = 0
+		l.PARHeight = 0
+	}
+
+	l.VOLControlParameters, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if l.VOLControlParameters {
+		tmp, err = bits.ReadBits(buf, &pos, 2)
+		if err != nil {
+			return err
+		}
+		l.ChromaFormat = uint8(tmp)
+
+		l.LowDelay, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		var vbvParameters bool
+		vbvParameters, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if vbvParameters {
+			err = l.skipVBVParameters(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		l.ChromaFormat = 0
+		l.LowDelay = false
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	l.Shape = VideoObjectLayerShape(tmp)
+
+	if l.Shape == VideoObjectLayerShapeGrayscale && l.VerID != 1 {
+		_, err = bits.ReadBits(buf, &pos, 4) // video_object_layer_shape_extension
+		if err != nil {
+			return err
+		}
+	}
+
+	err = skipMarker(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 16)
+	if err != nil {
+		return err
+	}
+	l.VOPTimeIncrementResolution = uint16(tmp)
+
+	if l.VOPTimeIncrementResolution == 0 {
+		return fmt.Errorf("invalid vop_time_increment_resolution")
+	}
+
+	err = skipMarker(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	l.FixedVOPRate, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if l.FixedVOPRate {
+		tmp, err = bits.ReadBits(buf, &pos, l.timeIncrementBits())
+		if err != nil {
+			return err
+		}
+		l.FixedVOPTimeIncrement = uint16(tmp)
+	} else {
+		l.FixedVOPTimeIncrement = 0
+	}
+
+	l.Width = 0
+	l.Height = 0
+	l.Interlaced = false
+
+	if l.Shape == VideoObjectLayerShapeBinaryOnly {
+		return nil
+	}
+
+	if l.Shape == VideoObjectLayerShapeRectangular {
+		err = skipMarker(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		tmp, err = bits.ReadBits(buf, &pos, 13)
+		if err != nil {
+			return err
+		}
+		l.Width = int(tmp)
+
+		err = skipMarker(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		tmp, err = bits.ReadBits(buf, &pos, 13)
+		if err != nil {
+			return err
+		}
+		l.Height = int(tmp)
+
+		err = skipMarker(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.Interlaced, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// number of bits used by vop_time_increment.
+func (l VideoObjectLayer) timeIncrementBits() int {
+	n := 1
+	for (1 << n) < int(l.VOPTimeIncrementResolution) {
+		n++
+	}
+	return n
+}
+
+// PixelAspectRatio returns the pixel aspect ratio.
+// Specification: ISO 14496-2, Table 6-12
+func (l VideoObjectLayer) PixelAspectRatio() (int, int) {
+	switch l.AspectRatioInfo {
+	case 2:
+		return 12, 11
+	case 3:
+		return 10, 11
+	case 4:
+		return 16, 11
+	case 5:
+		return 40, 33
+	case aspectRatioInfoExtendedPAR:
+		return int(l.PARWidth), int(l.PARHeight)
+	default:
+		return 1, 1
+	}
+}
+
+// FPS returns the frames per second of the video.
+// It is available only when the frame rate is fixed.
+func (l VideoObjectLayer) FPS() float64 {
+	if !l.FixedVOPRate || l.FixedVOPTimeIncrement == 0 {
+		return 0
+	}
+
+	return float64(l.VOPTimeIncrementResolution) / float64(l.FixedVOPTimeIncrement)
+}
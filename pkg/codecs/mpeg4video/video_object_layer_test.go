@@ -0,0 +1,65 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoObjectLayerFPS(t *testing.T) {
+	l := VideoObjectLayer{
+		VOPTimeIncrementResolution: 30000,
+		FixedVOPRate:               true,
+		FixedVOPTimeIncrement:      1001,
+	}
+	require.InDelta(t, 29.97, l.FPS(), 0.01)
+
+	l.FixedVOPRate = false
+	require.Equal(t, float64(0), l.FPS())
+}
+
+func TestVideoObjectLayerPixelAspectRatio(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		layer  VideoObjectLayer
+		width  int
+		height int
+	}{
+		{
+			"square",
+			VideoObjectLayer{AspectRatioInfo: 1},
+			1,
+			1,
+		},
+		{
+			"625-type 4:3",
+			VideoObjectLayer{AspectRatioInfo: 2},
+			12,
+			11,
+		},
+		{
+			"extended",
+			VideoObjectLayer{AspectRatioInfo: 0x0F, PARWidth: 64, PARHeight: 45},
+			64,
+			45,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			w, h := ca.layer.PixelAspectRatio()
+			require.Equal(t, ca.width, w)
+			require.Equal(t, ca.height, h)
+		})
+	}
+}
+
+func FuzzVideoObjectLayerUnmarshal(f *testing.F) {
+	f.Add([]byte{
+		0x00, 0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88,
+		0x00, 0xf5, 0x3c, 0x04, 0x87, 0x14, 0x43,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var l VideoObjectLayer
+		l.Unmarshal(b) //nolint:errcheck
+	})
+}
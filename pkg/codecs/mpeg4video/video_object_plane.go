@@ -0,0 +1,130 @@
+package mpeg4video
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// VOPCodingType is the coding type of a VOP.
+type VOPCodingType uint8
+
+// coding types.
+// Specification: ISO 14496-2, Table 6-20
+const (
+	VOPCodingTypeI VOPCodingType = 0
+	VOPCodingTypeP VOPCodingType = 1
+	VOPCodingTypeB VOPCodingType = 2
+	VOPCodingTypeS VOPCodingType = 3
+)
+
+// String implements fmt.Stringer.
+func (t VOPCodingType) String() string {
+	switch t {
+	case VOPCodingTypeI:
+		return "I"
+	case VOPCodingTypeP:
+		return "P"
+	case VOPCodingTypeB:
+		return "B"
+	default:
+		return "S"
+	}
+}
+
+// VideoObjectPlane is a VOP header.
+// Decoding stops after the vop_coded flag.
+// Specification: ISO 14496-2, 6.2.5
+type VideoObjectPlane struct {
+	CodingType VOPCodingType
+
+	// number of seconds elapsed since the last
+	// GOV header or the previous VOP in display order.
+	ModuloTimeBase int
+
+	// time increment in units of 1/VOPTimeIncrementResolution seconds.
+	TimeIncrement uint32
+
+	Coded bool
+}
+
+// Unmarshal decodes a VideoObjectPlane.
+// The buffer must start with a vop_start_code.
+// The VideoObjectLayer is needed to know the size of vop_time_increment.
+func (p *VideoObjectPlane) Unmarshal(buf []byte, vol *VideoObjectLayer) error {
+	if len(buf) < 4 || !bytes.Equal(buf[:4], []byte{0, 0, 1, byte(VOPStartCode)}) {
+		return fmt.Errorf("vop_start_code not found")
+	}
+
+	if vol.VOPTimeIncrementResolution == 0 {
+		return fmt.Errorf("invalid vop_time_increment_resolution")
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	tmp, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	p.CodingType = VOPCodingType(tmp)
+
+	p.ModuloTimeBase = 0
+
+	for {
+		var moduloTimeBase bool
+		moduloTimeBase, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if !moduloTimeBase {
+			break
+		}
+
+		p.ModuloTimeBase++
+	}
+
+	err = skipMarker(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, vol.timeIncrementBits())
+	if err != nil {
+		return err
+	}
+	p.TimeIncrement = uint32(tmp)
+
+	err = skipMarker(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.Coded, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindVOP returns the first VOP contained inside a frame, starting from its start code.
+func FindVOP(frame []byte) []byte {
+	i := bytes.Index(frame, []byte{0, 0, 1, byte(VOPStartCode)})
+	if i < 0 {
+		return nil
+	}
+	return frame[i:]
+}
+
+// IsRandomAccess checks whether a frame can be decoded independently,
+// that is, whether its first VOP is intra-coded.
+func IsRandomAccess(frame []byte) bool {
+	vop := FindVOP(frame)
+	if len(vop) < 5 {
+		return false
+	}
+	return VOPCodingType(vop[4]>>6) == VOPCodingTypeI
+}
@@ -0,0 +1,63 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVideoObjectPlane = []struct {
+	name string
+	byts []byte
+	vop  VideoObjectPlane
+}{
+	{
+		"i",
+		[]byte{0x00, 0x00, 0x01, 0xb6, 0x11, 0xe0},
+		VideoObjectPlane{
+			CodingType:    VOPCodingTypeI,
+			TimeIncrement: 3,
+			Coded:         true,
+		},
+	},
+	{
+		"p",
+		[]byte{0x00, 0x00, 0x01, 0xb6, 0x74, 0x18},
+		VideoObjectPlane{
+			CodingType:     VOPCodingTypeP,
+			ModuloTimeBase: 2,
+			Coded:          true,
+		},
+	},
+}
+
+func TestVideoObjectPlaneUnmarshal(t *testing.T) {
+	vol := VideoObjectLayer{VOPTimeIncrementResolution: 30}
+
+	for _, ca := range casesVideoObjectPlane {
+		t.Run(ca.name, func(t *testing.T) {
+			var vop VideoObjectPlane
+			err := vop.Unmarshal(ca.byts, &vol)
+			require.NoError(t, err)
+			require.Equal(t, ca.vop, vop)
+		})
+	}
+}
+
+func TestIsRandomAccess(t *testing.T) {
+	require.True(t, IsRandomAccess(append([]byte{0x00, 0x00, 0x01, 0xb3, 0x00, 0x10, 0x07},
+		casesVideoObjectPlane[0].byts...)))
+	require.False(t, IsRandomAccess(casesVideoObjectPlane[1].byts))
+	require.False(t, IsRandomAccess([]byte{0x00, 0x00, 0x01, 0xb3}))
+}
+
+func FuzzVideoObjectPlaneUnmarshal(f *testing.F) {
+	for _, ca := range casesVideoObjectPlane {
+		f.Add(ca.byts, uint16(30))
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte, res uint16) {
+		var vop VideoObjectPlane
+		vop.Unmarshal(b, &VideoObjectLayer{VOPTimeIncrementResolution: res}) //nolint:errcheck
+	})
+}
@@ -0,0 +1,109 @@
+package opus
+
+import (
+	"fmt"
+)
+
+// channel mappings of family 1 (Vorbis order), indexed by channel count - 3.
+var vorbisChannelMappings = []ChannelMapping{
+	{Family: 1, StreamCount: 2, CoupledCount: 1, Table: []uint8{0, 2, 1}},
+	{Family: 1, StreamCount: 2, CoupledCount: 2, Table: []uint8{0, 1, 2, 3}},
+	{Family: 1, StreamCount: 3, CoupledCount: 2, Table: []uint8{0, 4, 1, 2, 3}},
+	{Family: 1, StreamCount: 4, CoupledCount: 2, Table: []uint8{0, 4, 1, 2, 3, 5}},
+	{Family: 1, StreamCount: 4, CoupledCount: 3, Table: []uint8{0, 4, 1, 2, 3, 5, 6}},
+	{Family: 1, StreamCount: 5, CoupledCount: 3, Table: []uint8{0, 6, 1, 2, 3, 4, 5, 7}},
+}
+
+// ChannelMapping is the channel mapping of an Opus stream.
+// StreamCount, CoupledCount and Table are used only when Family is not zero.
+// Specification: RFC7845, 5.1.1
+type ChannelMapping struct {
+	Family       uint8
+	StreamCount  uint8
+	CoupledCount uint8
+	Table        []uint8
+}
+
+// DefaultChannelMapping returns the channel mapping that is used by default
+// with the given channel count, that is family 0 for mono and stereo
+// and family 1 for 3 to 8 channels.
+func DefaultChannelMapping(channelCount int) (*ChannelMapping, error) {
+	switch {
+	case channelCount >= 1 && channelCount <= 2:
+		return &ChannelMapping{}, nil
+
+	case channelCount >= 3 && channelCount <= 8:
+		m := vorbisChannelMappings[channelCount-3]
+		m.Table = append([]uint8(nil), m.Table...)
+		return &m, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported channel count: %d", channelCount)
+	}
+}
+
+// Equal checks whether two channel mappings are equal.
+func (m ChannelMapping) Equal(other ChannelMapping) bool {
+	if m.Family != other.Family {
+		return false
+	}
+
+	if m.Family == 0 {
+		return true
+	}
+
+	if m.StreamCount != other.StreamCount || m.CoupledCount != other.CoupledCount ||
+		len(m.Table) != len(other.Table) {
+		return false
+	}
+
+	for i, v := range m.Table {
+		if v != other.Table[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m ChannelMapping) validate(channelCount int) error {
+	if m.Family == 0 {
+		if channelCount < 1 || channelCount > 2 {
+			return fmt.Errorf("channel mapping family 0 requires 1 or 2 channels")
+		}
+		return nil
+	}
+
+	if channelCount < 1 {
+		return fmt.Errorf("invalid channel count")
+	}
+
+	if m.Family == 1 && channelCount > 8 {
+		return fmt.Errorf("channel mapping family 1 supports up to 8 channels")
+	}
+
+	if m.StreamCount == 0 {
+		return fmt.Errorf("invalid stream count")
+	}
+
+	if m.CoupledCount > m.StreamCount {
+		return fmt.Errorf("coupled count is greater than stream count")
+	}
+
+	if int(m.StreamCount)+int(m.CoupledCount) > 255 {
+		return fmt.Errorf("too many streams")
+	}
+
+	if len(m.Table) != channelCount {
+		return fmt.Errorf("channel mapping table size (%d) doesn't match channel count (%d)",
+			len(m.Table), channelCount)
+	}
+
+	for _, v := range m.Table {
+		if v != 255 && int(v) >= (int(m.StreamCount)+int(m.CoupledCount)) {
+			return fmt.Errorf("invalid channel mapping table entry: %d", v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package opus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultChannelMapping(t *testing.T) {
+	m, err := DefaultChannelMapping(2)
+	require.NoError(t, err)
+	require.Equal(t, &ChannelMapping{}, m)
+
+	m, err = DefaultChannelMapping(8)
+	require.NoError(t, err)
+	require.Equal(t, &ChannelMapping{
+		Family:       1,
+		StreamCount:  5,
+		CoupledCount: 3,
+		Table:        []uint8{0, 6, 1, 2, 3, 4, 5, 7},
+	}, m)
+
+	for ch := 1; ch <= 8; ch++ {
+		m, err = DefaultChannelMapping(ch)
+		require.NoError(t, err)
+		require.NoError(t, m.validate(ch))
+	}
+
+	_, err = DefaultChannelMapping(9)
+	require.EqualError(t, err, "unsupported channel count: 9")
+}
+
+func TestChannelMappingEqual(t *testing.T) {
+	m1, err := DefaultChannelMapping(6)
+	require.NoError(t, err)
+
+	m2, err := DefaultChannelMapping(6)
+	require.NoError(t, err)
+	require.True(t, m1.Equal(*m2))
+
+	m2.Table[0] = 1
+	require.False(t, m1.Equal(*m2))
+
+	require.True(t, ChannelMapping{}.Equal(ChannelMapping{StreamCount: 1}))
+}
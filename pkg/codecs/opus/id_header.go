@@ -0,0 +1,94 @@
+package opus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+var idHeaderMagic = []byte("OpusHead")
+
+// IDHeader is an Opus identification header (OpusHead).
+// Specification: RFC7845, 5.1
+type IDHeader struct {
+	Version         uint8
+	ChannelCount    int
+	PreSkip         uint16
+	InputSampleRate uint32
+	OutputGain      int16
+	ChannelMapping  ChannelMapping
+}
+
+// Unmarshal decodes an IDHeader.
+func (h *IDHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 19 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if !bytes.Equal(buf[:8], idHeaderMagic) {
+		return fmt.Errorf("invalid magic signature")
+	}
+
+	h.Version = buf[8]
+	if (h.Version >> 4) != 0 {
+		return fmt.Errorf("unsupported version: %d", h.Version)
+	}
+
+	h.ChannelCount = int(buf[9])
+	h.PreSkip = binary.LittleEndian.Uint16(buf[10:])
+	h.InputSampleRate = binary.LittleEndian.Uint32(buf[12:])
+	h.OutputGain = int16(binary.LittleEndian.Uint16(buf[16:]))
+	h.ChannelMapping = ChannelMapping{
+		Family: buf[18],
+	}
+
+	if h.ChannelMapping.Family != 0 {
+		if len(buf) < (21 + h.ChannelCount) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		h.ChannelMapping.StreamCount = buf[19]
+		h.ChannelMapping.CoupledCount = buf[20]
+		h.ChannelMapping.Table = buf[21 : 21+h.ChannelCount]
+	}
+
+	return h.ChannelMapping.validate(h.ChannelCount)
+}
+
+func (h IDHeader) marshalSize() int {
+	n := 19
+	if h.ChannelMapping.Family != 0 {
+		n += 2 + h.ChannelCount
+	}
+	return n
+}
+
+// Marshal encodes an IDHeader.
+func (h IDHeader) Marshal() ([]byte, error) {
+	if h.ChannelCount > 255 {
+		return nil, fmt.Errorf("invalid channel count")
+	}
+
+	err := h.ChannelMapping.validate(h.ChannelCount)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, h.marshalSize())
+
+	copy(buf, idHeaderMagic)
+	buf[8] = h.Version
+	buf[9] = uint8(h.ChannelCount)
+	binary.LittleEndian.PutUint16(buf[10:], h.PreSkip)
+	binary.LittleEndian.PutUint32(buf[12:], h.InputSampleRate)
+	binary.LittleEndian.PutUint16(buf[16:], uint16(h.OutputGain))
+	buf[18] = h.ChannelMapping.Family
+
+	if h.ChannelMapping.Family != 0 {
+		buf[19] = h.ChannelMapping.StreamCount
+		buf[20] = h.ChannelMapping.CoupledCount
+		copy(buf[21:], h.ChannelMapping.Table)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,147 @@
+package opus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesIDHeader = []struct {
+	name string
+	enc  []byte
+	dec  IDHeader
+}{
+	{
+		"stereo",
+		[]byte{
+			0x4f, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64,
+			0x01, 0x02, 0x38, 0x01, 0x80, 0xbb, 0x00, 0x00,
+			0x00, 0x00, 0x00,
+		},
+		IDHeader{
+			Version:         1,
+			ChannelCount:    2,
+			PreSkip:         312,
+			InputSampleRate: 48000,
+		},
+	},
+	{
+		"5.1",
+		[]byte{
+			0x4f, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64,
+			0x01, 0x06, 0x38, 0x01, 0x44, 0xac, 0x00, 0x00,
+			0x00, 0xff, 0x01, 0x04, 0x02, 0x00, 0x04, 0x01,
+			0x02, 0x03, 0x05,
+		},
+		IDHeader{
+			Version:         1,
+			ChannelCount:    6,
+			PreSkip:         312,
+			InputSampleRate: 44100,
+			OutputGain:      -256,
+			ChannelMapping: ChannelMapping{
+				Family:       1,
+				StreamCount:  4,
+				CoupledCount: 2,
+				Table:        []uint8{0, 4, 1, 2, 3, 5},
+			},
+		},
+	},
+	{
+		"discrete",
+		[]byte{
+			0x4f, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64,
+			0x01, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0xff, 0x03, 0x00, 0x00, 0x01, 0xff,
+		},
+		IDHeader{
+			Version:      1,
+			ChannelCount: 3,
+			ChannelMapping: ChannelMapping{
+				Family:      255,
+				StreamCount: 3,
+				Table:       []uint8{0, 1, 255},
+			},
+		},
+	},
+}
+
+func TestIDHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesIDHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec IDHeader
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestIDHeaderMarshal(t *testing.T) {
+	for _, ca := range casesIDHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestIDHeaderMarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		dec  IDHeader
+		err  string
+	}{
+		{
+			"family 0 with 6 channels",
+			IDHeader{ChannelCount: 6},
+			"channel mapping family 0 requires 1 or 2 channels",
+		},
+		{
+			"invalid table size",
+			IDHeader{
+				ChannelCount: 3,
+				ChannelMapping: ChannelMapping{
+					Family:       1,
+					StreamCount:  2,
+					CoupledCount: 1,
+					Table:        []uint8{0, 1},
+				},
+			},
+			"channel mapping table size (2) doesn't match channel count (3)",
+		},
+		{
+			"invalid table entry",
+			IDHeader{
+				ChannelCount: 3,
+				ChannelMapping: ChannelMapping{
+					Family:       1,
+					StreamCount:  2,
+					CoupledCount: 1,
+					Table:        []uint8{0, 1, 3},
+				},
+			},
+			"invalid channel mapping table entry: 3",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.dec.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzIDHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesIDHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h IDHeader
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,514 @@
+package opus
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxFrameSize is the maximum size of a frame.
+	MaxFrameSize = 1275
+
+	// MaxFramesPerPacket is the maximum number of frames contained in a packet.
+	MaxFramesPerPacket = 48
+
+	// maximum duration of a packet, in samples at 48khz (120ms).
+	maxPacketSamples = 5760
+)
+
+func readFrameLength(buf []byte) (int, int, error) {
+	if len(buf) == 0 {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] < 252 {
+		return int(buf[0]), 1, nil
+	}
+
+	if len(buf) < 2 {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	return int(buf[1])*4 + int(buf[0]), 2, nil
+}
+
+func frameLengthSize(le int) int {
+	if le < 252 {
+		return 1
+	}
+	return 2
+}
+
+func writeFrameLength(buf []byte, le int) int {
+	if le < 252 {
+		buf[0] = byte(le)
+		return 1
+	}
+
+	buf[0] = byte(252 + (le & 0x03))
+	buf[1] = byte((le - int(buf[0])) >> 2)
+	return 2
+}
+
+func paddingLengthSize(padding int) int {
+	if padding == 0 {
+		return 0
+	}
+	return (padding-1)/254 + 1
+}
+
+// Packet is an Opus packet.
+// Specification: RFC6716, 3
+type Packet struct {
+	TOC TOC
+
+	// whether frames have variable sizes (code 3 only).
+	VBR bool
+
+	// size of padding (code 3 only).
+	Padding int
+
+	Frames [][]byte
+}
+
+// Unmarshal decodes a Packet.
+func (p *Packet) Unmarshal(buf []byte) error {
+	_, err := p.unmarshal(buf, false)
+	return err
+}
+
+// UnmarshalSelfDelimited decodes a Packet in self-delimiting format.
+// It returns the number of consumed bytes.
+// Specification: RFC6716, Appendix B
+func (p *Packet) UnmarshalSelfDelimited(buf []byte) (int, error) {
+	return p.unmarshal(buf, true)
+}
+
+func (p *Packet) unmarshal(buf []byte, selfDelimited bool) (int, error) {
+	if len(buf) == 0 {
+		return 0, fmt.Errorf("packet is empty")
+	}
+
+	p.TOC.Unmarshal(buf[0])
+	p.VBR = false
+	p.Padding = 0
+	pos := 1
+
+	readLength := func() (int, error) {
+		le, n, err := readFrameLength(buf[pos:])
+		if err != nil {
+			return 0, err
+		}
+		pos += n
+		return le, nil
+	}
+
+	var sizes []int
+
+	switch p.TOC.FrameCountCode {
+	case 0:
+		if selfDelimited {
+			le, err := readLength()
+			if err != nil {
+				return 0, err
+			}
+			sizes = []int{le}
+		} else {
+			sizes = []int{len(buf) - pos}
+		}
+
+	case 1:
+		if selfDelimited {
+			le, err := readLength()
+			if err != nil {
+				return 0, err
+			}
+			sizes = []int{le, le}
+		} else {
+			if ((len(buf) - pos) % 2) != 0 {
+				return 0, fmt.Errorf("frames have different sizes")
+			}
+			le := (len(buf) - pos) / 2
+			sizes = []int{le, le}
+		}
+
+	case 2:
+		le1, err := readLength()
+		if err != nil {
+			return 0, err
+		}
+
+		var le2 int
+		if selfDelimited {
+			le2, err = readLength()
+			if err != nil {
+				return 0, err
+			}
+		} else {
+			le2 = len(buf) - pos - le1
+		}
+
+		sizes = []int{le1, le2}
+
+	default:
+		if len(buf) < 2 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+
+		p.VBR = (buf[1] & 0x80) != 0
+		hasPadding := (buf[1] & 0x40) != 0
+		frameCount := int(buf[1] & 0x3F)
+		pos++
+
+		if frameCount == 0 {
+			return 0, fmt.Errorf("invalid frame count")
+		}
+
+		if (frameCount * p.TOC.frameSize()) > maxPacketSamples {
+			return 0, fmt.Errorf("packet duration exceeds 120ms")
+		}
+
+		if hasPadding {
+			for {
+				if pos >= len(buf) {
+					return 0, fmt.Errorf("not enough bytes")
+				}
+
+				v := buf[pos]
+				pos++
+
+				if v != 255 {
+					p.Padding += int(v)
+					break
+				}
+				p.Padding += 254
+			}
+		}
+
+		sizes = make([]int, frameCount)
+
+		switch {
+		case p.VBR:
+			n := frameCount - 1
+			if selfDelimited {
+				n = frameCount
+			}
+
+			sum := 0
+			for i := 0; i < n; i++ {
+				le, err := readLength()
+				if err != nil {
+					return 0, err
+				}
+				sizes[i] = le
+				sum += le
+			}
+
+			if !selfDelimited {
+				sizes[frameCount-1] = len(buf) - pos - p.Padding - sum
+			}
+
+		case selfDelimited:
+			le, err := readLength()
+			if err != nil {
+				return 0, err
+			}
+
+			for i := range sizes {
+				sizes[i] = le
+			}
+
+		default:
+			rem := len(buf) - pos - p.Padding
+			if rem < 0 {
+				return 0, fmt.Errorf("not enough bytes")
+			}
+
+			if (rem % frameCount) != 0 {
+				return 0, fmt.Errorf("frames have different sizes")
+			}
+
+			for i := range sizes {
+				sizes[i] = rem / frameCount
+			}
+		}
+	}
+
+	p.Frames = make([][]byte, len(sizes))
+
+	for i, le := range sizes {
+		if le < 0 || (len(buf)-pos) < le {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+
+		if le > MaxFrameSize {
+			return 0, fmt.Errorf("frame size (%d) exceeds maximum (%d)", le, MaxFrameSize)
+		}
+
+		p.Frames[i] = buf[pos : pos+le]
+		pos += le
+	}
+
+	if (len(buf) - pos) < p.Padding {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+	pos += p.Padding
+
+	if !selfDelimited && pos != len(buf) {
+		return 0, fmt.Errorf("unexpected bytes after frames")
+	}
+
+	return pos, nil
+}
+
+func (p Packet) validate() error {
+	if len(p.Frames) == 0 {
+		return fmt.Errorf("packet contains no frames")
+	}
+
+	for _, frame := range p.Frames {
+		if len(frame) > MaxFrameSize {
+			return fmt.Errorf("frame size (%d) exceeds maximum (%d)", len(frame), MaxFrameSize)
+		}
+	}
+
+	if p.TOC.FrameCountCode != 3 && (p.VBR || p.Padding != 0) {
+		return fmt.Errorf("VBR and padding can be used with code 3 only")
+	}
+
+	if p.Padding < 0 {
+		return fmt.Errorf("invalid padding")
+	}
+
+	switch p.TOC.FrameCountCode {
+	case 0:
+		if len(p.Frames) != 1 {
+			return fmt.Errorf("code 0 requires a single frame")
+		}
+
+	case 1:
+		if len(p.Frames) != 2 || len(p.Frames[0]) != len(p.Frames[1]) {
+			return fmt.Errorf("code 1 requires two frames with equal size")
+		}
+
+	case 2:
+		if len(p.Frames) != 2 {
+			return fmt.Errorf("code 2 requires two frames")
+		}
+
+	default:
+		if len(p.Frames) > MaxFramesPerPacket {
+			return fmt.Errorf("frame count (%d) exceeds maximum (%d)", len(p.Frames), MaxFramesPerPacket)
+		}
+
+		if (len(p.Frames) * p.TOC.frameSize()) > maxPacketSamples {
+			return fmt.Errorf("packet duration exceeds 120ms")
+		}
+
+		if !p.VBR {
+			for _, frame := range p.Frames[1:] {
+				if len(frame) != len(p.Frames[0]) {
+					return fmt.Errorf("frames have different sizes and VBR is false")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lengths of frames that are explicitly written.
+func (p Packet) codedLengths(selfDelimited bool) []int {
+	switch p.TOC.FrameCountCode {
+	case 0, 1:
+		if selfDelimited {
+			return []int{len(p.Frames[0])}
+		}
+		return nil
+
+	case 2:
+		if selfDelimited {
+			return []int{len(p.Frames[0]), len(p.Frames[1])}
+		}
+		return []int{len(p.Frames[0])}
+
+	default:
+		if !p.VBR {
+			if selfDelimited {
+				return []int{len(p.Frames[0])}
+			}
+			return nil
+		}
+
+		n := len(p.Frames) - 1
+		if selfDelimited {
+			n = len(p.Frames)
+		}
+
+		ret := make([]int, n)
+		for i := range ret {
+			ret[i] = len(p.Frames[i])
+		}
+		return ret
+	}
+}
+
+// Marshal encodes a Packet.
+func (p Packet) Marshal() ([]byte, error) {
+	return p.marshal(false)
+}
+
+// MarshalSelfDelimited encodes a Packet in self-delimiting format.
+// Specification: RFC6716, Appendix B
+func (p Packet) MarshalSelfDelimited() ([]byte, error) {
+	return p.marshal(true)
+}
+
+func (p Packet) marshal(selfDelimited bool) ([]byte, error) {
+	err := p.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	lengths := p.codedLengths(selfDelimited)
+
+	n := 1
+	if p.TOC.FrameCountCode == 3 {
+		n++
+		if p.Padding != 0 {
+			n += paddingLengthSize(p.Padding) + p.Padding
+		}
+	}
+	for _, le := range lengths {
+		n += frameLengthSize(le)
+	}
+	for _, frame := range p.Frames {
+		n += len(frame)
+	}
+
+	buf := make([]byte, n)
+	buf[0] = p.TOC.Marshal()
+	pos := 1
+
+	if p.TOC.FrameCountCode == 3 {
+		buf[1] = byte(len(p.Frames))
+		if p.VBR {
+			buf[1] |= 0x80
+		}
+		pos++
+
+		if p.Padding != 0 {
+			buf[1] |= 0x40
+
+			rem := p.Padding
+			for rem > 254 {
+				buf[pos] = 255
+				pos++
+				rem -= 254
+			}
+			buf[pos] = byte(rem)
+			pos++
+		}
+	}
+
+	for _, le := range lengths {
+		pos += writeFrameLength(buf[pos:], le)
+	}
+
+	for _, frame := range p.Frames {
+		pos += copy(buf[pos:], frame)
+	}
+
+	// padding is left zeroed
+
+	return buf, nil
+}
+
+// Duration returns the duration of the packet.
+func (p Packet) Duration() time.Duration {
+	return time.Duration(len(p.Frames)) * p.TOC.FrameDuration()
+}
+
+// fillFrameCountCode picks the most compact frame count code.
+func (p *Packet) fillFrameCountCode() {
+	p.VBR = false
+	p.Padding = 0
+
+	switch {
+	case len(p.Frames) == 1:
+		p.TOC.FrameCountCode = 0
+
+	case len(p.Frames) == 2 && len(p.Frames[0]) == len(p.Frames[1]):
+		p.TOC.FrameCountCode = 1
+
+	case len(p.Frames) == 2:
+		p.TOC.FrameCountCode = 2
+
+	default:
+		p.TOC.FrameCountCode = 3
+		for _, frame := range p.Frames[1:] {
+			if len(frame) != len(p.Frames[0]) {
+				p.VBR = true
+				break
+			}
+		}
+	}
+}
+
+// Split splits a packet into packets containing a single frame each.
+// Padding is discarded.
+func (p Packet) Split() []*Packet {
+	ret := make([]*Packet, len(p.Frames))
+
+	for i, frame := range p.Frames {
+		ret[i] = &Packet{
+			TOC: TOC{
+				Config: p.TOC.Config,
+				Stereo: p.TOC.Stereo,
+			},
+			Frames: [][]byte{frame},
+		}
+	}
+
+	return ret
+}
+
+// MergePackets merges packets into a single packet.
+// Packets must share the same configuration and stereo flag.
+// Padding is discarded.
+func MergePackets(pkts []*Packet) (*Packet, error) {
+	if len(pkts) == 0 {
+		return nil, fmt.Errorf("no packets provided")
+	}
+
+	ret := &Packet{
+		TOC: TOC{
+			Config: pkts[0].TOC.Config,
+			Stereo: pkts[0].TOC.Stereo,
+		},
+	}
+
+	for _, pkt := range pkts {
+		if pkt.TOC.Config != ret.TOC.Config || pkt.TOC.Stereo != ret.TOC.Stereo {
+			return nil, fmt.Errorf("packets have different configurations")
+		}
+
+		ret.Frames = append(ret.Frames, pkt.Frames...)
+	}
+
+	if len(ret.Frames) == 0 {
+		return nil, fmt.Errorf("packets contain no frames")
+	}
+
+	if len(ret.Frames) > MaxFramesPerPacket {
+		return nil, fmt.Errorf("frame count (%d) exceeds maximum (%d)", len(ret.Frames), MaxFramesPerPacket)
+	}
+
+	if (len(ret.Frames) * ret.TOC.frameSize()) > maxPacketSamples {
+		return nil, fmt.Errorf("packet duration exceeds 120ms")
+	}
+
+	ret.fillFrameCountCode()
+
+	return ret, nil
+}
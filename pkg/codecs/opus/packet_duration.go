@@ -4,19 +4,9 @@ import (
 	"time"
 )
 
-var frameSizes = [32]int{
-	480, 960, 1920, 2880, // Silk NB
-	480, 960, 1920, 2880, // Silk MB
-	480, 960, 1920, 2880, // Silk WB
-	480, 960, // Hybrid SWB
-	480, 960, // Hybrid FB
-	120, 240, 480, 960, // CELT NB
-	120, 240, 480, 960, // CELT NB
-	120, 240, 480, 960, // CELT NB
-	120, 240, 480, 960, // CELT NB
-}
-
 // PacketDuration returns the duration of an Opus packet.
+// It only reads the TOC byte and the frame count, without validating the packet.
+// Use Packet to validate a packet and extract frames.
 // Specification: RFC6716, 3.1
 func PacketDuration(pkt []byte) time.Duration {
 	if len(pkt) == 0 {
@@ -0,0 +1,348 @@
+package opus
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPacket = []struct {
+	name string
+	enc  []byte
+	dec  Packet
+}{
+	{
+		"code 0",
+		[]byte{0xfc, 0x01, 0x02, 0x03},
+		Packet{
+			TOC: TOC{
+				Config: 31,
+				Stereo: true,
+			},
+			Frames: [][]byte{{0x01, 0x02, 0x03}},
+		},
+	},
+	{
+		"code 1",
+		[]byte{0x01, 0x01, 0x02, 0x03, 0x04},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 1,
+			},
+			Frames: [][]byte{{0x01, 0x02}, {0x03, 0x04}},
+		},
+	},
+	{
+		"code 2",
+		[]byte{0x02, 0x01, 0xaa, 0xbb, 0xcc},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 2,
+			},
+			Frames: [][]byte{{0xaa}, {0xbb, 0xcc}},
+		},
+	},
+	{
+		"code 2, long frame",
+		append([]byte{0x02, 0xfc, 0x0c}, bytes.Repeat([]byte{0x01}, 301)...),
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 2,
+			},
+			Frames: [][]byte{bytes.Repeat([]byte{0x01}, 300), {0x01}},
+		},
+	},
+	{
+		"code 3 cbr",
+		[]byte{0x03, 0x03, 0x01, 0x02, 0x03},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 3,
+			},
+			Frames: [][]byte{{0x01}, {0x02}, {0x03}},
+		},
+	},
+	{
+		"code 3 vbr with padding",
+		[]byte{0x03, 0xc2, 0x02, 0x01, 0xaa, 0xbb, 0xcc, 0x00, 0x00},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 3,
+			},
+			VBR:     true,
+			Padding: 2,
+			Frames:  [][]byte{{0xaa}, {0xbb, 0xcc}},
+		},
+	},
+	{
+		"code 3 long padding",
+		append([]byte{0x83, 0x41, 0xff, 0x01, 0xaa}, make([]byte, 255)...),
+		Packet{
+			TOC: TOC{
+				Config:         16,
+				FrameCountCode: 3,
+			},
+			Padding: 255,
+			Frames:  [][]byte{{0xaa}},
+		},
+	},
+}
+
+var casesPacketSelfDelimited = []struct {
+	name string
+	enc  []byte
+	dec  Packet
+}{
+	{
+		"code 0",
+		[]byte{0xfc, 0x03, 0x01, 0x02, 0x03},
+		Packet{
+			TOC: TOC{
+				Config: 31,
+				Stereo: true,
+			},
+			Frames: [][]byte{{0x01, 0x02, 0x03}},
+		},
+	},
+	{
+		"code 1",
+		[]byte{0x01, 0x02, 0x01, 0x02, 0x03, 0x04},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 1,
+			},
+			Frames: [][]byte{{0x01, 0x02}, {0x03, 0x04}},
+		},
+	},
+	{
+		"code 2",
+		[]byte{0x02, 0x01, 0x02, 0xaa, 0xbb, 0xcc},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 2,
+			},
+			Frames: [][]byte{{0xaa}, {0xbb, 0xcc}},
+		},
+	},
+	{
+		"code 3 cbr",
+		[]byte{0x03, 0x03, 0x01, 0x01, 0x02, 0x03},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 3,
+			},
+			Frames: [][]byte{{0x01}, {0x02}, {0x03}},
+		},
+	},
+	{
+		"code 3 vbr with padding",
+		[]byte{0x03, 0xc2, 0x02, 0x01, 0x02, 0xaa, 0xbb, 0xcc, 0x00, 0x00},
+		Packet{
+			TOC: TOC{
+				FrameCountCode: 3,
+			},
+			VBR:     true,
+			Padding: 2,
+			Frames:  [][]byte{{0xaa}, {0xbb, 0xcc}},
+		},
+	},
+}
+
+func TestPacketUnmarshal(t *testing.T) {
+	for _, ca := range casesPacket {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Packet
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestPacketUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"packet is empty",
+		},
+		{
+			"code 1 odd size",
+			[]byte{0x01, 0x01, 0x02, 0x03},
+			"frames have different sizes",
+		},
+		{
+			"code 2 invalid length",
+			[]byte{0x02, 0x05, 0x01},
+			"not enough bytes",
+		},
+		{
+			"code 3 zero frames",
+			[]byte{0x03, 0x00},
+			"invalid frame count",
+		},
+		{
+			"code 3 too long",
+			[]byte{0x1b, 0x03, 0x01, 0x02, 0x03},
+			"packet duration exceeds 120ms",
+		},
+		{
+			"code 3 padding too big",
+			[]byte{0x03, 0x41, 0x05, 0x01},
+			"not enough bytes",
+		},
+		{
+			"frame too big",
+			append([]byte{0x00}, make([]byte, 1276)...),
+			"frame size (1276) exceeds maximum (1275)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Packet
+			err := dec.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestPacketMarshal(t *testing.T) {
+	for _, ca := range casesPacket {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestPacketMarshalPadding(t *testing.T) {
+	for _, padding := range []int{1, 253, 254, 255, 508} {
+		t.Run(strconv.Itoa(padding), func(t *testing.T) {
+			p := Packet{
+				TOC: TOC{
+					Config:         1,
+					FrameCountCode: 3,
+				},
+				Padding: padding,
+				Frames:  [][]byte{{1, 2, 3}},
+			}
+
+			enc, err := p.Marshal()
+			require.NoError(t, err)
+
+			var dec Packet
+			err = dec.Unmarshal(enc)
+			require.NoError(t, err)
+			require.Equal(t, p, dec)
+		})
+	}
+}
+
+func TestPacketUnmarshalSelfDelimited(t *testing.T) {
+	for _, ca := range casesPacketSelfDelimited {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Packet
+			n, err := dec.UnmarshalSelfDelimited(append(ca.enc, 0x01, 0x02))
+			require.NoError(t, err)
+			require.Equal(t, len(ca.enc), n)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestPacketMarshalSelfDelimited(t *testing.T) {
+	for _, ca := range casesPacketSelfDelimited {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.MarshalSelfDelimited()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestPacketDurationMethod(t *testing.T) {
+	pkt := Packet{
+		TOC: TOC{
+			Config:         16,
+			FrameCountCode: 3,
+		},
+		Frames: [][]byte{{0x01}, {0x02}, {0x03}},
+	}
+	require.Equal(t, 7500*time.Microsecond, pkt.Duration())
+}
+
+func TestPacketSplitMerge(t *testing.T) {
+	var pkt Packet
+	err := pkt.Unmarshal([]byte{0x03, 0xc3, 0x02, 0x01, 0x02, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x00, 0x00})
+	require.NoError(t, err)
+
+	pkts := pkt.Split()
+	require.Equal(t, []*Packet{
+		{Frames: [][]byte{{0xaa}}},
+		{Frames: [][]byte{{0xbb, 0xcc}}},
+		{Frames: [][]byte{{0xdd, 0xee, 0xff}}},
+	}, pkts)
+
+	merged, err := MergePackets(pkts[:2])
+	require.NoError(t, err)
+	require.Equal(t, &Packet{
+		TOC: TOC{
+			FrameCountCode: 2,
+		},
+		Frames: [][]byte{{0xaa}, {0xbb, 0xcc}},
+	}, merged)
+
+	merged, err = MergePackets(pkts)
+	require.NoError(t, err)
+
+	enc, err := merged.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x03, 0x83, 0x01, 0x02, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}, enc)
+
+	_, err = MergePackets([]*Packet{
+		{Frames: [][]byte{{0x01}}},
+		{TOC: TOC{Stereo: true}, Frames: [][]byte{{0x01}}},
+	})
+	require.EqualError(t, err, "packets have different configurations")
+
+	_, err = MergePackets([]*Packet{
+		{TOC: TOC{Config: 3}, Frames: [][]byte{{0x01}, {0x01}}},
+		{TOC: TOC{Config: 3}, Frames: [][]byte{{0x01}}},
+	})
+	require.EqualError(t, err, "packet duration exceeds 120ms")
+}
+
+func FuzzPacketUnmarshal(f *testing.F) {
+	for _, ca := range casesPacket {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pkt Packet
+		err := pkt.Unmarshal(b)
+		if err == nil {
+			pkt.Marshal() //nolint:errcheck
+		}
+	})
+}
+
+func FuzzPacketUnmarshalSelfDelimited(f *testing.F) {
+	for _, ca := range casesPacketSelfDelimited {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pkt Packet
+		_, err := pkt.UnmarshalSelfDelimited(b)
+		if err == nil {
+			pkt.MarshalSelfDelimited() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package opus
+
+import (
+	"time"
+)
+
+// Mode is the coding mode of an Opus frame.
+type Mode int
+
+// modes.
+const (
+	ModeSILK Mode = iota
+	ModeHybrid
+	ModeCELT
+)
+
+// Bandwidth is the audio bandwidth of an Opus frame.
+type Bandwidth int
+
+// bandwidths.
+const (
+	BandwidthNarrowband Bandwidth = iota
+	BandwidthMediumband
+	BandwidthWideband
+	BandwidthSuperWideband
+	BandwidthFullband
+)
+
+// number of samples of a frame at 48khz.
+var frameSizes = [32]int{
+	480, 960, 1920, 2880, // SILK NB
+	480, 960, 1920, 2880, // SILK MB
+	480, 960, 1920, 2880, // SILK WB
+	480, 960, // Hybrid SWB
+	480, 960, // Hybrid FB
+	120, 240, 480, 960, // CELT NB
+	120, 240, 480, 960, // CELT WB
+	120, 240, 480, 960, // CELT SWB
+	120, 240, 480, 960, // CELT FB
+}
+
+// TOC is the table-of-contents byte of an Opus packet.
+// Specification: RFC6716, 3.1
+type TOC struct {
+	Config         uint8
+	Stereo         bool
+	FrameCountCode uint8
+}
+
+// Unmarshal decodes a TOC.
+func (t *TOC) Unmarshal(b byte) {
+	t.Config = b >> 3
+	t.Stereo = ((b >> 2) & 0x01) != 0
+	t.FrameCountCode = b & 0x03
+}
+
+// Marshal encodes a TOC.
+func (t TOC) Marshal() byte {
+	b := (t.Config&0x1F)<<3 | (t.FrameCountCode & 0x03)
+	if t.Stereo {
+		b |= 1 << 2
+	}
+	return b
+}
+
+// Mode returns the coding mode.
+func (t TOC) Mode() Mode {
+	switch {
+	case t.Config < 12:
+		return ModeSILK
+	case t.Config < 16:
+		return ModeHybrid
+	default:
+		return ModeCELT
+	}
+}
+
+// Bandwidth returns the audio bandwidth.
+func (t TOC) Bandwidth() Bandwidth {
+	switch {
+	case t.Config < 12:
+		return Bandwidth(t.Config / 4)
+	case t.Config < 16:
+		return BandwidthSuperWideband + Bandwidth((t.Config-12)/2)
+	case t.Config < 20:
+		return BandwidthNarrowband
+	default:
+		return BandwidthWideband + Bandwidth((t.Config-20)/4)
+	}
+}
+
+func (t TOC) frameSize() int {
+	return frameSizes[t.Config&0x1F]
+}
+
+// FrameDuration returns the duration of each frame.
+func (t TOC) FrameDuration() time.Duration {
+	return time.Duration(t.frameSize()) * time.Second / 48000
+}
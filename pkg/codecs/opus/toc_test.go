@@ -0,0 +1,78 @@
+package opus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOC(t *testing.T) {
+	for _, ca := range []struct {
+		name          string
+		byt           byte
+		toc           TOC
+		mode          Mode
+		bandwidth     Bandwidth
+		frameDuration time.Duration
+	}{
+		{
+			"silk nb 10ms",
+			0x00,
+			TOC{},
+			ModeSILK,
+			BandwidthNarrowband,
+			10 * time.Millisecond,
+		},
+		{
+			"silk wb 60ms stereo",
+			0x5d,
+			TOC{Config: 11, Stereo: true, FrameCountCode: 1},
+			ModeSILK,
+			BandwidthWideband,
+			60 * time.Millisecond,
+		},
+		{
+			"hybrid fb 20ms",
+			0x7a,
+			TOC{Config: 15, FrameCountCode: 2},
+			ModeHybrid,
+			BandwidthFullband,
+			20 * time.Millisecond,
+		},
+		{
+			"celt nb 2.5ms",
+			0x83,
+			TOC{Config: 16, FrameCountCode: 3},
+			ModeCELT,
+			BandwidthNarrowband,
+			2500 * time.Microsecond,
+		},
+		{
+			"celt swb 5ms",
+			0xc8,
+			TOC{Config: 25},
+			ModeCELT,
+			BandwidthSuperWideband,
+			5 * time.Millisecond,
+		},
+		{
+			"celt fb 20ms stereo",
+			0xfc,
+			TOC{Config: 31, Stereo: true},
+			ModeCELT,
+			BandwidthFullband,
+			20 * time.Millisecond,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var toc TOC
+			toc.Unmarshal(ca.byt)
+			require.Equal(t, ca.toc, toc)
+			require.Equal(t, ca.byt, toc.Marshal())
+			require.Equal(t, ca.mode, toc.Mode())
+			require.Equal(t, ca.bandwidth, toc.Bandwidth())
+			require.Equal(t, ca.frameDuration, toc.FrameDuration())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package vp9
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+type frameFlags struct {
+	showExistingFrame bool
+	nonKeyFrame       bool
+	showFrame         bool
+}
+
+// unmarshal decodes the first fields of the uncompressed header only,
+// without parsing the whole header.
+func (f *frameFlags) unmarshal(buf []byte) error {
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	frameMarker := bits.ReadBitsUnsafe(buf, &pos, 2)
+	if frameMarker != 2 {
+		return fmt.Errorf("invalid frame marker")
+	}
+
+	profileLowBit := uint8(bits.ReadBitsUnsafe(buf, &pos, 1))
+	profileHighBit := uint8(bits.ReadBitsUnsafe(buf, &pos, 1))
+	profile := profileHighBit<<1 + profileLowBit
+
+	if profile == 3 {
+		pos++
+	}
+
+	f.showExistingFrame, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if f.showExistingFrame {
+		return nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	f.nonKeyFrame = bits.ReadFlagUnsafe(buf, &pos)
+	f.showFrame = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
+
+// ContainsKeyFrame checks whether the frames of a superframe contain a key frame.
+func ContainsKeyFrame(frames [][]byte) (bool, error) {
+	if len(frames) == 0 {
+		return false, fmt.Errorf("superframe is empty")
+	}
+
+	for _, frame := range frames {
+		var f frameFlags
+		err := f.unmarshal(frame)
+		if err != nil {
+			return false, err
+		}
+
+		if !f.showExistingFrame && !f.nonKeyFrame {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ContainsShownFrame checks whether the frames of a superframe contain a frame that is meant to be displayed.
+// Superframes that contain only hidden frames (i.e. alt-ref frames) do not advance the presentation time.
+func ContainsShownFrame(frames [][]byte) (bool, error) {
+	if len(frames) == 0 {
+		return false, fmt.Errorf("superframe is empty")
+	}
+
+	for _, frame := range frames {
+		var f frameFlags
+		err := f.unmarshal(frame)
+		if err != nil {
+			return false, err
+		}
+
+		if f.showExistingFrame || f.showFrame {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
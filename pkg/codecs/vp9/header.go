@@ -6,11 +6,107 @@ import (
 	"github.com/bluenviron/mediacommon/pkg/bits"
 )
 
+const (
+	numRefFrames    = 8
+	maxSegments     = 8
+	segLvlMax       = 4
+	minTileWidthB64 = 4
+	maxTileWidthB64 = 64
+	frameSyncByte0  = 0x49
+	frameSyncByte1  = 0x83
+	frameSyncByte2  = 0x42
+)
+
+var segmentationFeatureBits = [segLvlMax]int{8, 6, 2, 0}
+
+var segmentationFeatureSigned = [segLvlMax]bool{true, true, false, false}
+
+// ColorSpace is a color space.
+// Specification: VP9 Bitstream & Decoding Process Specification, 7.2.2
+type ColorSpace uint8
+
+// color spaces.
+const (
+	ColorSpaceUnknown   ColorSpace = 0
+	ColorSpaceBT601     ColorSpace = 1
+	ColorSpaceBT709     ColorSpace = 2
+	ColorSpaceSMPTE170  ColorSpace = 3
+	ColorSpaceSMPTE240  ColorSpace = 4
+	ColorSpaceBT2020    ColorSpace = 5
+	ColorSpaceReserved2 ColorSpace = 6
+	ColorSpaceRGB       ColorSpace = 7
+)
+
+// su(n)
+func readSigned(buf []byte, pos *int, n int) (int8, error) {
+	err := bits.HasSpace(buf, *pos, n+1)
+	if err != nil {
+		return 0, err
+	}
+
+	v := int8(bits.ReadBitsUnsafe(buf, pos, n))
+	if bits.ReadFlagUnsafe(buf, pos) {
+		return -v, nil
+	}
+	return v, nil
+}
+
+func readDeltaQ(buf []byte, pos *int) (int8, error) {
+	deltaCoded, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if !deltaCoded {
+		return 0, nil
+	}
+
+	return readSigned(buf, pos, 4)
+}
+
+func readProb(buf []byte, pos *int) (uint8, error) {
+	probCoded, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if !probCoded {
+		return 255, nil
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(tmp), nil
+}
+
+func readFrameSyncCode(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 24)
+	if err != nil {
+		return err
+	}
+
+	if uint8(bits.ReadBitsUnsafe(buf, pos, 8)) != frameSyncByte0 {
+		return fmt.Errorf("wrong frame_sync_byte_0")
+	}
+
+	if uint8(bits.ReadBitsUnsafe(buf, pos, 8)) != frameSyncByte1 {
+		return fmt.Errorf("wrong frame_sync_byte_1")
+	}
+
+	if uint8(bits.ReadBitsUnsafe(buf, pos, 8)) != frameSyncByte2 {
+		return fmt.Errorf("wrong frame_sync_byte_2")
+	}
+
+	return nil
+}
+
 // Header_ColorConfig is the color_config member of an header.
 type Header_ColorConfig struct { //nolint:revive
 	TenOrTwelveBit bool
 	BitDepth       uint8
-	ColorSpace     uint8
+	ColorSpace     ColorSpace
 	ColorRange     bool
 	SubsamplingX   bool
 	SubsamplingY   bool
@@ -37,9 +133,9 @@ func (c *Header_ColorConfig) unmarshal(profile uint8, buf []byte, pos *int) erro
 	if err != nil {
 		return err
 	}
-	c.ColorSpace = uint8(tmp)
+	c.ColorSpace = ColorSpace(tmp)
 
-	if c.ColorSpace != 7 {
+	if c.ColorSpace != ColorSpaceRGB {
 		var err error
 		c.ColorRange, err = bits.ReadFlag(buf, pos)
 		if err != nil {
@@ -94,6 +190,296 @@ func (s *Header_FrameSize) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+// Header_RenderSize is the render_size member of an header.
+type Header_RenderSize struct { //nolint:revive
+	RenderWidthMinus1  uint16
+	RenderHeightMinus1 uint16
+}
+
+func (s *Header_RenderSize) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 32)
+	if err != nil {
+		return err
+	}
+
+	s.RenderWidthMinus1 = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	s.RenderHeightMinus1 = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	return nil
+}
+
+// Header_LoopFilterParams is the loop_filter_params member of an header.
+type Header_LoopFilterParams struct { //nolint:revive
+	LoopFilterLevel        uint8
+	LoopFilterSharpness    uint8
+	LoopFilterDeltaEnabled bool
+	LoopFilterDeltaUpdate  bool
+	UpdateRefDelta         [4]bool
+	LoopFilterRefDeltas    [4]int8
+	UpdateModeDelta        [2]bool
+	LoopFilterModeDeltas   [2]int8
+}
+
+func (p *Header_LoopFilterParams) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 10)
+	if err != nil {
+		return err
+	}
+
+	p.LoopFilterLevel = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+	p.LoopFilterSharpness = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	p.LoopFilterDeltaEnabled = bits.ReadFlagUnsafe(buf, pos)
+
+	if p.LoopFilterDeltaEnabled {
+		p.LoopFilterDeltaUpdate, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if p.LoopFilterDeltaUpdate {
+			for i := 0; i < 4; i++ {
+				p.UpdateRefDelta[i], err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+
+				if p.UpdateRefDelta[i] {
+					p.LoopFilterRefDeltas[i], err = readSigned(buf, pos, 6)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			for i := 0; i < 2; i++ {
+				p.UpdateModeDelta[i], err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+
+				if p.UpdateModeDelta[i] {
+					p.LoopFilterModeDeltas[i], err = readSigned(buf, pos, 6)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Header_QuantizationParams is the quantization_params member of an header.
+type Header_QuantizationParams struct { //nolint:revive
+	BaseQIdx   uint8
+	DeltaQYDc  int8
+	DeltaQUVDc int8
+	DeltaQUVAc int8
+}
+
+func (p *Header_QuantizationParams) unmarshal(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+	p.BaseQIdx = uint8(tmp)
+
+	p.DeltaQYDc, err = readDeltaQ(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	p.DeltaQUVDc, err = readDeltaQ(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	p.DeltaQUVAc, err = readDeltaQ(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Header_SegmentationParams is the segmentation_params member of an header.
+type Header_SegmentationParams struct { //nolint:revive
+	SegmentationEnabled          bool
+	SegmentationUpdateMap        bool
+	SegmentationTreeProbs        [7]uint8
+	SegmentationTemporalUpdate   bool
+	SegmentationPredProbs        [3]uint8
+	SegmentationUpdateData       bool
+	SegmentationAbsOrDeltaUpdate bool
+	FeatureEnabled               [maxSegments][segLvlMax]bool
+	FeatureData                  [maxSegments][segLvlMax]int16
+}
+
+func (p *Header_SegmentationParams) unmarshal(buf []byte, pos *int) error {
+	var err error
+	p.SegmentationEnabled, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !p.SegmentationEnabled {
+		return nil
+	}
+
+	p.SegmentationUpdateMap, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if p.SegmentationUpdateMap {
+		for i := 0; i < 7; i++ {
+			p.SegmentationTreeProbs[i], err = readProb(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		p.SegmentationTemporalUpdate, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < 3; i++ {
+			if p.SegmentationTemporalUpdate {
+				p.SegmentationPredProbs[i], err = readProb(buf, pos)
+				if err != nil {
+					return err
+				}
+			} else {
+				p.SegmentationPredProbs[i] = 255
+			}
+		}
+	}
+
+	p.SegmentationUpdateData, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if p.SegmentationUpdateData {
+		p.SegmentationAbsOrDeltaUpdate, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < maxSegments; i++ {
+			for j := 0; j < segLvlMax; j++ {
+				p.FeatureEnabled[i][j], err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+
+				if p.FeatureEnabled[i][j] {
+					n := segmentationFeatureBits[j]
+
+					// SEG_LVL_SKIP has no data
+					if n != 0 {
+						var tmp uint64
+						tmp, err = bits.ReadBits(buf, pos, n)
+						if err != nil {
+							return err
+						}
+						p.FeatureData[i][j] = int16(tmp)
+					}
+
+					if segmentationFeatureSigned[j] {
+						var sign bool
+						sign, err = bits.ReadFlag(buf, pos)
+						if err != nil {
+							return err
+						}
+
+						if sign {
+							p.FeatureData[i][j] = -p.FeatureData[i][j]
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Header_TileInfo is the tile_info member of an header.
+type Header_TileInfo struct { //nolint:revive
+	TileColsLog2 uint8
+	TileRowsLog2 uint8
+}
+
+func (t *Header_TileInfo) unmarshal(frameWidth int, buf []byte, pos *int) error {
+	miCols := (frameWidth + 7) >> 3
+	sb64Cols := (miCols + 7) >> 3
+
+	minLog2 := uint8(0)
+	for (maxTileWidthB64 << minLog2) < sb64Cols {
+		minLog2++
+	}
+
+	maxLog2 := uint8(1)
+	for (sb64Cols >> maxLog2) >= minTileWidthB64 {
+		maxLog2++
+	}
+	maxLog2--
+
+	t.TileColsLog2 = minLog2
+
+	for t.TileColsLog2 < maxLog2 {
+		increment, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if !increment {
+			break
+		}
+		t.TileColsLog2++
+	}
+
+	tmp, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if tmp {
+		t.TileRowsLog2 = 1
+
+		tmp, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if tmp {
+			t.TileRowsLog2++
+		}
+	}
+
+	return nil
+}
+
+// RefFrameSizes contains the size of each reference frame slot.
+// It allows to decode the size of frames that inherit it from a reference frame.
+type RefFrameSizes [numRefFrames]*Header_FrameSize
+
+// Update updates reference frame sizes with the ones of a decoded frame.
+func (r *RefFrameSizes) Update(h *Header) {
+	if h.FrameSize == nil {
+		return
+	}
+
+	for i := 0; i < numRefFrames; i++ {
+		if ((h.RefreshFrameFlags >> i) & 0x01) != 0 {
+			fs := *h.FrameSize
+			r[i] = &fs
+		}
+	}
+}
+
 // Header is a VP9 Frame header.
 // Specification:
 // https://storage.googleapis.com/downloads.webmproject.org/docs/vp9/vp9-bitstream-specification-v0.6-20160331-draft.pdf
@@ -106,10 +492,44 @@ type Header struct {
 	ErrorResilientMode bool
 	ColorConfig        *Header_ColorConfig
 	FrameSize          *Header_FrameSize
+
+	IntraOnly                 bool
+	ResetFrameContext         uint8
+	RefreshFrameFlags         uint8
+	RefFrameIdx               [3]uint8
+	RefFrameSignBias          [3]bool
+	FoundRef                  [3]bool
+	RenderSize                *Header_RenderSize
+	AllowHighPrecisionMV      bool
+	IsFilterSwitchable        bool
+	RawInterpolationFilter    uint8
+	RefreshFrameContext       bool
+	FrameParallelDecodingMode bool
+	FrameContextIdx           uint8
+	LoopFilterParams          *Header_LoopFilterParams
+	QuantizationParams        *Header_QuantizationParams
+	SegmentationParams        *Header_SegmentationParams
+	TileInfo                  *Header_TileInfo
+	HeaderSizeInBytes         uint16
 }
 
 // Unmarshal decodes a Header.
+//
+// When the frame size is inherited from a reference frame, it cannot be known
+// without decoding previous frames; in this case FrameSize, TileInfo and
+// HeaderSizeInBytes are left empty. Use UnmarshalWithRefs to decode them too.
 func (h *Header) Unmarshal(buf []byte) error {
+	return h.unmarshal(buf, nil)
+}
+
+// UnmarshalWithRefs decodes a Header, using sizes of reference frames
+// to fill the size of frames that inherit it.
+func (h *Header) UnmarshalWithRefs(buf []byte, refs *RefFrameSizes) error {
+	return h.unmarshal(buf, refs)
+}
+
+func (h *Header) unmarshal(buf []byte, refs *RefFrameSizes) error {
+	*h = Header{}
 	pos := 0
 
 	err := bits.HasSpace(buf, pos, 4)
@@ -158,35 +578,188 @@ func (h *Header) Unmarshal(buf []byte) error {
 	h.ShowFrame = bits.ReadFlagUnsafe(buf, &pos)
 	h.ErrorResilientMode = bits.ReadFlagUnsafe(buf, &pos)
 
+	frameSizeKnown := true
+
 	if !h.NonKeyFrame {
-		err := bits.HasSpace(buf, pos, 24)
+		err = readFrameSyncCode(buf, &pos)
 		if err != nil {
 			return err
 		}
 
-		frameSyncByte0 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte0 != 0x49 {
-			return fmt.Errorf("wrong frame_sync_byte_0")
+		h.ColorConfig = &Header_ColorConfig{}
+		err = h.ColorConfig.unmarshal(h.Profile, buf, &pos)
+		if err != nil {
+			return err
 		}
 
-		frameSyncByte1 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte1 != 0x83 {
-			return fmt.Errorf("wrong frame_sync_byte_1")
+		h.FrameSize = &Header_FrameSize{}
+		err = h.FrameSize.unmarshal(buf, &pos)
+		if err != nil {
+			return err
 		}
 
-		frameSyncByte2 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte2 != 0x42 {
-			return fmt.Errorf("wrong frame_sync_byte_2")
+		err = h.unmarshalRenderSize(buf, &pos)
+		if err != nil {
+			return err
 		}
 
-		h.ColorConfig = &Header_ColorConfig{}
-		err = h.ColorConfig.unmarshal(h.Profile, buf, &pos)
+		h.RefreshFrameFlags = 0xFF
+	} else {
+		if !h.ShowFrame {
+			h.IntraOnly, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !h.ErrorResilientMode {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 2)
+			if err != nil {
+				return err
+			}
+			h.ResetFrameContext = uint8(tmp)
+		}
+
+		if h.IntraOnly {
+			err = readFrameSyncCode(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if h.Profile > 0 {
+				h.ColorConfig = &Header_ColorConfig{}
+				err = h.ColorConfig.unmarshal(h.Profile, buf, &pos)
+				if err != nil {
+					return err
+				}
+			} else {
+				h.ColorConfig = &Header_ColorConfig{
+					BitDepth:     8,
+					ColorSpace:   ColorSpaceBT601,
+					SubsamplingX: true,
+					SubsamplingY: true,
+				}
+			}
+
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 8)
+			if err != nil {
+				return err
+			}
+			h.RefreshFrameFlags = uint8(tmp)
+
+			h.FrameSize = &Header_FrameSize{}
+			err = h.FrameSize.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			err = h.unmarshalRenderSize(buf, &pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = bits.HasSpace(buf, pos, 8+3*4)
+			if err != nil {
+				return err
+			}
+
+			h.RefreshFrameFlags = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+
+			for i := 0; i < 3; i++ {
+				h.RefFrameIdx[i] = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+				h.RefFrameSignBias[i] = bits.ReadFlagUnsafe(buf, &pos)
+			}
+
+			frameSizeKnown, err = h.unmarshalFrameSizeWithRefs(buf, &pos, refs)
+			if err != nil {
+				return err
+			}
+
+			err = bits.HasSpace(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+
+			h.AllowHighPrecisionMV = bits.ReadFlagUnsafe(buf, &pos)
+			h.IsFilterSwitchable = bits.ReadFlagUnsafe(buf, &pos)
+
+			if !h.IsFilterSwitchable {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, &pos, 2)
+				if err != nil {
+					return err
+				}
+				h.RawInterpolationFilter = uint8(tmp)
+			}
+		}
+	}
+
+	if !h.ErrorResilientMode {
+		err = bits.HasSpace(buf, pos, 2)
 		if err != nil {
 			return err
 		}
 
-		h.FrameSize = &Header_FrameSize{}
-		err = h.FrameSize.unmarshal(buf, &pos)
+		h.RefreshFrameContext = bits.ReadFlagUnsafe(buf, &pos)
+		h.FrameParallelDecodingMode = bits.ReadFlagUnsafe(buf, &pos)
+	} else {
+		h.FrameParallelDecodingMode = true
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	h.FrameContextIdx = uint8(tmp)
+
+	h.LoopFilterParams = &Header_LoopFilterParams{}
+	err = h.LoopFilterParams.unmarshal(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	h.QuantizationParams = &Header_QuantizationParams{}
+	err = h.QuantizationParams.unmarshal(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	h.SegmentationParams = &Header_SegmentationParams{}
+	err = h.SegmentationParams.unmarshal(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if !frameSizeKnown {
+		return nil
+	}
+
+	h.TileInfo = &Header_TileInfo{}
+	err = h.TileInfo.unmarshal(h.Width(), buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 16)
+	if err != nil {
+		return err
+	}
+	h.HeaderSizeInBytes = uint16(tmp)
+
+	return nil
+}
+
+func (h *Header) unmarshalRenderSize(buf []byte, pos *int) error {
+	renderAndFrameSizeDifferent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if renderAndFrameSizeDifferent {
+		h.RenderSize = &Header_RenderSize{}
+		err = h.RenderSize.unmarshal(buf, pos)
 		if err != nil {
 			return err
 		}
@@ -195,6 +768,46 @@ func (h *Header) Unmarshal(buf []byte) error {
 	return nil
 }
 
+func (h *Header) unmarshalFrameSizeWithRefs(buf []byte, pos *int, refs *RefFrameSizes) (bool, error) {
+	frameSizeKnown := true
+	foundRef := false
+
+	for i := 0; i < 3; i++ {
+		var err error
+		h.FoundRef[i], err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return false, err
+		}
+
+		if h.FoundRef[i] {
+			foundRef = true
+
+			if refs != nil && refs[h.RefFrameIdx[i]] != nil {
+				fs := *refs[h.RefFrameIdx[i]]
+				h.FrameSize = &fs
+			} else {
+				frameSizeKnown = false
+			}
+			break
+		}
+	}
+
+	if !foundRef {
+		h.FrameSize = &Header_FrameSize{}
+		err := h.FrameSize.unmarshal(buf, pos)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err := h.unmarshalRenderSize(buf, pos)
+	if err != nil {
+		return false, err
+	}
+
+	return frameSizeKnown, nil
+}
+
 // Width returns the video width.
 func (h Header) Width() int {
 	if h.FrameSize == nil {
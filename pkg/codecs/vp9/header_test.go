@@ -31,6 +31,23 @@ var casesHeader = []struct {
 				FrameWidthMinus1:  1919,
 				FrameHeightMinus1: 803,
 			},
+			RefreshFrameFlags:   0xff,
+			RefreshFrameContext: true,
+			LoopFilterParams: &Header_LoopFilterParams{
+				LoopFilterLevel:        24,
+				LoopFilterDeltaEnabled: true,
+				LoopFilterDeltaUpdate:  true,
+				UpdateRefDelta:         [4]bool{true, false, true, true},
+				LoopFilterRefDeltas:    [4]int8{1, 0, -1, -1},
+			},
+			QuantizationParams: &Header_QuantizationParams{
+				BaseQIdx: 160,
+			},
+			SegmentationParams: &Header_SegmentationParams{},
+			TileInfo: &Header_TileInfo{
+				TileColsLog2: 2,
+			},
+			HeaderSizeInBytes: 208,
 		},
 		1920,
 		804,
@@ -54,10 +71,60 @@ var casesHeader = []struct {
 				FrameWidthMinus1:  3839,
 				FrameHeightMinus1: 2159,
 			},
+			RefreshFrameFlags:   0xff,
+			RefreshFrameContext: true,
+			LoopFilterParams: &Header_LoopFilterParams{
+				LoopFilterLevel:        2,
+				LoopFilterDeltaEnabled: true,
+			},
+			QuantizationParams: &Header_QuantizationParams{
+				BaseQIdx: 26,
+			},
+			SegmentationParams: &Header_SegmentationParams{},
+			TileInfo: &Header_TileInfo{
+				TileColsLog2: 3,
+			},
+			HeaderSizeInBytes: 3,
 		},
 		3840,
 		2160,
 	},
+	{
+		"inter frame with size from reference",
+		[]byte{
+			0x86, 0x00, 0x40, 0x92, 0xe0, 0xa0, 0x40, 0x00,
+			0x00, 0x80,
+		},
+		Header{
+			NonKeyFrame:          true,
+			ShowFrame:            true,
+			RefreshFrameFlags:    0x01,
+			RefFrameIdx:          [3]uint8{0, 1, 2},
+			FoundRef:             [3]bool{true, false, false},
+			AllowHighPrecisionMV: true,
+			IsFilterSwitchable:   true,
+			RefreshFrameContext:  true,
+			LoopFilterParams: &Header_LoopFilterParams{
+				LoopFilterLevel: 10,
+			},
+			QuantizationParams: &Header_QuantizationParams{
+				BaseQIdx: 64,
+			},
+			SegmentationParams: &Header_SegmentationParams{},
+		},
+		0,
+		0,
+	},
+	{
+		"show existing frame",
+		[]byte{0x8d},
+		Header{
+			ShowExistingFrame: true,
+			FrameToShowMapIdx: 5,
+		},
+		0,
+		0,
+	},
 }
 
 func TestHeaderUnmarshal(t *testing.T) {
@@ -73,6 +140,36 @@ func TestHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestHeaderUnmarshalReuse(t *testing.T) {
+	var sh Header
+	err := sh.Unmarshal(casesHeader[0].byts)
+	require.NoError(t, err)
+
+	err = sh.Unmarshal(casesHeader[len(casesHeader)-1].byts)
+	require.NoError(t, err)
+	require.Equal(t, casesHeader[len(casesHeader)-1].sh, sh)
+}
+
+func TestHeaderUnmarshalWithRefs(t *testing.T) {
+	var refs RefFrameSizes
+
+	var h Header
+	err := h.UnmarshalWithRefs(casesHeader[0].byts, &refs)
+	require.NoError(t, err)
+	refs.Update(&h)
+
+	var h2 Header
+	err = h2.UnmarshalWithRefs([]byte{
+		0x86, 0x00, 0x40, 0x92, 0xe0, 0xa0, 0x40, 0x00,
+		0x00, 0x80,
+	}, &refs)
+	require.NoError(t, err)
+	require.Equal(t, 1920, h2.Width())
+	require.Equal(t, 804, h2.Height())
+	require.Equal(t, &Header_TileInfo{}, h2.TileInfo)
+	require.Equal(t, uint16(32), h2.HeaderSizeInBytes)
+}
+
 func FuzzHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesHeader {
 		f.Add(ca.byts)
@@ -0,0 +1,36 @@
+package vp9
+
+// levels, in ISO-BMFF/vpcC format (level number multiplied by 10).
+// Specification: https://www.webmproject.org/vp9/levels
+var levels = []struct {
+	level          uint8
+	maxPictureSize int
+	maxBreadth     int
+}{
+	{10, 36864, 512},
+	{11, 73728, 768},
+	{20, 122880, 960},
+	{21, 245760, 1344},
+	{30, 552960, 2048},
+	{31, 983040, 2752},
+	{40, 2228224, 4160},
+	{50, 8912896, 8384},
+	{60, 35651584, 16832},
+}
+
+// EstimateLevel returns the minimum level that is able to contain a picture with given size,
+// in ISO-BMFF/vpcC format (level number multiplied by 10).
+// Since the frame rate is not taken into account, levels that differ only by sample rate
+// (i.e. 4.1, 5.1, 5.2, 6.1, 6.2) are never returned.
+func EstimateLevel(width int, height int) uint8 {
+	size := width * height
+	breadth := max(width, height)
+
+	for _, l := range levels {
+		if size <= l.maxPictureSize && breadth <= l.maxBreadth {
+			return l.level
+		}
+	}
+
+	return 62
+}
@@ -0,0 +1,25 @@
+package vp9
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateLevel(t *testing.T) {
+	for _, ca := range []struct {
+		width  int
+		height int
+		level  uint8
+	}{
+		{256, 144, 10},
+		{640, 360, 21},
+		{1280, 720, 31},
+		{1920, 1080, 40},
+		{3840, 2160, 50},
+		{7680, 4320, 60},
+		{16384, 16384, 62},
+	} {
+		require.Equal(t, ca.level, EstimateLevel(ca.width, ca.height))
+	}
+}
@@ -0,0 +1,140 @@
+package vp9
+
+import (
+	"fmt"
+)
+
+const (
+	// MaxFramesPerSuperframe is the maximum number of frames contained in a superframe.
+	MaxFramesPerSuperframe = 8
+)
+
+func superframeIndexSize(buf []byte) (int, int, int) {
+	if len(buf) == 0 {
+		return 0, 0, 0
+	}
+
+	marker := buf[len(buf)-1]
+	if (marker & 0xE0) != 0xC0 {
+		return 0, 0, 0
+	}
+
+	bytesPerFrameSize := int((marker>>3)&0x03) + 1
+	frameCount := int(marker&0x07) + 1
+	indexSize := 2 + bytesPerFrameSize*frameCount
+
+	if len(buf) < indexSize || buf[len(buf)-indexSize] != marker {
+		return 0, 0, 0
+	}
+
+	return indexSize, bytesPerFrameSize, frameCount
+}
+
+// SuperframeUnmarshal splits a superframe into frames.
+// If the buffer doesn't contain a superframe index, it is returned as a single frame.
+// Specification: VP9 Bitstream & Decoding Process Specification, Annex B
+func SuperframeUnmarshal(buf []byte) ([][]byte, error) {
+	if len(buf) == 0 {
+		return nil, fmt.Errorf("frame is empty")
+	}
+
+	indexSize, bytesPerFrameSize, frameCount := superframeIndexSize(buf)
+	if indexSize == 0 {
+		return [][]byte{buf}, nil
+	}
+
+	index := buf[len(buf)-indexSize+1:]
+	data := buf[:len(buf)-indexSize]
+	frames := make([][]byte, frameCount)
+	pos := 0
+
+	for i := 0; i < frameCount; i++ {
+		size := 0
+		for j := 0; j < bytesPerFrameSize; j++ {
+			size |= int(index[i*bytesPerFrameSize+j]) << (j * 8)
+		}
+
+		if size == 0 {
+			return nil, fmt.Errorf("invalid frame size")
+		}
+
+		if (len(data) - pos) < size {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		frames[i] = data[pos : pos+size]
+		pos += size
+	}
+
+	return frames, nil
+}
+
+// SuperframeMarshal bundles frames into a superframe.
+// If there's a single frame, it is returned without a superframe index.
+// Specification: VP9 Bitstream & Decoding Process Specification, Annex B
+func SuperframeMarshal(frames [][]byte) ([]byte, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	if len(frames) > MaxFramesPerSuperframe {
+		return nil, fmt.Errorf("frame count (%d) exceeds maximum (%d)", len(frames), MaxFramesPerSuperframe)
+	}
+
+	if len(frames) == 1 {
+		return frames[0], nil
+	}
+
+	maxSize := 0
+	n := 0
+
+	for _, frame := range frames {
+		if len(frame) == 0 {
+			return nil, fmt.Errorf("frame is empty")
+		}
+
+		if len(frame) > maxSize {
+			maxSize = len(frame)
+		}
+		n += len(frame)
+	}
+
+	var bytesPerFrameSize int
+	switch {
+	case maxSize <= 0xFF:
+		bytesPerFrameSize = 1
+	case maxSize <= 0xFFFF:
+		bytesPerFrameSize = 2
+	case maxSize <= 0xFFFFFF:
+		bytesPerFrameSize = 3
+	case uint64(maxSize) <= 0xFFFFFFFF:
+		bytesPerFrameSize = 4
+	default:
+		return nil, fmt.Errorf("frame is too big")
+	}
+
+	marker := byte(0xC0 | ((bytesPerFrameSize - 1) << 3) | (len(frames) - 1))
+	indexSize := 2 + bytesPerFrameSize*len(frames)
+
+	buf := make([]byte, n+indexSize)
+	pos := 0
+
+	for _, frame := range frames {
+		pos += copy(buf[pos:], frame)
+	}
+
+	buf[pos] = marker
+	pos++
+
+	for _, frame := range frames {
+		size := len(frame)
+		for j := 0; j < bytesPerFrameSize; j++ {
+			buf[pos] = byte(size >> (j * 8))
+			pos++
+		}
+	}
+
+	buf[pos] = marker
+
+	return buf, nil
+}
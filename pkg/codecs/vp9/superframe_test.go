@@ -0,0 +1,147 @@
+package vp9
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSuperframe = []struct {
+	name string
+	enc  []byte
+	dec  [][]byte
+}{
+	{
+		"single frame",
+		[]byte{0x86, 0x00, 0x40, 0x92},
+		[][]byte{{0x86, 0x00, 0x40, 0x92}},
+	},
+	{
+		"hidden + shown",
+		[]byte{
+			0x80, 0x49, 0x83, 0x42, 0x00, 0x77, 0xf0, 0x32,
+			0x34, 0x30, 0x38, 0x24, 0x1c, 0x19, 0x40, 0x18,
+			0x03, 0x40, 0x5f, 0xb4, 0x88, 0xc1, 0x14, 0x01,
+			0xc1,
+		},
+		[][]byte{
+			{
+				0x80, 0x49, 0x83, 0x42, 0x00, 0x77, 0xf0, 0x32,
+				0x34, 0x30, 0x38, 0x24, 0x1c, 0x19, 0x40, 0x18,
+				0x03, 0x40, 0x5f, 0xb4,
+			},
+			{0x88},
+		},
+	},
+	{
+		"2 bytes per size",
+		append(append(
+			[]byte{0x86},
+			make([]byte, 299)...),
+			0x8c, 0x00, 0xc9, 0x2c, 0x01, 0x02, 0x00, 0xc9,
+		),
+		[][]byte{
+			append([]byte{0x86}, make([]byte, 299)...),
+			{0x8c, 0x00},
+		},
+	},
+}
+
+func TestSuperframeUnmarshal(t *testing.T) {
+	for _, ca := range casesSuperframe {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := SuperframeUnmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestSuperframeMarshal(t *testing.T) {
+	for _, ca := range casesSuperframe {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := SuperframeMarshal(ca.dec)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestSuperframeUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+	}{
+		{
+			"empty",
+			[]byte{},
+		},
+		{
+			"frame size too big",
+			[]byte{0x82, 0x49, 0xc1, 0x08, 0x01, 0xc1},
+		},
+		{
+			"zero frame size",
+			[]byte{0x82, 0x49, 0xc1, 0x00, 0x01, 0xc1},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := SuperframeUnmarshal(ca.enc)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestContainsKeyFrame(t *testing.T) {
+	frames, err := SuperframeUnmarshal(casesSuperframe[1].enc)
+	require.NoError(t, err)
+
+	ok, err := ContainsKeyFrame(frames)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+
+	ok, err = ContainsKeyFrame([][]byte{{0x86, 0x00, 0x40, 0x92}})
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	_, err = ContainsKeyFrame([][]byte{})
+	require.Error(t, err)
+}
+
+func TestContainsShownFrame(t *testing.T) {
+	frames, err := SuperframeUnmarshal(casesSuperframe[1].enc)
+	require.NoError(t, err)
+
+	ok, err := ContainsShownFrame(frames[:1])
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	ok, err = ContainsShownFrame(frames)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+
+	ok, err = ContainsShownFrame([][]byte{{0x84, 0x00, 0x40, 0x92}})
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	ok, err = ContainsShownFrame([][]byte{{0x84, 0x00, 0x40, 0x92}, {0x88}})
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+
+	_, err = ContainsShownFrame([][]byte{})
+	require.Error(t, err)
+}
+
+func FuzzSuperframeUnmarshal(f *testing.F) {
+	for _, ca := range casesSuperframe {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		frames, err := SuperframeUnmarshal(b)
+		if err == nil {
+			SuperframeMarshal(frames) //nolint:errcheck
+			ContainsKeyFrame(frames)  //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package vp9
+
+import (
+	"fmt"
+)
+
+// ISO/IEC 23091-2 (formerly 23001-8) code points.
+const (
+	colourPrimariesBT709       = 1
+	colourPrimariesUnspecified = 2
+	colourPrimariesSMPTE170M   = 6
+	colourPrimariesSMPTE240M   = 7
+	colourPrimariesBT2020      = 9
+
+	transferCharacteristicsBT709       = 1
+	transferCharacteristicsUnspecified = 2
+	transferCharacteristicsSMPTE170M   = 6
+	transferCharacteristicsSMPTE240M   = 7
+	transferCharacteristicsSRGB        = 13
+	transferCharacteristicsBT202010bit = 14
+	transferCharacteristicsBT202012bit = 15
+
+	matrixCoefficientsIdentity    = 0
+	matrixCoefficientsBT709       = 1
+	matrixCoefficientsUnspecified = 2
+	matrixCoefficientsSMPTE170M   = 6
+	matrixCoefficientsSMPTE240M   = 7
+	matrixCoefficientsBT2020NCL   = 9
+)
+
+// VPCodecConfigurationRecord is a VP codec configuration record,
+// that is the content of a vpcC box.
+// Specification: https://www.webmproject.org/vp9/mp4/
+type VPCodecConfigurationRecord struct {
+	Profile                 uint8
+	Level                   uint8
+	BitDepth                uint8
+	ChromaSubsampling       uint8
+	VideoFullRangeFlag      bool
+	ColourPrimaries         uint8
+	TransferCharacteristics uint8
+	MatrixCoefficients      uint8
+	CodecInitializationData []byte
+}
+
+// Unmarshal decodes a VPCodecConfigurationRecord.
+func (r *VPCodecConfigurationRecord) Unmarshal(buf []byte) error {
+	if len(buf) < 8 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	r.Profile = buf[0]
+	r.Level = buf[1]
+	r.BitDepth = buf[2] >> 4
+	r.ChromaSubsampling = (buf[2] >> 1) & 0b111
+	r.VideoFullRangeFlag = (buf[2] & 0b1) != 0
+	r.ColourPrimaries = buf[3]
+	r.TransferCharacteristics = buf[4]
+	r.MatrixCoefficients = buf[5]
+
+	codecInitializationDataSize := int(buf[6])<<8 | int(buf[7])
+	if len(buf[8:]) != codecInitializationDataSize {
+		return fmt.Errorf("invalid codec initialization data size")
+	}
+
+	if codecInitializationDataSize != 0 {
+		r.CodecInitializationData = buf[8:]
+	}
+
+	return nil
+}
+
+func (r VPCodecConfigurationRecord) marshalSize() int {
+	return 8 + len(r.CodecInitializationData)
+}
+
+// Marshal encodes a VPCodecConfigurationRecord.
+func (r VPCodecConfigurationRecord) Marshal() ([]byte, error) {
+	if len(r.CodecInitializationData) > 0xFFFF {
+		return nil, fmt.Errorf("codec initialization data is too big")
+	}
+
+	buf := make([]byte, r.marshalSize())
+
+	buf[0] = r.Profile
+	buf[1] = r.Level
+	buf[2] = r.BitDepth<<4 | (r.ChromaSubsampling&0b111)<<1
+	if r.VideoFullRangeFlag {
+		buf[2] |= 1
+	}
+	buf[3] = r.ColourPrimaries
+	buf[4] = r.TransferCharacteristics
+	buf[5] = r.MatrixCoefficients
+	buf[6] = byte(len(r.CodecInitializationData) >> 8)
+	buf[7] = byte(len(r.CodecInitializationData))
+	copy(buf[8:], r.CodecInitializationData)
+
+	return buf, nil
+}
+
+// FillFromHeader fills the record with parameters of a key frame or intra-only frame header.
+// Level is estimated from the picture size.
+func (r *VPCodecConfigurationRecord) FillFromHeader(h *Header) error {
+	if h.ColorConfig == nil || h.FrameSize == nil {
+		return fmt.Errorf("header doesn't contain color config or frame size")
+	}
+
+	r.Profile = h.Profile
+	r.Level = EstimateLevel(h.Width(), h.Height())
+	r.BitDepth = h.ColorConfig.BitDepth
+	r.ChromaSubsampling = h.ChromaSubsampling()
+	r.VideoFullRangeFlag = h.ColorConfig.ColorRange
+
+	switch h.ColorConfig.ColorSpace {
+	case ColorSpaceBT601, ColorSpaceSMPTE170:
+		r.ColourPrimaries = colourPrimariesSMPTE170M
+		r.TransferCharacteristics = transferCharacteristicsSMPTE170M
+		r.MatrixCoefficients = matrixCoefficientsSMPTE170M
+
+	case ColorSpaceBT709:
+		r.ColourPrimaries = colourPrimariesBT709
+		r.TransferCharacteristics = transferCharacteristicsBT709
+		r.MatrixCoefficients = matrixCoefficientsBT709
+
+	case ColorSpaceSMPTE240:
+		r.ColourPrimaries = colourPrimariesSMPTE240M
+		r.TransferCharacteristics = transferCharacteristicsSMPTE240M
+		r.MatrixCoefficients = matrixCoefficientsSMPTE240M
+
+	case ColorSpaceBT2020:
+		r.ColourPrimaries = colourPrimariesBT2020
+		if h.ColorConfig.BitDepth >= 12 {
+			r.TransferCharacteristics = transferCharacteristicsBT202012bit
+		} else {
+			r.TransferCharacteristics = transferCharacteristicsBT202010bit
+		}
+		r.MatrixCoefficients = matrixCoefficientsBT2020NCL
+
+	case ColorSpaceRGB:
+		r.ColourPrimaries = colourPrimariesBT709
+		r.TransferCharacteristics = transferCharacteristicsSRGB
+		r.MatrixCoefficients = matrixCoefficientsIdentity
+
+	default:
+		r.ColourPrimaries = colourPrimariesUnspecified
+		r.TransferCharacteristics = transferCharacteristicsUnspecified
+		r.MatrixCoefficients = matrixCoefficientsUnspecified
+	}
+
+	return nil
+}
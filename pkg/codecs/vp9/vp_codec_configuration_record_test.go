@@ -0,0 +1,97 @@
+package vp9
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVPCodecConfigurationRecord = []struct {
+	name string
+	enc  []byte
+	dec  VPCodecConfigurationRecord
+}{
+	{
+		"bt709",
+		[]byte{0x00, 0x28, 0x82, 0x01, 0x01, 0x01, 0x00, 0x00},
+		VPCodecConfigurationRecord{
+			Level:                   40,
+			BitDepth:                8,
+			ChromaSubsampling:       1,
+			ColourPrimaries:         1,
+			TransferCharacteristics: 1,
+			MatrixCoefficients:      1,
+		},
+	},
+	{
+		"10 bit full range with init data",
+		[]byte{0x02, 0x32, 0xa3, 0x09, 0x10, 0x09, 0x00, 0x02, 0x01, 0x02},
+		VPCodecConfigurationRecord{
+			Profile:                 2,
+			Level:                   50,
+			BitDepth:                10,
+			ChromaSubsampling:       1,
+			VideoFullRangeFlag:      true,
+			ColourPrimaries:         9,
+			TransferCharacteristics: 16,
+			MatrixCoefficients:      9,
+			CodecInitializationData: []byte{1, 2},
+		},
+	},
+}
+
+func TestVPCodecConfigurationRecordUnmarshal(t *testing.T) {
+	for _, ca := range casesVPCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec VPCodecConfigurationRecord
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestVPCodecConfigurationRecordMarshal(t *testing.T) {
+	for _, ca := range casesVPCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestVPCodecConfigurationRecordFillFromHeader(t *testing.T) {
+	var h Header
+	err := h.Unmarshal(casesHeader[1].byts)
+	require.NoError(t, err)
+
+	var r VPCodecConfigurationRecord
+	err = r.FillFromHeader(&h)
+	require.NoError(t, err)
+	require.Equal(t, VPCodecConfigurationRecord{
+		Level:                   50,
+		BitDepth:                8,
+		ChromaSubsampling:       1,
+		ColourPrimaries:         1,
+		TransferCharacteristics: 1,
+		MatrixCoefficients:      1,
+	}, r)
+
+	err = r.FillFromHeader(&Header{NonKeyFrame: true})
+	require.Error(t, err)
+}
+
+func FuzzVPCodecConfigurationRecordUnmarshal(f *testing.F) {
+	for _, ca := range casesVPCodecConfigurationRecord {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var r VPCodecConfigurationRecord
+		err := r.Unmarshal(b)
+		if err == nil {
+			r.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package fmp4
+
+// CodecAMR is the AMR-NB or AMR-WB codec.
+type CodecAMR struct {
+	Wideband bool
+
+	// parameters of the AMRSpecificBox.
+	ModeSet          uint16
+	ModeChangePeriod uint8
+	FramesPerSample  uint8
+}
+
+// IsVideo implements Codec.
+func (CodecAMR) IsVideo() bool {
+	return false
+}
+
+func (*CodecAMR) isCodec() {}
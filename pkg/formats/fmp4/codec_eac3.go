@@ -0,0 +1,40 @@
+package fmp4
+
+import (
+	"fmt"
+)
+
+// CodecEAC3Substream is an independent substream of an E-AC-3 stream.
+type CodecEAC3Substream struct {
+	Fscod     uint8
+	Bsid      uint8
+	Asvc      bool
+	Bsmod     uint8
+	Acmod     uint8
+	LfeOn     bool
+	NumDepSub uint8
+	ChanLoc   uint16 // channel locations of dependent substreams, used when NumDepSub > 0
+}
+
+// CodecEAC3 is the E-AC-3 codec.
+type CodecEAC3 struct {
+	SampleRate   int
+	ChannelCount int
+	DataRate     uint16 // in kbit/s
+	Substreams   []CodecEAC3Substream
+}
+
+// IsVideo implements Codec.
+func (CodecEAC3) IsVideo() bool {
+	return false
+}
+
+func (*CodecEAC3) isCodec() {}
+
+// Validate checks the codec parameters.
+func (c CodecEAC3) Validate() error {
+	if len(c.Substreams) < 1 || len(c.Substreams) > 8 {
+		return fmt.Errorf("invalid E-AC-3 substream count (%d), must be between 1 and 8", len(c.Substreams))
+	}
+	return nil
+}
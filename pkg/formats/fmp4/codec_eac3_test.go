@@ -0,0 +1,51 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecEAC3Validate(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		count int
+		err   string
+	}{
+		{
+			"one",
+			1,
+			"",
+		},
+		{
+			"eight",
+			8,
+			"",
+		},
+		{
+			"none",
+			0,
+			"invalid E-AC-3 substream count (0), must be between 1 and 8",
+		},
+		{
+			"nine",
+			9,
+			"invalid E-AC-3 substream count (9), must be between 1 and 8",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			codec := CodecEAC3{
+				SampleRate:   48000,
+				ChannelCount: 2,
+				Substreams:   make([]CodecEAC3Substream, ca.count),
+			}
+
+			err := codec.Validate()
+			if ca.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, ca.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package fmp4
+
+import (
+	"github.com/bluenviron/mediacommon/pkg/codecs/flac"
+)
+
+// CodecFLAC is the FLAC codec.
+type CodecFLAC struct {
+	StreamInfo flac.StreamInfo
+}
+
+// IsVideo implements Codec.
+func (CodecFLAC) IsVideo() bool {
+	return false
+}
+
+func (*CodecFLAC) isCodec() {}
@@ -0,0 +1,15 @@
+package fmp4
+
+// CodecG711 is the G711 codec.
+type CodecG711 struct {
+	MULaw        bool
+	SampleRate   int
+	ChannelCount int
+}
+
+// IsVideo implements Codec.
+func (CodecG711) IsVideo() bool {
+	return false
+}
+
+func (*CodecG711) isCodec() {}
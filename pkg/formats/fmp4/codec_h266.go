@@ -0,0 +1,15 @@
+package fmp4
+
+// CodecH266 is the H266 codec.
+type CodecH266 struct {
+	SPS []byte
+	PPS []byte
+	VPS []byte // optional
+}
+
+// IsVideo implements Codec.
+func (CodecH266) IsVideo() bool {
+	return true
+}
+
+func (*CodecH266) isCodec() {}
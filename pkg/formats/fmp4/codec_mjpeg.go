@@ -1,5 +1,9 @@
 package fmp4
 
+import (
+	"github.com/bluenviron/mediacommon/pkg/codecs/jpeg"
+)
+
 // CodecMJPEG is the M-JPEG codec.
 type CodecMJPEG struct {
 	Width  int
@@ -12,3 +16,16 @@ func (CodecMJPEG) IsVideo() bool {
 }
 
 func (*CodecMJPEG) isCodec() {}
+
+// FillFromFrame fills Width and Height by parsing the markers of a JPEG frame.
+func (c *CodecMJPEG) FillFromFrame(frame []byte) error {
+	var h jpeg.Header
+	err := h.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	c.Width = h.StartOfFrame.Width
+	c.Height = h.StartOfFrame.Height
+	return nil
+}
@@ -0,0 +1,21 @@
+package fmp4
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecMJPEGFillFromFrame(t *testing.T) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 320, 240)), nil)
+	require.NoError(t, err)
+
+	var codec CodecMJPEG
+	err = codec.FillFromFrame(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, CodecMJPEG{Width: 320, Height: 240}, codec)
+}
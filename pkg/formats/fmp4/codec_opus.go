@@ -1,8 +1,28 @@
 package fmp4
 
+import (
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+)
+
+const (
+	opusDefaultPreSkip         = 312
+	opusDefaultInputSampleRate = 48000
+)
+
 // CodecOpus is the Opus codec.
 type CodecOpus struct {
 	ChannelCount int
+
+	// parameters of the identification header.
+	// If PreSkip is nil, 312 is used.
+	// If InputSampleRate is nil, 48000 is used.
+	PreSkip         *uint16
+	OutputGain      int16
+	InputSampleRate *uint32
+
+	// channel mapping.
+	// If nil, the default channel mapping for ChannelCount is used.
+	ChannelMapping *opus.ChannelMapping
 }
 
 // IsVideo implements Codec.
@@ -11,3 +31,28 @@ func (CodecOpus) IsVideo() bool {
 }
 
 func (*CodecOpus) isCodec() {}
+
+// PreSkipOrDefault returns PreSkip, or its default value if PreSkip is nil.
+func (c CodecOpus) PreSkipOrDefault() uint16 {
+	if c.PreSkip == nil {
+		return opusDefaultPreSkip
+	}
+	return *c.PreSkip
+}
+
+// InputSampleRateOrDefault returns InputSampleRate, or its default value if InputSampleRate is nil.
+func (c CodecOpus) InputSampleRateOrDefault() uint32 {
+	if c.InputSampleRate == nil {
+		return opusDefaultInputSampleRate
+	}
+	return *c.InputSampleRate
+}
+
+// ChannelMappingOrDefault returns ChannelMapping, or the default channel mapping
+// for ChannelCount if ChannelMapping is nil.
+func (c CodecOpus) ChannelMappingOrDefault() (*opus.ChannelMapping, error) {
+	if c.ChannelMapping != nil {
+		return c.ChannelMapping, nil
+	}
+	return opus.DefaultChannelMapping(c.ChannelCount)
+}
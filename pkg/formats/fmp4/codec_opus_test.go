@@ -0,0 +1,73 @@
+package fmp4
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+	"github.com/bluenviron/mediacommon/pkg/formats/fmp4/seekablebuffer"
+)
+
+func TestCodecOpusDefaults(t *testing.T) {
+	codec := CodecOpus{ChannelCount: 2}
+	require.Equal(t, uint16(312), codec.PreSkipOrDefault())
+	require.Equal(t, uint32(48000), codec.InputSampleRateOrDefault())
+
+	m, err := codec.ChannelMappingOrDefault()
+	require.NoError(t, err)
+	require.Equal(t, &opus.ChannelMapping{}, m)
+
+	preSkip := uint16(0)
+	inputSampleRate := uint32(44100)
+
+	codec = CodecOpus{ChannelCount: 2, PreSkip: &preSkip, InputSampleRate: &inputSampleRate}
+	require.Equal(t, uint16(0), codec.PreSkipOrDefault())
+	require.Equal(t, uint32(44100), codec.InputSampleRateOrDefault())
+}
+
+func TestCodecOpusUnsupportedChannelCount(t *testing.T) {
+	codec := &CodecOpus{ChannelCount: 9}
+
+	_, err := codec.ChannelMappingOrDefault()
+	require.EqualError(t, err, "unsupported channel count: 9")
+
+	i := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 48000,
+			Codec:     codec,
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err = i.Marshal(&buf)
+	require.EqualError(t, err, "invalid Opus channel mapping: unsupported channel count: 9")
+}
+
+func TestCodecOpusZeroPreSkip(t *testing.T) {
+	preSkip := uint16(0)
+	inputSampleRate := uint32(48000)
+
+	i := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 48000,
+			Codec: &CodecOpus{
+				ChannelCount:    2,
+				PreSkip:         &preSkip,
+				InputSampleRate: &inputSampleRate,
+			},
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := i.Marshal(&buf)
+	require.NoError(t, err)
+
+	var dec Init
+	err = dec.Unmarshal(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, i, dec)
+}
@@ -8,6 +8,15 @@ type CodecVP9 struct {
 	BitDepth          uint8
 	ChromaSubsampling uint8
 	ColorRange        bool
+
+	// level, multiplied by 10.
+	// If zero, it is estimated from width and height.
+	Level uint8
+
+	// ISO/IEC 23091-2 color parameters.
+	ColourPrimaries         uint8
+	TransferCharacteristics uint8
+	MatrixCoefficients      uint8
 }
 
 // IsVideo implements Codec.
@@ -7,17 +7,28 @@ import (
 	"github.com/abema/go-mp4"
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/pkg/codecs/flac"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+	"github.com/bluenviron/mediacommon/pkg/formats/internal/mp4boxes"
 )
 
 // Specification: ISO 14496-1, Table 5
 const (
-	objectTypeIndicationVisualISO14496part2    = 0x20
-	objectTypeIndicationAudioISO14496part3     = 0x40
-	objectTypeIndicationVisualISO1318part2Main = 0x61
-	objectTypeIndicationAudioISO11172part3     = 0x6B
-	objectTypeIndicationVisualISO10918part1    = 0x6C
+	objectTypeIndicationVisualISO14496part2        = 0x20
+	objectTypeIndicationAudioISO14496part3         = 0x40
+	objectTypeIndicationVisualISO13818part2Simple  = 0x60
+	objectTypeIndicationVisualISO13818part2Main    = 0x61
+	objectTypeIndicationVisualISO13818part2SNR     = 0x62
+	objectTypeIndicationVisualISO13818part2Spatial = 0x63
+	objectTypeIndicationVisualISO13818part2High    = 0x64
+	objectTypeIndicationVisualISO13818part2422     = 0x65
+	objectTypeIndicationAudioISO13818part3         = 0x69
+	objectTypeIndicationVisualISO11172part2        = 0x6A
+	objectTypeIndicationAudioISO11172part3         = 0x6B
+	objectTypeIndicationVisualISO10918part1        = 0x6C
 )
 
 // Specification: ISO 14496-1, Table 6
@@ -87,6 +98,42 @@ func h265FindParams(params []mp4.HEVCNaluArray) ([]byte, []byte, []byte, error)
 	return vps, sps, pps, nil
 }
 
+func h266FindParams(params []mp4boxes.VvcNaluArray) ([]byte, []byte, []byte, error) {
+	var vps []byte
+	var sps []byte
+	var pps []byte
+
+	for _, arr := range params {
+		switch h266.NALUType(arr.NaluType) {
+		case h266.NALUType_VPS_NUT, h266.NALUType_SPS_NUT, h266.NALUType_PPS_NUT:
+			if arr.NumNalus != 1 {
+				return nil, nil, nil, fmt.Errorf("multiple VPS/SPS/PPS are not supported")
+			}
+		}
+
+		switch h266.NALUType(arr.NaluType) {
+		case h266.NALUType_VPS_NUT:
+			vps = arr.Nalus[0].NALUnit
+
+		case h266.NALUType_SPS_NUT:
+			sps = arr.Nalus[0].NALUnit
+
+		case h266.NALUType_PPS_NUT:
+			pps = arr.Nalus[0].NALUnit
+		}
+	}
+
+	if sps == nil {
+		return nil, nil, nil, fmt.Errorf("SPS not provided")
+	}
+
+	if pps == nil {
+		return nil, nil, nil, fmt.Errorf("PPS not provided")
+	}
+
+	return vps, sps, pps, nil
+}
+
 func h264FindParams(avcc *mp4.AVCDecoderConfiguration) ([]byte, []byte, error) {
 	if len(avcc.SequenceParameterSets) > 1 {
 		return nil, nil, fmt.Errorf("multiple SPS are not supported")
@@ -143,12 +190,16 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 		waitingCodec
 		waitingAv1C
 		waitingVpcC
+		waitingVvcC
 		waitingHvcC
 		waitingAvcC
 		waitingVideoEsds
 		waitingAudioEsds
 		waitingDOps
+		waitingDfLa
+		waitingDamr
 		waitingDac3
+		waitingDec3
 		waitingPcmC
 	)
 
@@ -158,6 +209,7 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 	var height int
 	var sampleRate int
 	var channelCount int
+	var amrWideband bool
 
 	_, err := mp4.ReadBoxStructure(r, func(h *mp4.ReadHandle) (interface{}, error) {
 		if !h.BoxInfo.IsSupportedType() {
@@ -277,12 +329,47 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					BitDepth:          vpcc.BitDepth,
 					ChromaSubsampling: vpcc.ChromaSubsampling,
 					ColorRange:        vpcc.VideoFullRangeFlag != 0,
+					Level:             vpcc.Level,
+
+					ColourPrimaries:         vpcc.ColourPrimaries,
+					TransferCharacteristics: vpcc.TransferCharacteristics,
+					MatrixCoefficients:      vpcc.MatrixCoefficients,
 				}
 				state = waitingTrak
 
 			case "vp08": // VP8, not supported yet
 				return nil, nil
 
+			case "vvc1", "vvi1":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+				state = waitingVvcC
+				return h.Expand()
+
+			case "vvcC":
+				if state != waitingVvcC {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				vvcc := box.(*mp4boxes.VvcC)
+
+				vps, sps, pps, err := h266FindParams(vvcc.NaluArrays)
+				if err != nil {
+					return nil, err
+				}
+
+				curTrack.Codec = &CodecH266{
+					VPS: vps,
+					SPS: sps,
+					PPS: pps,
+				}
+				state = waitingTrak
+
 			case "hev1", "hvc1":
 				if state != waitingCodec {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
@@ -359,8 +446,83 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 				}
 				dops := box.(*mp4.DOps)
 
-				curTrack.Codec = &CodecOpus{
-					ChannelCount: int(dops.OutputChannelCount),
+				codec := &CodecOpus{
+					ChannelCount:    int(dops.OutputChannelCount),
+					PreSkip:         &dops.PreSkip,
+					OutputGain:      dops.OutputGain,
+					InputSampleRate: &dops.InputSampleRate,
+				}
+
+				if dops.ChannelMappingFamily != 0 {
+					codec.ChannelMapping = &opus.ChannelMapping{
+						Family:       dops.ChannelMappingFamily,
+						StreamCount:  dops.StreamCount,
+						CoupledCount: dops.CoupledCount,
+						Table:        dops.ChannelMapping,
+					}
+				}
+
+				curTrack.Codec = codec
+				state = waitingTrak
+
+			case "fLaC":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+				state = waitingDfLa
+				return h.Expand()
+
+			case "dfLa":
+				if state != waitingDfLa {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				dfla := box.(*mp4boxes.DfLa)
+
+				if len(dfla.MetadataBlocks) == 0 ||
+					dfla.MetadataBlocks[0].BlockType != uint8(flac.MetadataBlockTypeStreamInfo) {
+					return nil, fmt.Errorf("STREAMINFO not found")
+				}
+
+				var streamInfo flac.StreamInfo
+				err = streamInfo.Unmarshal(dfla.MetadataBlocks[0].BlockData)
+				if err != nil {
+					return nil, fmt.Errorf("invalid FLAC STREAMINFO: %w", err)
+				}
+
+				curTrack.Codec = &CodecFLAC{
+					StreamInfo: streamInfo,
+				}
+				state = waitingTrak
+
+			case "samr", "sawb":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+				amrWideband = h.BoxInfo.Type == mp4boxes.BoxTypeSawb()
+				state = waitingDamr
+				return h.Expand()
+
+			case "damr":
+				if state != waitingDamr {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				damr := box.(*mp4boxes.Damr)
+
+				curTrack.Codec = &CodecAMR{
+					Wideband:         amrWideband,
+					ModeSet:          damr.ModeSet,
+					ModeChangePeriod: damr.ModeChangePeriod,
+					FramesPerSample:  damr.FramesPerSample,
 				}
 				state = waitingTrak
 
@@ -421,7 +583,10 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 							Config: spec,
 						}
 
-					case objectTypeIndicationVisualISO1318part2Main:
+					case objectTypeIndicationVisualISO13818part2Simple, objectTypeIndicationVisualISO13818part2Main,
+						objectTypeIndicationVisualISO13818part2SNR, objectTypeIndicationVisualISO13818part2Spatial,
+						objectTypeIndicationVisualISO13818part2High, objectTypeIndicationVisualISO13818part2422,
+						objectTypeIndicationVisualISO11172part2:
 						spec := esdsFindDecoderSpecificInfo(esds.Descriptors)
 						if spec == nil {
 							return nil, fmt.Errorf("unable to find decoder specific info")
@@ -461,7 +626,7 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 							Config: c,
 						}
 
-					case objectTypeIndicationAudioISO11172part3:
+					case objectTypeIndicationAudioISO11172part3, objectTypeIndicationAudioISO13818part3:
 						curTrack.Codec = &CodecMPEG1Audio{
 							SampleRate:   sampleRate,
 							ChannelCount: channelCount,
@@ -516,6 +681,80 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 				}
 				state = waitingTrak
 
+			case "ec-3":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				ec3 := box.(*mp4.AudioSampleEntry)
+
+				sampleRate = int(ec3.SampleRate / 65536)
+				channelCount = int(ec3.ChannelCount)
+				state = waitingDec3
+				return h.Expand()
+
+			case "dec3":
+				if state != waitingDec3 {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				dec3 := box.(*mp4boxes.Dec3)
+
+				substreams := make([]CodecEAC3Substream, len(dec3.Substreams))
+				for i, sub := range dec3.Substreams {
+					substreams[i] = CodecEAC3Substream{
+						Fscod:     sub.Fscod,
+						Bsid:      sub.Bsid,
+						Asvc:      sub.Asvc != 0,
+						Bsmod:     sub.Bsmod,
+						Acmod:     sub.Acmod,
+						LfeOn:     sub.LfeOn != 0,
+						NumDepSub: sub.NumDepSub,
+						ChanLoc:   sub.ChanLoc,
+					}
+				}
+
+				codec := &CodecEAC3{
+					SampleRate:   sampleRate,
+					ChannelCount: channelCount,
+					DataRate:     dec3.DataRate,
+					Substreams:   substreams,
+				}
+
+				err = codec.Validate()
+				if err != nil {
+					return nil, err
+				}
+
+				curTrack.Codec = codec
+				state = waitingTrak
+
+			case "alaw", "ulaw":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				g711 := box.(*mp4.AudioSampleEntry)
+
+				curTrack.Codec = &CodecG711{
+					MULaw:        h.BoxInfo.Type == mp4boxes.BoxTypeUlaw(),
+					SampleRate:   int(g711.SampleRate / 65536),
+					ChannelCount: int(g711.ChannelCount),
+				}
+				state = waitingTrak
+
 			case "ipcm":
 				if state != waitingCodec {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
@@ -6,7 +6,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/bluenviron/mediacommon/pkg/codecs/flac"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
 	"github.com/bluenviron/mediacommon/pkg/formats/fmp4/seekablebuffer"
 )
 
@@ -30,6 +32,11 @@ var testAudioTrack = &CodecMPEG4Audio{
 	},
 }
 
+var (
+	testOpusPreSkip         = uint16(312)
+	testOpusInputSampleRate = uint32(48000)
+)
+
 var casesInit = []struct {
 	name string
 	enc  []byte
@@ -202,7 +209,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x14, 0x76,
 			0x70, 0x63, 0x43, 0x01, 0x00, 0x00, 0x00, 0x01,
-			0x0a, 0x82, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x28, 0x82, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40, 0x00,
 			0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10, 0x73,
@@ -231,10 +238,133 @@ var casesInit = []struct {
 					BitDepth:          8,
 					ChromaSubsampling: 1,
 					ColorRange:        false,
+					Level:             40,
 				},
 			}},
 		},
 	},
+	{
+		"h266",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20,
+			'f', 't', 'y', 'p',
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0xc2,
+			'm', 'o', 'o', 'v',
+			0x00, 0x00, 0x00, 0x6c,
+			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x26,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x0f, 0x00, 0x00, 0x00, 0x08, 0x70, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xc2, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x2d, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xe1, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xd1, 0x76, 0x76, 0x69, 0x31, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x0f, 0x00, 0x08,
+			0x70, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x67, 0x76,
+			0x76, 0x63, 0x43, 0x00, 0x00, 0x00, 0x00, 0xff,
+			0x00, 0x21, 0x5f, 0x0b, 0x02, 0x53, 0xa0, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x80, 0x50, 0x01, 0x12, 0x34, 0x56, 0x78,
+			0x0f, 0x00, 0x08, 0x70, 0x00, 0x00, 0x02, 0x0f,
+			0x00, 0x01, 0x00, 0x27, 0x00, 0x79, 0x00, 0x2d,
+			0x02, 0x53, 0xa0, 0x00, 0x00, 0x03, 0x00, 0x00,
+			0x03, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03, 0x00,
+			0x00, 0x80, 0x50, 0x01, 0x12, 0x34, 0x56, 0x78,
+			0x00, 0x07, 0x80, 0x80, 0x08, 0x71, 0x1a, 0x80,
+			0x15, 0xc9, 0x60, 0x10, 0x00, 0x01, 0x00, 0x0a,
+			0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+			0xc8, 0x86, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
+			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0f,
+			0x42, 0x40, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &CodecH266{
+						SPS: []byte{
+							0x00, 0x79, 0x00, 0x2d, 0x02, 0x53, 0xa0, 0x00,
+							0x00, 0x03, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+							0x00, 0x00, 0x03, 0x00, 0x00, 0x80, 0x50, 0x01,
+							0x12, 0x34, 0x56, 0x78, 0x00, 0x07, 0x80, 0x80,
+							0x08, 0x71, 0x1a, 0x80, 0x15, 0xc9, 0x60,
+						},
+						PPS: []byte{
+							0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+							0xc8, 0x86,
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		"h265",
 		[]byte{
@@ -499,7 +629,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
-			0x03, 0x20, 0x00, 0x00, 0x02, 0x58, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
 			0x00, 0x00, 0x01, 0xbc, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -525,8 +655,8 @@ var casesInit = []struct {
 			0xcb, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x20, 0x02,
-			0x58, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -614,7 +744,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
-			0x03, 0x20, 0x00, 0x00, 0x02, 0x58, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
 			0x00, 0x00, 0x01, 0xa2, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -640,8 +770,8 @@ var casesInit = []struct {
 			0xb1, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x20, 0x02,
-			0x58, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -880,24 +1010,23 @@ var casesInit = []struct {
 					ID:        1,
 					TimeScale: 48000,
 					Codec: &CodecOpus{
-						ChannelCount: 2,
+						ChannelCount:    2,
+						PreSkip:         &testOpusPreSkip,
+						InputSampleRate: &testOpusInputSampleRate,
 					},
 				},
 			},
 		},
 	},
 	{
-		"mpeg-4 audio",
+		"opus 5.1",
 		[]byte{
-			0x00, 0x00, 0x00, 0x20,
-			'f', 't', 'y', 'p',
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x58,
-			'm', 'o', 'o', 'v',
-			0x00, 0x00, 0x00, 0x6c,
-			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x02, 0x40, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
@@ -910,11 +1039,9 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbc,
-			't', 'r', 'a', 'k',
-			0x00, 0x00, 0x00, 0x5c,
-			't', 'k', 'h', 'd',
-			0x00, 0x00, 0x00, 0x03,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xa4,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -925,81 +1052,784 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x58,
-			'm', 'd', 'i', 'a',
-			0x00, 0x00, 0x00, 0x20,
-			'm', 'd', 'h', 'd',
+			0x00, 0x00, 0x01, 0x40, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xeb, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xaf, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x63, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x53, 0x4f, 0x70, 0x75,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x1b, 0x64, 0x4f, 0x70, 0x73, 0x00, 0x06, 0x01,
+			0x38, 0x00, 0x00, 0xbb, 0x80, 0xff, 0x00, 0x01,
+			0x04, 0x02, 0x00, 0x04, 0x01, 0x02, 0x03, 0x05,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 48000,
+					Codec: &CodecOpus{
+						ChannelCount:    6,
+						PreSkip:         &testOpusPreSkip,
+						OutputGain:      -256,
+						InputSampleRate: &testOpusInputSampleRate,
+						ChannelMapping: &opus.ChannelMapping{
+							Family:       1,
+							StreamCount:  4,
+							CoupledCount: 2,
+							Table:        []uint8{0, 4, 1, 2, 3, 5},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"flac",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x57, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbb,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x57, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x2d,
-			'h', 'd', 'l', 'r',
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x03,
-			'm', 'i', 'n', 'f',
-			0x00, 0x00, 0x00, 0x10,
-			's', 'm', 'h', 'd',
-			0x00, 0x00, 0x00,
+			0x02, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc6, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7a, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6a, 0x66, 0x4c, 0x61,
+			0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x32, 0x64, 0x66, 0x4c, 0x61, 0x00, 0x00, 0x00,
+			0x00, 0x80, 0x00, 0x00, 0x22, 0x10, 0x00, 0x10,
+			0x00, 0x00, 0x00, 0x0e, 0x00, 0x20, 0x00, 0x0a,
+			0xc4, 0x42, 0xf0, 0x00, 0x06, 0xba, 0xa8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 44100,
+					Codec: &CodecFLAC{
+						StreamInfo: flac.StreamInfo{
+							MinimumBlockSize: 4096,
+							MaximumBlockSize: 4096,
+							MinimumFrameSize: 14,
+							MaximumFrameSize: 8192,
+							SampleRate:       44100,
+							ChannelCount:     2,
+							BitsPerSample:    16,
+							TotalSamples:     441000,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"amr-nb",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x36, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x9a,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x36, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f, 0x40,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xe1, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa5, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x59, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x49, 0x73, 0x61, 0x6d,
+			0x72, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x1f, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x11, 0x64, 0x61, 0x6d, 0x72, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x81, 0xff, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74,
+			0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 8000,
+					Codec: &CodecAMR{
+						ModeSet:         0x81ff,
+						FramesPerSample: 1,
+					},
+				},
+			},
+		},
+	},
+	{
+		"amr-wb",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x36, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x9a,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x36, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xe1, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa5, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x59, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x49, 0x73, 0x61, 0x77,
+			0x62, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x3e, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x11, 0x64, 0x61, 0x6d, 0x72, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x83, 0xff, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74,
+			0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 16000,
+					Codec: &CodecAMR{
+						Wideband:        true,
+						ModeSet:         0x83ff,
+						FramesPerSample: 1,
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20,
+			'f', 't', 'y', 'p',
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x58,
+			'm', 'o', 'o', 'v',
+			0x00, 0x00, 0x00, 0x6c,
+			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbc,
+			't', 'r', 'a', 'k',
+			0x00, 0x00, 0x00, 0x5c,
+			't', 'k', 'h', 'd',
+			0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x58,
+			'm', 'd', 'i', 'a',
+			0x00, 0x00, 0x00, 0x20,
+			'm', 'd', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d,
+			'h', 'd', 'l', 'r',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x03,
+			'm', 'i', 'n', 'f',
+			0x00, 0x00, 0x00, 0x10,
+			's', 'm', 'h', 'd',
+			0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24,
+			'd', 'i', 'n', 'f',
+			0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc7, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7b, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6b,
+			'm', 'p', '4', 'a',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x33,
+			'e', 's', 'd', 's',
+			0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x22, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x14, 0x40, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x02,
+			0x12, 0x10, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
+			0x00, 0x00, 0x00, 0x14,
+			'b', 't', 'r', 't',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28,
+			'm', 'v', 'e', 'x',
+			0x00, 0x00, 0x00, 0x20,
+			't', 'r', 'e', 'x',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: uint32(testAudioTrack.SampleRate),
+					Codec:     testAudioTrack,
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio eld",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x5a, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbe,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x5a, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x05, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc9, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7d, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6d, 0x6d, 0x70, 0x34,
+			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x35, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x24, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x16, 0x40, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x04,
+			0xf8, 0xe6, 0x40, 0x00, 0x06, 0x80, 0x80, 0x80,
+			0x01, 0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
+			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 48000,
+					Codec: &CodecMPEG4Audio{
+						Config: mpeg4audio.Config{
+							Type:         mpeg4audio.ObjectTypeERAACELD,
+							SampleRate:   48000,
+							ChannelCount: 2,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio program config element",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x60, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xc4,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x60, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x0b, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xcf, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x83, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x73, 0x6d, 0x70, 0x34,
+			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x3b, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x2a, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x1c, 0x40, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x0a,
+			0x11, 0x80, 0x04, 0xc8, 0x05, 0x00, 0x01, 0x08,
+			0x80, 0x00, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 48000,
+					Codec: &CodecMPEG4Audio{
+						Config: mpeg4audio.Config{
+							Type:         mpeg4audio.ObjectTypeAACLC,
+							SampleRate:   48000,
+							ChannelCount: 6,
+							ProgramConfigElement: &mpeg4audio.ProgramConfigElement{
+								ObjectType: 1,
+								SampleRate: 48000,
+								FrontChannelElements: []*mpeg4audio.ProgramConfigElementChannel{
+									{},
+									{IsCPE: true},
+								},
+								BackChannelElements: []*mpeg4audio.ProgramConfigElementChannel{
+									{IsCPE: true, TagSelect: 1},
+								},
+								LFEChannelElements: []uint8{0},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-1 audio",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x51, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xb5,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x51, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x24,
-			'd', 'i', 'n', 'f',
-			0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xfc, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xc7, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x7b, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xc0, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x74, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x6b,
-			'm', 'p', '4', 'a',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x64, 0x6d, 0x70, 0x34,
+			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
-			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x33,
-			'e', 's', 'd', 's',
-			0x00, 0x00, 0x00,
-			0x00, 0x03, 0x80, 0x80, 0x80, 0x22, 0x00, 0x01,
-			0x00, 0x04, 0x80, 0x80, 0x80, 0x14, 0x40, 0x15,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x2c, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x1b, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x0d, 0x6b, 0x15,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
-			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x02,
-			0x12, 0x10, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
-			0x00, 0x00, 0x00, 0x14,
-			'b', 't', 'r', 't',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
-			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
-			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0xf7, 0x39, 0x06, 0x80, 0x80, 0x80, 0x01,
+			0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72,
+			0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7,
+			0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x28,
-			'm', 'v', 'e', 'x',
-			0x00, 0x00, 0x00, 0x20,
-			't', 'r', 'e', 'x',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63,
+			0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65,
+			0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65,
+			0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: uint32(testAudioTrack.SampleRate),
-					Codec:     testAudioTrack,
+					TimeScale: 90000,
+					Codec: &CodecMPEG1Audio{
+						SampleRate:   48000,
+						ChannelCount: 2,
+					},
 				},
 			},
 		},
 	},
 	{
-		"mpeg-1 audio",
+		"mpeg-2 audio",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
@@ -1056,11 +1886,11 @@ var casesInit = []struct {
 			0x01, 0x00, 0x00, 0x00, 0x64, 0x6d, 0x70, 0x34,
 			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
-			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x56, 0x22, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x2c, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
 			0x00, 0x03, 0x80, 0x80, 0x80, 0x1b, 0x00, 0x01,
-			0x00, 0x04, 0x80, 0x80, 0x80, 0x0d, 0x6b, 0x15,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x0d, 0x69, 0x15,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
 			0x01, 0xf7, 0x39, 0x06, 0x80, 0x80, 0x80, 0x01,
 			0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72,
@@ -1087,8 +1917,8 @@ var casesInit = []struct {
 					ID:        1,
 					TimeScale: 90000,
 					Codec: &CodecMPEG1Audio{
-						SampleRate:   48000,
-						ChannelCount: 2,
+						SampleRate:   22050,
+						ChannelCount: 1,
 					},
 				},
 			},
@@ -1191,6 +2021,197 @@ var casesInit = []struct {
 			},
 		},
 	},
+	{
+		"e-ac-3",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x33, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x97,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x33, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xde, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa2, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x56, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x46, 0x65, 0x63, 0x2d,
+			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x08, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0e, 0x64, 0x65, 0x63, 0x33, 0x14, 0x00, 0x20,
+			0x0f, 0x02, 0x02, 0x00, 0x00, 0x00, 0x14, 0x62,
+			0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d,
+			0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74,
+			0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &CodecEAC3{
+						SampleRate:   48000,
+						ChannelCount: 8,
+						DataRate:     640,
+						Substreams: []CodecEAC3Substream{{
+							Bsid:      16,
+							Acmod:     7,
+							LfeOn:     true,
+							NumDepSub: 1,
+							ChanLoc:   0x2,
+						}},
+					},
+				},
+			},
+		},
+	},
+	{
+		"g711",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x25, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x89,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x25, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f, 0x40,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xd0, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x94, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x48, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x38, 0x75, 0x6c, 0x61,
+			0x77, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x1f, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7,
+			0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73,
+			0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73,
+			0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 8000,
+					Codec: &CodecG711{
+						MULaw:        true,
+						SampleRate:   8000,
+						ChannelCount: 1,
+					},
+				},
+			},
+		},
+	},
 	{
 		"lpcm",
 		[]byte{
@@ -2090,6 +3111,10 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 			"vp9",
 			&CodecVP9{},
 		},
+		{
+			"h266",
+			&CodecH266{},
+		},
 		{
 			"h265",
 			&CodecH265{},
@@ -2110,6 +3135,14 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 			"mjpeg",
 			&CodecMJPEG{},
 		},
+		{
+			"flac",
+			&CodecFLAC{},
+		},
+		{
+			"eac3",
+			&CodecEAC3{},
+		},
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			i := Init{
@@ -2127,6 +3160,43 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 	}
 }
 
+func TestInitMarshalUnparsableConfig(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		codec Codec
+		err   string
+	}{
+		{
+			"mpeg-4 video",
+			&CodecMPEG4Video{
+				Config: []byte{0x00, 0x00, 0x01, 0xb0, 0x01},
+			},
+			"unable to parse MPEG-4 Video config: video object layer not found",
+		},
+		{
+			"mpeg-1 video",
+			&CodecMPEG1Video{
+				Config: []byte{0x00, 0x00, 0x01, 0xb3, 0x78},
+			},
+			"unable to parse MPEG-1/2 Video config: not enough bits",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			i := Init{
+				Tracks: []*InitTrack{{
+					ID:        1,
+					TimeScale: 90000,
+					Codec:     ca.codec,
+				}},
+			}
+
+			var buf seekablebuffer.Buffer
+			err := i.Marshal(&buf)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
 func FuzzInitUnmarshal(f *testing.F) {
 	for _, ca := range casesInit {
 		f.Add(ca.enc)
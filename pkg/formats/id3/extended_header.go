@@ -0,0 +1,215 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// ExtendedHeader is the extended header of a tag.
+// Specification: ID3v2.3, 3.2; ID3v2.4 main structure, 3.2
+type ExtendedHeader struct {
+	// tag is an update of a previous tag (ID3v2.4 only).
+	IsUpdate bool
+
+	// tag contains a CRC-32 of its content.
+	// It is filled by Marshal and checked by Unmarshal.
+	HasCRC bool
+
+	// tag restrictions (ID3v2.4 only).
+	HasRestrictions bool
+	Restrictions    uint8
+}
+
+// unmarshal decodes an ExtendedHeader.
+// It returns the size of the header, the CRC and the padding size (ID3v2.3 only).
+func (h *ExtendedHeader) unmarshal(majorVersion uint8, buf []byte) (int, uint32, uint32, error) {
+	if len(buf) < 4 {
+		return 0, 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	if majorVersion == 3 {
+		size := int(uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3]))
+		if size != 6 && size != 10 {
+			return 0, 0, 0, fmt.Errorf("invalid extended header size: %d", size)
+		}
+
+		if len(buf) < (4 + size) {
+			return 0, 0, 0, fmt.Errorf("not enough bytes")
+		}
+
+		h.IsUpdate = false
+		h.HasCRC = (buf[4] & 0x80) != 0
+		h.HasRestrictions = false
+		h.Restrictions = 0
+
+		if h.HasCRC != (size == 10) {
+			return 0, 0, 0, fmt.Errorf("invalid extended header size: %d", size)
+		}
+
+		paddingSize := uint32(buf[6])<<24 | uint32(buf[7])<<16 | uint32(buf[8])<<8 | uint32(buf[9])
+
+		var crc uint32
+		if h.HasCRC {
+			crc = uint32(buf[10])<<24 | uint32(buf[11])<<16 | uint32(buf[12])<<8 | uint32(buf[13])
+		}
+
+		return 4 + size, crc, paddingSize, nil
+	}
+
+	tmp, err := readSyncsafe(buf[:4])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	size := int(tmp)
+
+	if size < 6 || len(buf) < size {
+		return 0, 0, 0, fmt.Errorf("invalid extended header size: %d", size)
+	}
+
+	if buf[4] != 1 {
+		return 0, 0, 0, fmt.Errorf("invalid number of flag bytes: %d", buf[4])
+	}
+
+	flags := buf[5]
+	h.IsUpdate = (flags & 0x40) != 0
+	h.HasCRC = (flags & 0x20) != 0
+	h.HasRestrictions = (flags & 0x10) != 0
+
+	pos := 6
+
+	readFlagData := func(expectedLen int) ([]byte, error) {
+		if len(buf[:size]) < (pos + 1 + expectedLen) {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+		if int(buf[pos]) != expectedLen {
+			return nil, fmt.Errorf("invalid flag data length: %d", buf[pos])
+		}
+		data := buf[pos+1 : pos+1+expectedLen]
+		pos += 1 + expectedLen
+		return data, nil
+	}
+
+	if h.IsUpdate {
+		_, err = readFlagData(0)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	var crc uint32
+	if h.HasCRC {
+		var data []byte
+		data, err = readFlagData(5)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		crc, err = readSyncsafe(data)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	if h.HasRestrictions {
+		var data []byte
+		data, err = readFlagData(1)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		h.Restrictions = data[0]
+	} else {
+		h.Restrictions = 0
+	}
+
+	return size, crc, 0, nil
+}
+
+func (h ExtendedHeader) marshalSize(majorVersion uint8) int {
+	if majorVersion == 3 {
+		if h.HasCRC {
+			return 14
+		}
+		return 10
+	}
+
+	n := 6
+	if h.IsUpdate {
+		n++
+	}
+	if h.HasCRC {
+		n += 6
+	}
+	if h.HasRestrictions {
+		n += 2
+	}
+	return n
+}
+
+func (h ExtendedHeader) marshalTo(majorVersion uint8, buf []byte, crc uint32, paddingSize uint32) error {
+	if majorVersion == 3 {
+		if h.IsUpdate || h.HasRestrictions {
+			return fmt.Errorf("update and restriction flags are not supported in ID3v2.3")
+		}
+
+		size := h.marshalSize(majorVersion) - 4
+		buf[0] = 0
+		buf[1] = 0
+		buf[2] = 0
+		buf[3] = byte(size)
+
+		if h.HasCRC {
+			buf[4] = 0x80
+		} else {
+			buf[4] = 0
+		}
+		buf[5] = 0
+
+		buf[6] = byte(paddingSize >> 24)
+		buf[7] = byte(paddingSize >> 16)
+		buf[8] = byte(paddingSize >> 8)
+		buf[9] = byte(paddingSize)
+
+		if h.HasCRC {
+			buf[10] = byte(crc >> 24)
+			buf[11] = byte(crc >> 16)
+			buf[12] = byte(crc >> 8)
+			buf[13] = byte(crc)
+		}
+
+		return nil
+	}
+
+	writeSyncsafe(buf[:4], uint32(h.marshalSize(majorVersion)))
+	buf[4] = 1
+
+	var flags byte
+	if h.IsUpdate {
+		flags |= 0x40
+	}
+	if h.HasCRC {
+		flags |= 0x20
+	}
+	if h.HasRestrictions {
+		flags |= 0x10
+	}
+	buf[5] = flags
+
+	pos := 6
+
+	if h.IsUpdate {
+		buf[pos] = 0
+		pos++
+	}
+
+	if h.HasCRC {
+		buf[pos] = 5
+		writeSyncsafe(buf[pos+1:pos+6], crc)
+		pos += 6
+	}
+
+	if h.HasRestrictions {
+		buf[pos] = 1
+		buf[pos+1] = h.Restrictions
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package id3
+
+// Frame is an ID3v2 frame.
+// Unmarshal and Marshal work with the frame content, without the frame header.
+type Frame interface {
+	// FrameID returns the four-character identifier of the frame.
+	FrameID() string
+
+	Unmarshal(buf []byte) error
+	Marshal() ([]byte, error)
+}
+
+func isValidFrameID(id []byte) bool {
+	for _, c := range id {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func newFrame(id string, buf []byte) Frame {
+	switch {
+	case id == "TXXX":
+		return &FrameTXXX{}
+
+	case id[0] == 'T':
+		return &FrameText{ID: id}
+
+	case id == "PRIV":
+		if isTransportStreamTimestamp(buf) {
+			return &FrameTransportStreamTimestamp{}
+		}
+		return &FramePRIV{}
+
+	case id == "APIC":
+		return &FrameAPIC{}
+
+	default:
+		return &FrameUnknown{ID: id}
+	}
+}
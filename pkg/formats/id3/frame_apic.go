@@ -0,0 +1,69 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// FrameAPIC is an attached picture frame.
+// Specification: ID3v2.4 native frames, 4.14
+type FrameAPIC struct {
+	Encoding    TextEncoding
+	MIMEType    string
+	PictureType uint8
+	Description string
+	Data        []byte
+}
+
+// FrameID implements Frame.
+func (FrameAPIC) FrameID() string {
+	return "APIC"
+}
+
+// Unmarshal decodes a FrameAPIC.
+func (f *FrameAPIC) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	f.Encoding = TextEncoding(buf[0])
+	err := f.Encoding.validate()
+	if err != nil {
+		return err
+	}
+
+	f.MIMEType, buf, err = readTerminatedString(TextEncodingISO88591, buf[1:])
+	if err != nil {
+		return err
+	}
+
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	f.PictureType = buf[0]
+
+	f.Description, buf, err = readTerminatedString(f.Encoding, buf[1:])
+	if err != nil {
+		return err
+	}
+
+	f.Data = buf
+	return nil
+}
+
+// Marshal encodes a FrameAPIC.
+func (f FrameAPIC) Marshal() ([]byte, error) {
+	buf, err := appendTerminatedString([]byte{byte(f.Encoding)}, TextEncodingISO88591, f.MIMEType)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = append(buf, f.PictureType)
+
+	buf, err = appendTerminatedString(buf, f.Encoding, f.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, f.Data...), nil
+}
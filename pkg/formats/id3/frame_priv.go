@@ -0,0 +1,43 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// FramePRIV is a private frame.
+// Specification: ID3v2.4 native frames, 4.27
+type FramePRIV struct {
+	Owner string
+	Data  []byte
+}
+
+// FrameID implements Frame.
+func (FramePRIV) FrameID() string {
+	return "PRIV"
+}
+
+// Unmarshal decodes a FramePRIV.
+func (f *FramePRIV) Unmarshal(buf []byte) error {
+	var err error
+	f.Owner, buf, err = readTerminatedString(TextEncodingISO88591, buf)
+	if err != nil {
+		return err
+	}
+
+	f.Data = buf
+	return nil
+}
+
+// Marshal encodes a FramePRIV.
+func (f FramePRIV) Marshal() ([]byte, error) {
+	if f.Owner == "" {
+		return nil, fmt.Errorf("owner is empty")
+	}
+
+	buf, err := appendTerminatedString(nil, TextEncodingISO88591, f.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, f.Data...), nil
+}
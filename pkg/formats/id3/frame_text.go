@@ -0,0 +1,50 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// FrameText is a text information frame (T000-TZZZ, except TXXX).
+// Specification: ID3v2.4 native frames, 4.2
+type FrameText struct {
+	ID       string
+	Encoding TextEncoding
+
+	// in ID3v2.4, multiple values are separated by null characters.
+	Text string
+}
+
+// FrameID implements Frame.
+func (f FrameText) FrameID() string {
+	return f.ID
+}
+
+// Unmarshal decodes a FrameText.
+func (f *FrameText) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	f.Encoding = TextEncoding(buf[0])
+	err := f.Encoding.validate()
+	if err != nil {
+		return err
+	}
+
+	f.Text, err = decodeString(f.Encoding, trimTerminators(f.Encoding, buf[1:]))
+	return err
+}
+
+// Marshal encodes a FrameText.
+func (f FrameText) Marshal() ([]byte, error) {
+	if len(f.ID) != 4 || f.ID[0] != 'T' || f.ID == "TXXX" {
+		return nil, fmt.Errorf("invalid text frame ID: '%s'", f.ID)
+	}
+
+	text, err := encodeString(f.Encoding, f.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(f.Encoding)}, text...), nil
+}
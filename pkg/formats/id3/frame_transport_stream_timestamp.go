@@ -0,0 +1,65 @@
+package id3
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const transportStreamTimestampOwner = "com.apple.streaming.transportStreamTimestamp"
+
+func isTransportStreamTimestamp(buf []byte) bool {
+	return len(buf) == (len(transportStreamTimestampOwner)+1+8) &&
+		bytes.HasPrefix(buf, []byte(transportStreamTimestampOwner+"\x00"))
+}
+
+// FrameTransportStreamTimestamp is the PRIV frame that Apple HLS uses to
+// associate a packed audio segment with the MPEG-TS timeline.
+// Specification: Apple HTTP Live Streaming, Packed Audio
+type FrameTransportStreamTimestamp struct {
+	Timestamp uint64 // 33-bit MPEG-TS timestamp, in 90kHz units
+}
+
+// FrameID implements Frame.
+func (FrameTransportStreamTimestamp) FrameID() string {
+	return "PRIV"
+}
+
+// Unmarshal decodes a FrameTransportStreamTimestamp.
+func (f *FrameTransportStreamTimestamp) Unmarshal(buf []byte) error {
+	if !isTransportStreamTimestamp(buf) {
+		return fmt.Errorf("not a transport stream timestamp")
+	}
+
+	buf = buf[len(transportStreamTimestampOwner)+1:]
+
+	f.Timestamp = uint64(buf[0])<<56 | uint64(buf[1])<<48 | uint64(buf[2])<<40 | uint64(buf[3])<<32 |
+		uint64(buf[4])<<24 | uint64(buf[5])<<16 | uint64(buf[6])<<8 | uint64(buf[7])
+
+	if (f.Timestamp >> 33) != 0 {
+		return fmt.Errorf("invalid timestamp")
+	}
+
+	return nil
+}
+
+// Marshal encodes a FrameTransportStreamTimestamp.
+func (f FrameTransportStreamTimestamp) Marshal() ([]byte, error) {
+	if (f.Timestamp >> 33) != 0 {
+		return nil, fmt.Errorf("invalid timestamp")
+	}
+
+	buf := make([]byte, len(transportStreamTimestampOwner)+1+8)
+	n := copy(buf, transportStreamTimestampOwner)
+	n++
+
+	buf[n] = byte(f.Timestamp >> 56)
+	buf[n+1] = byte(f.Timestamp >> 48)
+	buf[n+2] = byte(f.Timestamp >> 40)
+	buf[n+3] = byte(f.Timestamp >> 32)
+	buf[n+4] = byte(f.Timestamp >> 24)
+	buf[n+5] = byte(f.Timestamp >> 16)
+	buf[n+6] = byte(f.Timestamp >> 8)
+	buf[n+7] = byte(f.Timestamp)
+
+	return buf, nil
+}
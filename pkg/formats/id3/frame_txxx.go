@@ -0,0 +1,54 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// FrameTXXX is a user defined text information frame.
+// Specification: ID3v2.4 native frames, 4.2.6
+type FrameTXXX struct {
+	Encoding    TextEncoding
+	Description string
+	Value       string
+}
+
+// FrameID implements Frame.
+func (FrameTXXX) FrameID() string {
+	return "TXXX"
+}
+
+// Unmarshal decodes a FrameTXXX.
+func (f *FrameTXXX) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	f.Encoding = TextEncoding(buf[0])
+	err := f.Encoding.validate()
+	if err != nil {
+		return err
+	}
+
+	f.Description, buf, err = readTerminatedString(f.Encoding, buf[1:])
+	if err != nil {
+		return err
+	}
+
+	f.Value, err = decodeString(f.Encoding, trimTerminators(f.Encoding, buf))
+	return err
+}
+
+// Marshal encodes a FrameTXXX.
+func (f FrameTXXX) Marshal() ([]byte, error) {
+	buf, err := appendTerminatedString([]byte{byte(f.Encoding)}, f.Encoding, f.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := encodeString(f.Encoding, f.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, value...), nil
+}
@@ -0,0 +1,31 @@
+package id3
+
+import (
+	"fmt"
+)
+
+// FrameUnknown is a frame whose content is not decoded.
+type FrameUnknown struct {
+	ID   string
+	Data []byte
+}
+
+// FrameID implements Frame.
+func (f FrameUnknown) FrameID() string {
+	return f.ID
+}
+
+// Unmarshal decodes a FrameUnknown.
+func (f *FrameUnknown) Unmarshal(buf []byte) error {
+	f.Data = buf
+	return nil
+}
+
+// Marshal encodes a FrameUnknown.
+func (f FrameUnknown) Marshal() ([]byte, error) {
+	if len(f.ID) != 4 || !isValidFrameID([]byte(f.ID)) {
+		return nil, fmt.Errorf("invalid frame ID: '%s'", f.ID)
+	}
+
+	return f.Data, nil
+}
@@ -0,0 +1,2 @@
+// Package id3 contains utilities to work with ID3v2 tags.
+package id3
@@ -0,0 +1,59 @@
+package id3
+
+import (
+	"fmt"
+)
+
+func readSyncsafe(buf []byte) (uint32, error) {
+	var v uint32
+	for _, b := range buf {
+		if (b & 0x80) != 0 {
+			return 0, fmt.Errorf("invalid syncsafe integer")
+		}
+		v = v<<7 | uint32(b)
+	}
+	return v, nil
+}
+
+func writeSyncsafe(buf []byte, v uint32) {
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = byte(v & 0x7F)
+		v >>= 7
+	}
+}
+
+// unsynchronise applies the unsynchronisation scheme,
+// that prevents false MPEG sync words from appearing inside the tag.
+func unsynchronise(buf []byte) []byte {
+	n := 0
+	for i, b := range buf {
+		if b == 0xFF && (i == len(buf)-1 || buf[i+1] == 0x00 || buf[i+1] >= 0xE0) {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return buf
+	}
+
+	ret := make([]byte, 0, len(buf)+n)
+	for i, b := range buf {
+		ret = append(ret, b)
+		if b == 0xFF && (i == len(buf)-1 || buf[i+1] == 0x00 || buf[i+1] >= 0xE0) {
+			ret = append(ret, 0x00)
+		}
+	}
+	return ret
+}
+
+// resynchronise reverts the unsynchronisation scheme.
+func resynchronise(buf []byte) []byte {
+	ret := make([]byte, 0, len(buf))
+	for i := 0; i < len(buf); i++ {
+		ret = append(ret, buf[i])
+		if buf[i] == 0xFF && i < (len(buf)-1) && buf[i+1] == 0x00 {
+			i++
+		}
+	}
+	return ret
+}
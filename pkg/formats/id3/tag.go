@@ -0,0 +1,367 @@
+package id3
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+const (
+	headerSize      = 10
+	frameHeaderSize = 10
+)
+
+// tag header flags.
+const (
+	flagUnsynchronisation = 0x80
+	flagExtendedHeader    = 0x40
+	flagExperimental      = 0x20
+	flagFooter            = 0x10
+)
+
+// Tag is an ID3v2.3 or ID3v2.4 tag.
+// Specification: ID3v2.3; ID3v2.4 main structure
+type Tag struct {
+	MajorVersion      uint8 // 3 or 4
+	Revision          uint8
+	Unsynchronisation bool
+	Experimental      bool
+	ExtendedHeader    *ExtendedHeader
+	Footer            bool // ID3v2.4 only
+	Frames            []Frame
+	Padding           int
+}
+
+// Size returns the size of the tag that starts at the beginning of buf,
+// including header and footer.
+func Size(buf []byte) (int, error) {
+	if len(buf) < headerSize {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 'I' || buf[1] != 'D' || buf[2] != '3' {
+		return 0, fmt.Errorf("invalid tag identifier")
+	}
+
+	size, err := readSyncsafe(buf[6:10])
+	if err != nil {
+		return 0, err
+	}
+
+	n := headerSize + int(size)
+	if buf[3] == 4 && (buf[5]&flagFooter) != 0 {
+		n += headerSize
+	}
+
+	return n, nil
+}
+
+// Unmarshal decodes a Tag.
+func (t *Tag) Unmarshal(buf []byte) error {
+	size, err := Size(buf)
+	if err != nil {
+		return err
+	}
+
+	t.MajorVersion = buf[3]
+	t.Revision = buf[4]
+
+	if t.MajorVersion != 3 && t.MajorVersion != 4 {
+		return fmt.Errorf("unsupported version: ID3v2.%d", t.MajorVersion)
+	}
+
+	flags := buf[5]
+	t.Unsynchronisation = (flags & flagUnsynchronisation) != 0
+	t.Experimental = (flags & flagExperimental) != 0
+
+	if t.MajorVersion == 4 {
+		t.Footer = (flags & flagFooter) != 0
+	} else {
+		t.Footer = false
+	}
+
+	if len(buf) < size {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	body := buf[headerSize:size]
+	if t.Footer {
+		body = body[:len(body)-headerSize]
+	}
+
+	// in ID3v2.3, unsynchronisation is applied to the whole tag.
+	if t.MajorVersion == 3 && t.Unsynchronisation {
+		body = resynchronise(body)
+	}
+
+	var expectedCRC uint32
+	var paddingSize uint32
+
+	if (flags & flagExtendedHeader) != 0 {
+		t.ExtendedHeader = &ExtendedHeader{}
+		var n int
+		n, expectedCRC, paddingSize, err = t.ExtendedHeader.unmarshal(t.MajorVersion, body)
+		if err != nil {
+			return err
+		}
+		body = body[n:]
+
+		if t.ExtendedHeader.HasCRC {
+			crcBody := body
+			if t.MajorVersion == 3 {
+				if int(paddingSize) > len(body) {
+					return fmt.Errorf("invalid padding size")
+				}
+				crcBody = body[:len(body)-int(paddingSize)]
+			}
+
+			crc := crc32.ChecksumIEEE(crcBody)
+			if crc != expectedCRC {
+				return fmt.Errorf("CRC mismatch: expected %08x, got %08x", expectedCRC, crc)
+			}
+		}
+	} else {
+		t.ExtendedHeader = nil
+	}
+
+	t.Frames = nil
+
+	for len(body) > 0 {
+		// padding
+		if body[0] == 0 {
+			break
+		}
+
+		if len(body) < frameHeaderSize {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		var frame Frame
+		var n int
+		frame, n, err = t.unmarshalFrame(body)
+		if err != nil {
+			return err
+		}
+
+		t.Frames = append(t.Frames, frame)
+		body = body[n:]
+	}
+
+	for _, b := range body {
+		if b != 0 {
+			return fmt.Errorf("invalid padding")
+		}
+	}
+	t.Padding = len(body)
+
+	return nil
+}
+
+func (t Tag) unmarshalFrame(buf []byte) (Frame, int, error) {
+	id := buf[:4]
+	if !isValidFrameID(id) {
+		return nil, 0, fmt.Errorf("invalid frame ID: '%s'", id)
+	}
+
+	var size int
+
+	if t.MajorVersion == 3 {
+		size = int(uint32(buf[4])<<24 | uint32(buf[5])<<16 | uint32(buf[6])<<8 | uint32(buf[7]))
+	} else {
+		tmp, err := readSyncsafe(buf[4:8])
+		if err != nil {
+			return nil, 0, err
+		}
+		size = int(tmp)
+	}
+
+	if len(buf) < (frameHeaderSize + size) {
+		return nil, 0, fmt.Errorf("not enough bytes")
+	}
+
+	flags := buf[9]
+	data := buf[frameHeaderSize : frameHeaderSize+size]
+
+	if t.MajorVersion == 3 {
+		if (flags & 0xC0) != 0 {
+			return nil, 0, fmt.Errorf("compressed or encrypted frames are not supported")
+		}
+
+		// grouping identity
+		if (flags & 0x20) != 0 {
+			if len(data) < 1 {
+				return nil, 0, fmt.Errorf("not enough bytes")
+			}
+			data = data[1:]
+		}
+	} else {
+		if (flags & 0x0C) != 0 {
+			return nil, 0, fmt.Errorf("compressed or encrypted frames are not supported")
+		}
+
+		// grouping identity
+		if (flags & 0x40) != 0 {
+			if len(data) < 1 {
+				return nil, 0, fmt.Errorf("not enough bytes")
+			}
+			data = data[1:]
+		}
+
+		// data length indicator
+		if (flags & 0x01) != 0 {
+			if len(data) < 4 {
+				return nil, 0, fmt.Errorf("not enough bytes")
+			}
+			data = data[4:]
+		}
+
+		if t.Unsynchronisation || (flags&0x02) != 0 {
+			data = resynchronise(data)
+		}
+	}
+
+	frame := newFrame(string(id), data)
+
+	err := frame.Unmarshal(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid frame '%s': %w", id, err)
+	}
+
+	return frame, frameHeaderSize + size, nil
+}
+
+func (t Tag) marshalFrames() ([]byte, error) {
+	var buf []byte
+
+	for _, frame := range t.Frames {
+		id := frame.FrameID()
+		if len(id) != 4 || !isValidFrameID([]byte(id)) {
+			return nil, fmt.Errorf("invalid frame ID: '%s'", id)
+		}
+
+		data, err := frame.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		var flags byte
+
+		if t.MajorVersion == 4 && t.Unsynchronisation {
+			data = unsynchronise(data)
+			flags |= 0x02
+		}
+
+		header := make([]byte, frameHeaderSize)
+		copy(header, id)
+
+		if t.MajorVersion == 3 {
+			size := uint32(len(data))
+			header[4] = byte(size >> 24)
+			header[5] = byte(size >> 16)
+			header[6] = byte(size >> 8)
+			header[7] = byte(size)
+		} else {
+			if len(data) >= (1 << 28) {
+				return nil, fmt.Errorf("frame is too big")
+			}
+			writeSyncsafe(header[4:8], uint32(len(data)))
+		}
+
+		header[9] = flags
+
+		buf = append(buf, header...)
+		buf = append(buf, data...)
+	}
+
+	return buf, nil
+}
+
+// Marshal encodes a Tag.
+func (t Tag) Marshal() ([]byte, error) {
+	if t.MajorVersion != 3 && t.MajorVersion != 4 {
+		return nil, fmt.Errorf("unsupported version: ID3v2.%d", t.MajorVersion)
+	}
+
+	if t.Footer && t.MajorVersion != 4 {
+		return nil, fmt.Errorf("footer is supported in ID3v2.4 only")
+	}
+
+	if t.Footer && t.Padding != 0 {
+		return nil, fmt.Errorf("padding cannot be used together with a footer")
+	}
+
+	if t.Padding < 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+
+	frames, err := t.marshalFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]byte, len(frames)+t.Padding)
+	copy(content, frames)
+
+	var body []byte
+
+	if t.ExtendedHeader != nil {
+		var crc uint32
+		if t.ExtendedHeader.HasCRC {
+			if t.MajorVersion == 3 {
+				crc = crc32.ChecksumIEEE(frames)
+			} else {
+				crc = crc32.ChecksumIEEE(content)
+			}
+		}
+
+		body = make([]byte, t.ExtendedHeader.marshalSize(t.MajorVersion))
+		err = t.ExtendedHeader.marshalTo(t.MajorVersion, body, crc, uint32(t.Padding))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body = append(body, content...)
+
+	if t.MajorVersion == 3 && t.Unsynchronisation {
+		body = unsynchronise(body)
+	}
+
+	if len(body) >= (1 << 28) {
+		return nil, fmt.Errorf("tag is too big")
+	}
+
+	var flags byte
+	if t.Unsynchronisation {
+		flags |= flagUnsynchronisation
+	}
+	if t.ExtendedHeader != nil {
+		flags |= flagExtendedHeader
+	}
+	if t.Experimental {
+		flags |= flagExperimental
+	}
+	if t.Footer {
+		flags |= flagFooter
+	}
+
+	n := headerSize + len(body)
+	if t.Footer {
+		n += headerSize
+	}
+
+	buf := make([]byte, n)
+	copy(buf, "ID3")
+	buf[3] = t.MajorVersion
+	buf[4] = t.Revision
+	buf[5] = flags
+	writeSyncsafe(buf[6:10], uint32(len(body)))
+	copy(buf[headerSize:], body)
+
+	if t.Footer {
+		footer := buf[headerSize+len(body):]
+		copy(footer, buf[:headerSize])
+		copy(footer, "3DI")
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,194 @@
+package id3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesTag = []struct {
+	name string
+	enc  []byte
+	dec  Tag
+}{
+	{
+		"v2.3",
+		[]byte{
+			0x49, 0x44, 0x33, 0x03, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x35, 0x54, 0x49, 0x54, 0x32, 0x00, 0x00,
+			0x00, 0x06, 0x00, 0x00, 0x00, 0x54, 0x69, 0x74,
+			0x6c, 0x65, 0x54, 0x58, 0x58, 0x58, 0x00, 0x00,
+			0x00, 0x17, 0x00, 0x00, 0x01, 0xff, 0xfe, 0x6b,
+			0x00, 0x65, 0x00, 0x79, 0x00, 0x00, 0x00, 0xff,
+			0xfe, 0x76, 0x00, 0x61, 0x00, 0x6c, 0x00, 0x75,
+			0x00, 0x65, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Tag{
+			MajorVersion: 3,
+			Frames: []Frame{
+				&FrameText{
+					ID:       "TIT2",
+					Encoding: TextEncodingISO88591,
+					Text:     "Title",
+				},
+				&FrameTXXX{
+					Encoding:    TextEncodingUTF16,
+					Description: "key",
+					Value:       "value",
+				},
+			},
+			Padding: 4,
+		},
+	},
+	{
+		"v2.3 unsynchronisation and crc",
+		[]byte{
+			0x49, 0x44, 0x33, 0x03, 0x00, 0xc0, 0x00, 0x00,
+			0x00, 0x26, 0x00, 0x00, 0x00, 0x0a, 0x80, 0x00,
+			0x00, 0x00, 0x00, 0x02, 0xc8, 0x32, 0x80, 0x3a,
+			0x50, 0x52, 0x49, 0x56, 0x00, 0x00, 0x00, 0x0a,
+			0x00, 0x00, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x00,
+			0xff, 0x00, 0xe0, 0x01, 0xff, 0x00, 0x00, 0x00,
+		},
+		Tag{
+			MajorVersion:      3,
+			Unsynchronisation: true,
+			ExtendedHeader: &ExtendedHeader{
+				HasCRC: true,
+			},
+			Frames: []Frame{
+				&FramePRIV{
+					Owner: "owner",
+					Data:  []byte{0xff, 0xe0, 0x01, 0xff},
+				},
+			},
+			Padding: 2,
+		},
+	},
+	{
+		"v2.4 transport stream timestamp",
+		[]byte{
+			0x49, 0x44, 0x33, 0x04, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x3f, 0x50, 0x52, 0x49, 0x56, 0x00, 0x00,
+			0x00, 0x35, 0x00, 0x00, 0x63, 0x6f, 0x6d, 0x2e,
+			0x61, 0x70, 0x70, 0x6c, 0x65, 0x2e, 0x73, 0x74,
+			0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2e,
+			0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+			0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+			0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0d, 0xbb,
+			0xa0,
+		},
+		Tag{
+			MajorVersion: 4,
+			Frames: []Frame{
+				&FrameTransportStreamTimestamp{
+					Timestamp: 900000,
+				},
+			},
+		},
+	},
+	{
+		"v2.4 extended header, unsynchronisation and footer",
+		[]byte{
+			0x49, 0x44, 0x33, 0x04, 0x00, 0xd0, 0x00, 0x00,
+			0x00, 0x6b, 0x00, 0x00, 0x00, 0x0f, 0x01, 0x70,
+			0x00, 0x05, 0x0f, 0x2f, 0x68, 0x1c, 0x3f, 0x01,
+			0x12, 0x54, 0x50, 0x45, 0x31, 0x00, 0x00, 0x00,
+			0x08, 0x00, 0x02, 0x03, 0xc3, 0x84, 0x72, 0x74,
+			0x69, 0x73, 0x74, 0x54, 0x43, 0x4f, 0x4e, 0x00,
+			0x00, 0x00, 0x11, 0x00, 0x02, 0x02, 0x00, 0x52,
+			0x00, 0x6f, 0x00, 0x63, 0x00, 0x6b, 0x00, 0x00,
+			0x00, 0x50, 0x00, 0x6f, 0x00, 0x70, 0x41, 0x50,
+			0x49, 0x43, 0x00, 0x00, 0x00, 0x18, 0x00, 0x02,
+			0x00, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2f, 0x6a,
+			0x70, 0x65, 0x67, 0x00, 0x03, 0x63, 0x6f, 0x76,
+			0x65, 0x72, 0x00, 0xff, 0xd8, 0xff, 0x00, 0xe0,
+			0x4d, 0x43, 0x44, 0x49, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x02, 0x01, 0x02, 0x03, 0x33, 0x44, 0x49,
+			0x04, 0x00, 0xd0, 0x00, 0x00, 0x00, 0x6b,
+		},
+		Tag{
+			MajorVersion:      4,
+			Unsynchronisation: true,
+			ExtendedHeader: &ExtendedHeader{
+				IsUpdate:        true,
+				HasCRC:          true,
+				HasRestrictions: true,
+				Restrictions:    0x12,
+			},
+			Footer: true,
+			Frames: []Frame{
+				&FrameText{
+					ID:       "TPE1",
+					Encoding: TextEncodingUTF8,
+					Text:     "Ärtist",
+				},
+				&FrameText{
+					ID:       "TCON",
+					Encoding: TextEncodingUTF16BE,
+					Text:     "Rock\x00Pop",
+				},
+				&FrameAPIC{
+					Encoding:    TextEncodingISO88591,
+					MIMEType:    "image/jpeg",
+					PictureType: 3,
+					Description: "cover",
+					Data:        []byte{0xff, 0xd8, 0xff, 0xe0},
+				},
+				&FrameUnknown{
+					ID:   "MCDI",
+					Data: []byte{1, 2, 3},
+				},
+			},
+		},
+	},
+}
+
+func TestTagUnmarshal(t *testing.T) {
+	for _, ca := range casesTag {
+		t.Run(ca.name, func(t *testing.T) {
+			size, err := Size(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.enc), size)
+
+			var tag Tag
+			err = tag.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, tag)
+		})
+	}
+}
+
+func TestTagMarshal(t *testing.T) {
+	for _, ca := range casesTag {
+		t.Run(ca.name, func(t *testing.T) {
+			buf, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, buf)
+		})
+	}
+}
+
+func TestTagUnmarshalCRCMismatch(t *testing.T) {
+	buf := append([]byte(nil), casesTag[3].enc...)
+	buf[len(buf)-12] = 0x04
+
+	var tag Tag
+	err := tag.Unmarshal(buf)
+	require.EqualError(t, err, "CRC mismatch: expected f5fa0e3f, got a3a0a9b9")
+}
+
+func FuzzTagUnmarshal(f *testing.F) {
+	for _, ca := range casesTag {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var tag Tag
+		err := tag.Unmarshal(b)
+		if err == nil {
+			tag.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package id3
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// TextEncoding is the encoding of a string inside a frame.
+type TextEncoding uint8
+
+// text encodings.
+const (
+	TextEncodingISO88591 TextEncoding = 0
+	TextEncodingUTF16    TextEncoding = 1 // UTF-16 with BOM
+	TextEncodingUTF16BE  TextEncoding = 2 // ID3v2.4 only
+	TextEncodingUTF8     TextEncoding = 3 // ID3v2.4 only
+)
+
+func (e TextEncoding) terminatorSize() int {
+	if e == TextEncodingUTF16 || e == TextEncodingUTF16BE {
+		return 2
+	}
+	return 1
+}
+
+func (e TextEncoding) validate() error {
+	if e > TextEncodingUTF8 {
+		return fmt.Errorf("invalid text encoding: %d", e)
+	}
+	return nil
+}
+
+func decodeString(enc TextEncoding, buf []byte) (string, error) {
+	switch enc {
+	case TextEncodingISO88591:
+		runes := make([]rune, len(buf))
+		for i, b := range buf {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+
+	case TextEncodingUTF16, TextEncodingUTF16BE:
+		if (len(buf) % 2) != 0 {
+			return "", fmt.Errorf("invalid UTF-16 string")
+		}
+
+		littleEndian := false
+
+		if enc == TextEncodingUTF16 && len(buf) >= 2 {
+			switch {
+			case buf[0] == 0xFF && buf[1] == 0xFE:
+				littleEndian = true
+				buf = buf[2:]
+
+			case buf[0] == 0xFE && buf[1] == 0xFF:
+				buf = buf[2:]
+			}
+		}
+
+		u := make([]uint16, len(buf)/2)
+		for i := range u {
+			if littleEndian {
+				u[i] = uint16(buf[i*2+1])<<8 | uint16(buf[i*2])
+			} else {
+				u[i] = uint16(buf[i*2])<<8 | uint16(buf[i*2+1])
+			}
+		}
+		return string(utf16.Decode(u)), nil
+
+	case TextEncodingUTF8:
+		if !utf8.Valid(buf) {
+			return "", fmt.Errorf("invalid UTF-8 string")
+		}
+		return string(buf), nil
+
+	default:
+		return "", fmt.Errorf("invalid text encoding: %d", enc)
+	}
+}
+
+func encodeString(enc TextEncoding, s string) ([]byte, error) {
+	switch enc {
+	case TextEncodingISO88591:
+		buf := make([]byte, 0, len(s))
+		for _, r := range s {
+			if r > 0xFF {
+				return nil, fmt.Errorf("string cannot be encoded with ISO-8859-1")
+			}
+			buf = append(buf, byte(r))
+		}
+		return buf, nil
+
+	case TextEncodingUTF16:
+		u := utf16.Encode([]rune(s))
+		buf := make([]byte, 2+len(u)*2)
+		buf[0] = 0xFF
+		buf[1] = 0xFE
+		for i, v := range u {
+			buf[2+i*2] = byte(v)
+			buf[2+i*2+1] = byte(v >> 8)
+		}
+		return buf, nil
+
+	case TextEncodingUTF16BE:
+		u := utf16.Encode([]rune(s))
+		buf := make([]byte, len(u)*2)
+		for i, v := range u {
+			buf[i*2] = byte(v >> 8)
+			buf[i*2+1] = byte(v)
+		}
+		return buf, nil
+
+	case TextEncodingUTF8:
+		return []byte(s), nil
+
+	default:
+		return nil, fmt.Errorf("invalid text encoding: %d", enc)
+	}
+}
+
+// readTerminatedString reads a string followed by a terminator.
+// It returns the string and the remaining bytes.
+func readTerminatedString(enc TextEncoding, buf []byte) (string, []byte, error) {
+	size := enc.terminatorSize()
+
+	for i := 0; (i + size) <= len(buf); i += size {
+		if buf[i] == 0 && (size == 1 || buf[i+1] == 0) {
+			s, err := decodeString(enc, buf[:i])
+			if err != nil {
+				return "", nil, err
+			}
+			return s, buf[i+size:], nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("string terminator not found")
+}
+
+// trimTerminators removes trailing terminators from a string that is not required to be terminated.
+func trimTerminators(enc TextEncoding, buf []byte) []byte {
+	size := enc.terminatorSize()
+
+	for len(buf) >= size && buf[len(buf)-1] == 0 && (size == 1 || buf[len(buf)-2] == 0) {
+		buf = buf[:len(buf)-size]
+	}
+
+	return buf
+}
+
+func appendTerminatedString(buf []byte, enc TextEncoding, s string) ([]byte, error) {
+	enc2, err := encodeString(enc, s)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = append(buf, enc2...)
+
+	for i := 0; i < enc.terminatorSize(); i++ {
+		buf = append(buf, 0)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,66 @@
+package id3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeString(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  TextEncoding
+		buf  []byte
+		dec  string
+	}{
+		{
+			"iso-8859-1",
+			TextEncodingISO88591,
+			[]byte{0x41, 0xe9},
+			"Aé",
+		},
+		{
+			"utf-16 little endian",
+			TextEncodingUTF16,
+			[]byte{0xff, 0xfe, 0x41, 0x00, 0xe9, 0x00},
+			"Aé",
+		},
+		{
+			"utf-16 big endian",
+			TextEncodingUTF16,
+			[]byte{0xfe, 0xff, 0x00, 0x41, 0x00, 0xe9},
+			"Aé",
+		},
+		{
+			"utf-16 without bom",
+			TextEncodingUTF16,
+			[]byte{0x00, 0x41, 0x00, 0xe9},
+			"Aé",
+		},
+		{
+			"utf-16 surrogate pair",
+			TextEncodingUTF16BE,
+			[]byte{0xd8, 0x3c, 0xdf, 0xb5},
+			"🎵",
+		},
+		{
+			"utf-8",
+			TextEncodingUTF8,
+			[]byte{0x41, 0xc3, 0xa9},
+			"Aé",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := decodeString(ca.enc, ca.buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestUnsynchronise(t *testing.T) {
+	dec := []byte{0xff, 0xe0, 0x01, 0xff, 0x00, 0x02, 0xff}
+	enc := []byte{0xff, 0x00, 0xe0, 0x01, 0xff, 0x00, 0x00, 0x02, 0xff, 0x00}
+	require.Equal(t, enc, unsynchronise(dec))
+	require.Equal(t, dec, resynchronise(enc))
+}
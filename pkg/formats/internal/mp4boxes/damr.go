@@ -0,0 +1,43 @@
+package mp4boxes
+
+import (
+	"github.com/abema/go-mp4"
+)
+
+/*************************** samr, sawb ****************************/
+
+// BoxTypeSamr returns the type of the samr box.
+func BoxTypeSamr() mp4.BoxType { return mp4.StrToBoxType("samr") }
+
+// BoxTypeSawb returns the type of the sawb box.
+func BoxTypeSawb() mp4.BoxType { return mp4.StrToBoxType("sawb") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeSamr())
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeSawb())
+}
+
+/*************************** damr ****************************/
+
+// BoxTypeDamr returns the type of the damr box.
+func BoxTypeDamr() mp4.BoxType { return mp4.StrToBoxType("damr") }
+
+func init() {
+	mp4.AddBoxDef(&Damr{})
+}
+
+// Damr is a AMRSpecificBox.
+// Specification: 3GPP TS 26.244, 6.7
+type Damr struct {
+	mp4.Box
+	Vendor           [4]byte `mp4:"0,size=8"`
+	DecoderVersion   uint8   `mp4:"1,size=8"`
+	ModeSet          uint16  `mp4:"2,size=16"`
+	ModeChangePeriod uint8   `mp4:"3,size=8"`
+	FramesPerSample  uint8   `mp4:"4,size=8"`
+}
+
+// GetType implements mp4.IBox.
+func (Damr) GetType() mp4.BoxType {
+	return BoxTypeDamr()
+}
@@ -0,0 +1,73 @@
+package mp4boxes
+
+import (
+	"github.com/abema/go-mp4"
+)
+
+/*************************** ec-3 ****************************/
+
+// BoxTypeEC3 returns the type of the ec-3 box.
+func BoxTypeEC3() mp4.BoxType { return mp4.StrToBoxType("ec-3") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeEC3())
+}
+
+/*************************** dec3 ****************************/
+
+// BoxTypeDec3 returns the type of the dec3 box.
+func BoxTypeDec3() mp4.BoxType { return mp4.StrToBoxType("dec3") }
+
+func init() {
+	mp4.AddBoxDef(&Dec3{})
+}
+
+// Dec3Substream is an independent substream of a dec3 box.
+type Dec3Substream struct {
+	mp4.BaseCustomFieldObject
+	Fscod     uint8  `mp4:"0,size=2"`
+	Bsid      uint8  `mp4:"1,size=5"`
+	Reserved  uint8  `mp4:"2,size=1,const=0"`
+	Asvc      uint8  `mp4:"3,size=1"`
+	Bsmod     uint8  `mp4:"4,size=3"`
+	Acmod     uint8  `mp4:"5,size=3"`
+	LfeOn     uint8  `mp4:"6,size=1"`
+	Reserved2 uint8  `mp4:"7,size=3,const=0"`
+	NumDepSub uint8  `mp4:"8,size=4"`
+	ChanLoc   uint16 `mp4:"9,size=9,opt=dynamic"`
+	Reserved3 uint8  `mp4:"10,size=1,opt=dynamic,const=0"`
+}
+
+// IsOptFieldEnabled implements mp4.ICustomFieldObject.
+func (s Dec3Substream) IsOptFieldEnabled(name string, _ mp4.Context) bool {
+	switch name {
+	case "ChanLoc":
+		return s.NumDepSub > 0
+	case "Reserved3":
+		return s.NumDepSub == 0
+	}
+	return false
+}
+
+// Dec3 is a EC3SpecificBox.
+// Specification: ETSI TS 102 366, F.6
+type Dec3 struct {
+	mp4.Box
+	DataRate   uint16          `mp4:"0,size=13"`
+	NumIndSub  uint8           `mp4:"1,size=3"`
+	Substreams []Dec3Substream `mp4:"2,len=dynamic"`
+}
+
+// GetType implements mp4.IBox.
+func (Dec3) GetType() mp4.BoxType {
+	return BoxTypeDec3()
+}
+
+// GetFieldLength implements mp4.ICustomFieldObject.
+func (b Dec3) GetFieldLength(name string, _ mp4.Context) uint {
+	switch name {
+	case "Substreams":
+		return uint(b.NumIndSub) + 1
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package mp4boxes
+
+import (
+	"github.com/abema/go-mp4"
+)
+
+/*************************** fLaC ****************************/
+
+// BoxTypeFLaC returns the type of the fLaC box.
+func BoxTypeFLaC() mp4.BoxType { return mp4.StrToBoxType("fLaC") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeFLaC())
+}
+
+/*************************** dfLa ****************************/
+
+// BoxTypeDfLa returns the type of the dfLa box.
+func BoxTypeDfLa() mp4.BoxType { return mp4.StrToBoxType("dfLa") }
+
+func init() {
+	mp4.AddBoxDef(&DfLa{}, 0)
+}
+
+// DfLaMetadataBlock is a metadata block of a dfLa box.
+type DfLaMetadataBlock struct {
+	mp4.BaseCustomFieldObject
+	LastMetadataBlockFlag bool   `mp4:"0,size=1"`
+	BlockType             uint8  `mp4:"1,size=7"`
+	Length                uint32 `mp4:"2,size=24"`
+	BlockData             []byte `mp4:"3,size=8,len=dynamic"`
+}
+
+// GetFieldLength implements mp4.ICustomFieldObject.
+func (b DfLaMetadataBlock) GetFieldLength(name string, _ mp4.Context) uint {
+	switch name {
+	case "BlockData":
+		return uint(b.Length)
+	}
+	return 0
+}
+
+// DfLa is a FLACSpecificBox.
+// Specification: Encapsulation of FLAC in ISO Base Media File Format, 3.3.2
+type DfLa struct {
+	mp4.FullBox    `mp4:"0,extend"`
+	MetadataBlocks []DfLaMetadataBlock `mp4:"1"`
+}
+
+// GetType implements mp4.IBox.
+func (DfLa) GetType() mp4.BoxType {
+	return BoxTypeDfLa()
+}
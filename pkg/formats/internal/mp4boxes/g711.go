@@ -0,0 +1,23 @@
+package mp4boxes
+
+import (
+	"github.com/abema/go-mp4"
+)
+
+/*************************** alaw ****************************/
+
+// BoxTypeAlaw returns the type of the alaw box.
+func BoxTypeAlaw() mp4.BoxType { return mp4.StrToBoxType("alaw") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeAlaw())
+}
+
+/*************************** ulaw ****************************/
+
+// BoxTypeUlaw returns the type of the ulaw box.
+func BoxTypeUlaw() mp4.BoxType { return mp4.StrToBoxType("ulaw") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, BoxTypeUlaw())
+}
@@ -0,0 +1,2 @@
+// Package mp4boxes contains MP4 boxes that are not provided by go-mp4.
+package mp4boxes
@@ -0,0 +1,133 @@
+package mp4boxes
+
+import (
+	"math/bits"
+
+	"github.com/abema/go-mp4"
+)
+
+/*************************** vvc1, vvi1 ****************************/
+
+// BoxTypeVvc1 returns the type of the vvc1 box.
+func BoxTypeVvc1() mp4.BoxType { return mp4.StrToBoxType("vvc1") }
+
+// BoxTypeVvi1 returns the type of the vvi1 box.
+func BoxTypeVvi1() mp4.BoxType { return mp4.StrToBoxType("vvi1") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.VisualSampleEntry{}, BoxTypeVvc1())
+	mp4.AddAnyTypeBoxDef(&mp4.VisualSampleEntry{}, BoxTypeVvi1())
+}
+
+/*************************** vvcC ****************************/
+
+const (
+	vvcNALUTypeOPI = 12
+	vvcNALUTypeDCI = 13
+)
+
+// BoxTypeVvcC returns the type of the vvcC box.
+func BoxTypeVvcC() mp4.BoxType { return mp4.StrToBoxType("vvcC") }
+
+func init() {
+	mp4.AddBoxDef(&VvcC{}, 0)
+}
+
+// VvcNaluArray is an array of NAL units of a vvcC box.
+type VvcNaluArray struct {
+	mp4.BaseCustomFieldObject
+	Completeness bool           `mp4:"0,size=1"`
+	Reserved     uint8          `mp4:"1,size=2,const=0"`
+	NaluType     uint8          `mp4:"2,size=5"`
+	NumNalus     uint16         `mp4:"3,size=16,opt=dynamic"`
+	Nalus        []mp4.HEVCNalu `mp4:"4,len=dynamic"`
+}
+
+// IsOptFieldEnabled implements mp4.ICustomFieldObject.
+func (a VvcNaluArray) IsOptFieldEnabled(name string, _ mp4.Context) bool {
+	switch name {
+	case "NumNalus":
+		return a.NaluType != vvcNALUTypeDCI && a.NaluType != vvcNALUTypeOPI
+	}
+	return false
+}
+
+// GetFieldLength implements mp4.ICustomFieldObject.
+func (a VvcNaluArray) GetFieldLength(name string, _ mp4.Context) uint {
+	switch name {
+	case "Nalus":
+		if a.NaluType == vvcNALUTypeDCI || a.NaluType == vvcNALUTypeOPI {
+			return 1
+		}
+		return uint(a.NumNalus)
+	}
+	return 0
+}
+
+// VvcC is a VvcConfigurationBox.
+// Specification: ISO 14496-15, 11.2.4.2
+type VvcC struct {
+	mp4.FullBox            `mp4:"0,extend"`
+	Reserved               uint8  `mp4:"1,size=5,const=31"`
+	LengthSizeMinusOne     uint8  `mp4:"2,size=2"`
+	PtlPresentFlag         bool   `mp4:"3,size=1"`
+	OlsIdx                 uint16 `mp4:"4,size=9,opt=dynamic"`
+	NumSublayers           uint8  `mp4:"5,size=3,opt=dynamic"`
+	ConstantFrameRate      uint8  `mp4:"6,size=2,opt=dynamic"`
+	ChromaFormatIdc        uint8  `mp4:"7,size=2,opt=dynamic"`
+	BitDepthMinus8         uint8  `mp4:"8,size=3,opt=dynamic"`
+	Reserved2              uint8  `mp4:"9,size=5,opt=dynamic,const=31"`
+	Reserved3              uint8  `mp4:"10,size=2,opt=dynamic,const=0"`
+	NumBytesConstraintInfo uint8  `mp4:"11,size=6,opt=dynamic"`
+	GeneralProfileIdc      uint8  `mp4:"12,size=7,opt=dynamic"`
+	GeneralTierFlag        bool   `mp4:"13,size=1,opt=dynamic"`
+	GeneralLevelIdc        uint8  `mp4:"14,size=8,opt=dynamic"`
+	// ptl_frame_only_constraint_flag, ptl_multilayer_enabled_flag and general_constraint_info.
+	GeneralConstraintInfo []uint8 `mp4:"15,size=8,len=dynamic,opt=dynamic"`
+	// ptl_sublayer_level_present_flag[i] for i = NumSublayers-2 to 0, followed by reserved bits.
+	SublayerLevelPresentFlags uint8          `mp4:"16,size=8,opt=dynamic"`
+	SublayerLevelIdc          []uint8        `mp4:"17,size=8,len=dynamic,opt=dynamic"`
+	PtlNumSubProfiles         uint8          `mp4:"18,size=8,opt=dynamic"`
+	GeneralSubProfileIdc      []uint32       `mp4:"19,size=32,len=dynamic,opt=dynamic"`
+	MaxPictureWidth           uint16         `mp4:"20,size=16,opt=dynamic"`
+	MaxPictureHeight          uint16         `mp4:"21,size=16,opt=dynamic"`
+	AvgFrameRate              uint16         `mp4:"22,size=16,opt=dynamic"`
+	NumOfNaluArrays           uint8          `mp4:"23,size=8"`
+	NaluArrays                []VvcNaluArray `mp4:"24,len=dynamic"`
+}
+
+// GetType implements mp4.IBox.
+func (VvcC) GetType() mp4.BoxType {
+	return BoxTypeVvcC()
+}
+
+// IsOptFieldEnabled implements mp4.ICustomFieldObject.
+func (b VvcC) IsOptFieldEnabled(name string, _ mp4.Context) bool {
+	switch name {
+	case "SublayerLevelPresentFlags":
+		return b.PtlPresentFlag && b.NumSublayers > 1
+	}
+	return b.PtlPresentFlag
+}
+
+// GetFieldLength implements mp4.ICustomFieldObject.
+func (b VvcC) GetFieldLength(name string, _ mp4.Context) uint {
+	switch name {
+	case "GeneralConstraintInfo":
+		return uint(b.NumBytesConstraintInfo)
+
+	case "SublayerLevelIdc":
+		if b.NumSublayers <= 1 {
+			return 0
+		}
+		mask := uint8(0xFF) << (9 - b.NumSublayers)
+		return uint(bits.OnesCount8(b.SublayerLevelPresentFlags & mask))
+
+	case "GeneralSubProfileIdc":
+		return uint(b.PtlNumSubProfiles)
+
+	case "NaluArrays":
+		return uint(b.NumOfNaluArrays)
+	}
+	return 0
+}
@@ -8,6 +8,10 @@ import (
 type CodecAC3 struct {
 	SampleRate   int
 	ChannelCount int
+
+	// use DVB signaling (private data with an AC-3 descriptor)
+	// instead of ATSC signaling.
+	DVB bool
 }
 
 // IsVideo implements Codec.
@@ -18,6 +22,18 @@ func (CodecAC3) IsVideo() bool {
 func (*CodecAC3) isCodec() {}
 
 func (c CodecAC3) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	if c.DVB {
+		return &astits.PMTElementaryStream{
+			ElementaryPID: pid,
+			StreamType:    astits.StreamTypePrivateData,
+			ElementaryStreamDescriptors: []*astits.Descriptor{{
+				Length: 1,
+				Tag:    astits.DescriptorTagAC3,
+				AC3:    &astits.DescriptorAC3{},
+			}},
+		}, nil
+	}
+
 	return &astits.PMTElementaryStream{
 		ElementaryPID: pid,
 		StreamType:    astits.StreamTypeAC3Audio,
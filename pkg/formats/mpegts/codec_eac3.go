@@ -0,0 +1,41 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecEAC3 is an E-AC-3 codec.
+type CodecEAC3 struct {
+	SampleRate   int
+	ChannelCount int
+
+	// use DVB signaling (private data with an enhanced_AC-3 descriptor)
+	// instead of ATSC signaling.
+	DVB bool
+}
+
+// IsVideo implements Codec.
+func (CodecEAC3) IsVideo() bool {
+	return false
+}
+
+func (*CodecEAC3) isCodec() {}
+
+func (c CodecEAC3) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	if c.DVB {
+		return &astits.PMTElementaryStream{
+			ElementaryPID: pid,
+			StreamType:    astits.StreamTypePrivateData,
+			ElementaryStreamDescriptors: []*astits.Descriptor{{
+				Length:      1,
+				Tag:         astits.DescriptorTagEnhancedAC3,
+				EnhancedAC3: &astits.DescriptorEnhancedAC3{},
+			}},
+		}, nil
+	}
+
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		StreamType:    astits.StreamTypeEAC3Audio,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecG711 is a G711 codec.
+// It is carried as private data with a registration descriptor,
+// whose additional identification info contains sample rate and channel count.
+type CodecG711 struct {
+	MULaw        bool
+	SampleRate   int
+	ChannelCount int
+}
+
+// IsVideo implements Codec.
+func (CodecG711) IsVideo() bool {
+	return false
+}
+
+func (*CodecG711) isCodec() {}
+
+func (c CodecG711) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	var formatIdentifier uint32
+	if c.MULaw {
+		formatIdentifier = ulawIdentifier
+	} else {
+		formatIdentifier = alawIdentifier
+	}
+
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		StreamType:    astits.StreamTypePrivateData,
+		ElementaryStreamDescriptors: []*astits.Descriptor{
+			{
+				Length: 4 + 5,
+				Tag:    astits.DescriptorTagRegistration,
+				Registration: &astits.DescriptorRegistration{
+					FormatIdentifier: formatIdentifier,
+					AdditionalIdentificationInfo: []byte{
+						byte(c.SampleRate >> 24),
+						byte(c.SampleRate >> 16),
+						byte(c.SampleRate >> 8),
+						byte(c.SampleRate),
+						byte(c.ChannelCount),
+					},
+				},
+			},
+		},
+	}, nil
+}
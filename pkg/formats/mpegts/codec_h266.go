@@ -0,0 +1,30 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// Specification: ISO 13818-1, Table 2-34
+const streamTypeH266Video astits.StreamType = 0x33
+
+// CodecH266 is a H266 codec.
+type CodecH266 struct {
+	// in Go, empty structs share the same pointer,
+	// therefore they cannot be used as map keys
+	// or in equality operations. Prevent this.
+	unused int //nolint:unused
+}
+
+// IsVideo implements Codec.
+func (CodecH266) IsVideo() bool {
+	return true
+}
+
+func (*CodecH266) isCodec() {}
+
+func (c CodecH266) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		StreamType:    streamTypeH266Video,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+const (
+	metadataDescriptorTag = 0x26
+	id3Identifier         = 'I'<<24 | 'D'<<16 | '3'<<8 | ' '
+)
+
+// metadata_descriptor that signals ID3 timed metadata.
+// Specification: ISO 13818-1, 2.6.60
+// Specification: Apple, Timed Metadata for HTTP Live Streaming
+var id3MetadataDescriptor = []byte{
+	0xff, 0xff, // metadata_application_format
+	'I', 'D', '3', ' ', // metadata_application_format_identifier
+	0xff,               // metadata_format
+	'I', 'D', '3', ' ', // metadata_format_identifier
+	0x00, // metadata_service_id
+	0x0f, // decoder_config_flags, DSM-CC_flag, reserved
+}
+
+func isID3MetadataDescriptor(buf []byte) bool {
+	if len(buf) < 2 {
+		return false
+	}
+
+	pos := 2
+	if buf[0] == 0xff && buf[1] == 0xff {
+		pos += 4
+	}
+
+	if len(buf) < (pos+5) || buf[pos] != 0xff {
+		return false
+	}
+	pos++
+
+	return uint32(buf[pos])<<24|uint32(buf[pos+1])<<16|uint32(buf[pos+2])<<8|uint32(buf[pos+3]) == id3Identifier
+}
+
+func findID3MetadataDescriptor(descriptors []*astits.Descriptor) bool {
+	for _, sd := range descriptors {
+		if sd.Tag == metadataDescriptorTag && sd.Unknown != nil &&
+			isID3MetadataDescriptor(sd.Unknown.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+// CodecMetadataID3 is a timed ID3 metadata codec.
+type CodecMetadataID3 struct {
+	// in Go, empty structs share the same pointer,
+	// therefore they cannot be used as map keys
+	// or in equality operations. Prevent this.
+	unused int //nolint:unused
+}
+
+// IsVideo implements Codec.
+func (CodecMetadataID3) IsVideo() bool {
+	return false
+}
+
+func (*CodecMetadataID3) isCodec() {}
+
+func (c CodecMetadataID3) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		StreamType:    astits.StreamTypeMetadata,
+		ElementaryStreamDescriptors: []*astits.Descriptor{
+			{
+				Length: uint8(len(id3MetadataDescriptor)),
+				Tag:    metadataDescriptorTag,
+				Unknown: &astits.DescriptorUnknown{
+					Tag:     metadataDescriptorTag,
+					Content: id3MetadataDescriptor,
+				},
+			},
+		},
+	}, nil
+}
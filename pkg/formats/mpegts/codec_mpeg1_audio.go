@@ -6,10 +6,10 @@ import (
 
 // CodecMPEG1Audio is a MPEG-1 Audio codec.
 type CodecMPEG1Audio struct {
-	// in Go, empty structs share the same pointer,
-	// therefore they cannot be used as map keys
-	// or in equality operations. Prevent this.
-	unused int //nolint:unused
+	// sample rate.
+	// streams with a sample rate below 32000 (MPEG-2 and MPEG-2.5)
+	// are signaled as MPEG-2 Audio.
+	SampleRate int
 }
 
 // IsVideo implements Codec.
@@ -20,6 +20,13 @@ func (CodecMPEG1Audio) IsVideo() bool {
 func (*CodecMPEG1Audio) isCodec() {}
 
 func (c CodecMPEG1Audio) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	if c.SampleRate != 0 && c.SampleRate < 32000 {
+		return &astits.PMTElementaryStream{
+			ElementaryPID: pid,
+			StreamType:    astits.StreamTypeMPEG2Audio,
+		}, nil
+	}
+
 	return &astits.PMTElementaryStream{
 		ElementaryPID: pid,
 		StreamType:    astits.StreamTypeMPEG1Audio,
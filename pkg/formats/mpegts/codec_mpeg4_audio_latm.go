@@ -0,0 +1,26 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+)
+
+// CodecMPEG4AudioLATM is a MPEG-4 Audio codec with LATM/LOAS transport.
+type CodecMPEG4AudioLATM struct {
+	Config mpeg4audio.StreamMuxConfig
+}
+
+// IsVideo implements Codec.
+func (CodecMPEG4AudioLATM) IsVideo() bool {
+	return false
+}
+
+func (*CodecMPEG4AudioLATM) isCodec() {}
+
+func (c CodecMPEG4AudioLATM) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		StreamType:    astits.StreamTypeAACLATMAudio,
+	}, nil
+}
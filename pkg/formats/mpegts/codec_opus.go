@@ -2,11 +2,17 @@ package mpegts
 
 import (
 	"github.com/asticode/go-astits"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
 )
 
 // CodecOpus is a Opus codec.
 type CodecOpus struct {
 	ChannelCount int
+
+	// channel mapping.
+	// If nil, the default channel mapping for ChannelCount is used.
+	ChannelMapping *opus.ChannelMapping
 }
 
 // IsVideo implements Codec.
@@ -17,6 +23,16 @@ func (CodecOpus) IsVideo() bool {
 func (*CodecOpus) isCodec() {}
 
 func (c CodecOpus) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	desc := opusAudioDescriptor{
+		ChannelCount:   c.ChannelCount,
+		ChannelMapping: c.ChannelMapping,
+	}
+
+	buf, err := desc.marshal()
+	if err != nil {
+		return nil, err
+	}
+
 	return &astits.PMTElementaryStream{
 		ElementaryPID: pid,
 		StreamType:    astits.StreamTypePrivateData,
@@ -29,11 +45,11 @@ func (c CodecOpus) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
 				},
 			},
 			{
-				Length: 2,
+				Length: uint8(1 + len(buf)),
 				Tag:    astits.DescriptorTagExtension,
 				Extension: &astits.DescriptorExtension{
-					Tag:     0x80,
-					Unknown: &[]uint8{uint8(c.ChannelCount)},
+					Tag:     opusAudioDescriptorTag,
+					Unknown: &buf,
 				},
 			},
 		},
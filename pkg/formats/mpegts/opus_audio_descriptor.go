@@ -0,0 +1,223 @@
+package mpegts
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+)
+
+const (
+	opusAudioDescriptorTag = 0x80
+
+	opusChannelConfigDualMono = 0x00
+	opusChannelConfigExtended = 0x80
+
+	// 0x81 - 0x88: mapping family 255 with 1 - 8 uncoupled channels
+	opusChannelConfigUncoupledFirst = 0x81
+	opusChannelConfigUncoupledLast  = 0x88
+)
+
+// number of bits needed to store values between 0 and v-1.
+func ceilLog2(v int) int {
+	n := 0
+	for (1 << n) < v {
+		n++
+	}
+	return n
+}
+
+// fields can have a size of zero bits, that must not be read or written.
+func readBitsOrZero(buf []byte, pos *int, n int) (uint64, error) {
+	if n == 0 {
+		return 0, nil
+	}
+	return bits.ReadBits(buf, pos, n)
+}
+
+func writeBitsOrZero(buf []byte, pos *int, v uint64, n int) {
+	if n != 0 {
+		bits.WriteBitsUnsafe(buf, pos, v, n)
+	}
+}
+
+func opusDualMonoChannelMapping() *opus.ChannelMapping {
+	return &opus.ChannelMapping{
+		Family:      255,
+		StreamCount: 2,
+		Table:       []uint8{0, 1},
+	}
+}
+
+func opusUncoupledChannelMapping(channelCount int) *opus.ChannelMapping {
+	table := make([]uint8, channelCount)
+	for i := range table {
+		table[i] = uint8(i)
+	}
+
+	return &opus.ChannelMapping{
+		Family:      255,
+		StreamCount: uint8(channelCount),
+		Table:       table,
+	}
+}
+
+// Specification: ETSI TS Opus 0.1.3-draft, 6.1
+type opusAudioDescriptor struct {
+	ChannelCount   int
+	ChannelMapping *opus.ChannelMapping
+}
+
+func (d *opusAudioDescriptor) unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	channelConfigCode := buf[0]
+
+	switch {
+	case channelConfigCode == opusChannelConfigDualMono:
+		d.ChannelCount = 2
+		d.ChannelMapping = opusDualMonoChannelMapping()
+
+	case channelConfigCode <= 8:
+		d.ChannelCount = int(channelConfigCode)
+		d.ChannelMapping = nil
+
+	case channelConfigCode >= opusChannelConfigUncoupledFirst && channelConfigCode <= opusChannelConfigUncoupledLast:
+		d.ChannelCount = int(channelConfigCode - opusChannelConfigExtended)
+		d.ChannelMapping = opusUncoupledChannelMapping(d.ChannelCount)
+
+	case channelConfigCode == opusChannelConfigExtended:
+		if len(buf) < 3 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		d.ChannelCount = int(buf[1])
+		if d.ChannelCount == 0 {
+			return fmt.Errorf("invalid channel count")
+		}
+
+		family := buf[2]
+		if family == 0 {
+			d.ChannelMapping = nil
+			return nil
+		}
+
+		pos := 3 * 8
+
+		tmp, err := readBitsOrZero(buf, &pos, ceilLog2(d.ChannelCount))
+		if err != nil {
+			return err
+		}
+		streamCount := int(tmp) + 1
+
+		tmp, err = readBitsOrZero(buf, &pos, ceilLog2(streamCount+1))
+		if err != nil {
+			return err
+		}
+		coupledCount := int(tmp)
+
+		if coupledCount > streamCount {
+			return fmt.Errorf("coupled count is greater than stream count")
+		}
+
+		mappingBits := ceilLog2(streamCount + coupledCount + 1)
+		table := make([]uint8, d.ChannelCount)
+
+		for i := range table {
+			tmp, err = readBitsOrZero(buf, &pos, mappingBits)
+			if err != nil {
+				return err
+			}
+
+			// the highest value represents a silent channel
+			if int(tmp) >= (streamCount + coupledCount) {
+				table[i] = 255
+			} else {
+				table[i] = uint8(tmp)
+			}
+		}
+
+		d.ChannelMapping = &opus.ChannelMapping{
+			Family:       family,
+			StreamCount:  uint8(streamCount),
+			CoupledCount: uint8(coupledCount),
+			Table:        table,
+		}
+
+	default:
+		return fmt.Errorf("unsupported channel config code: %d", channelConfigCode)
+	}
+
+	return nil
+}
+
+func (d opusAudioDescriptor) isDefaultMapping() bool {
+	if d.ChannelCount < 1 || d.ChannelCount > 8 {
+		return false
+	}
+
+	if d.ChannelMapping == nil {
+		return true
+	}
+
+	def, _ := opus.DefaultChannelMapping(d.ChannelCount)
+	return d.ChannelMapping.Equal(*def)
+}
+
+func (d opusAudioDescriptor) marshal() ([]byte, error) {
+	if d.isDefaultMapping() {
+		return []byte{uint8(d.ChannelCount)}, nil
+	}
+
+	if d.ChannelCount == 2 && d.ChannelMapping.Equal(*opusDualMonoChannelMapping()) {
+		return []byte{opusChannelConfigDualMono}, nil
+	}
+
+	if d.ChannelCount < 1 || d.ChannelCount > 255 {
+		return nil, fmt.Errorf("invalid channel count")
+	}
+
+	if d.ChannelCount <= 8 && d.ChannelMapping != nil &&
+		d.ChannelMapping.Equal(*opusUncoupledChannelMapping(d.ChannelCount)) {
+		return []byte{uint8(opusChannelConfigExtended + d.ChannelCount)}, nil
+	}
+
+	if d.ChannelMapping == nil || d.ChannelMapping.Family == 0 {
+		return []byte{opusChannelConfigExtended, uint8(d.ChannelCount), 0}, nil
+	}
+
+	m := d.ChannelMapping
+	streamCount := int(m.StreamCount)
+	coupledCount := int(m.CoupledCount)
+
+	if streamCount < 1 || streamCount > d.ChannelCount || coupledCount > streamCount ||
+		len(m.Table) != d.ChannelCount {
+		return nil, fmt.Errorf("invalid channel mapping")
+	}
+
+	mappingBits := ceilLog2(streamCount + coupledCount + 1)
+	n := ceilLog2(d.ChannelCount) + ceilLog2(streamCount+1) + mappingBits*d.ChannelCount
+
+	buf := make([]byte, 3+(n+7)/8)
+	buf[0] = opusChannelConfigExtended
+	buf[1] = uint8(d.ChannelCount)
+	buf[2] = m.Family
+	pos := 3 * 8
+
+	writeBitsOrZero(buf, &pos, uint64(streamCount-1), ceilLog2(d.ChannelCount))
+	writeBitsOrZero(buf, &pos, uint64(coupledCount), ceilLog2(streamCount+1))
+
+	for _, v := range m.Table {
+		if v == 255 {
+			v = uint8(streamCount + coupledCount)
+		} else if int(v) >= (streamCount + coupledCount) {
+			return nil, fmt.Errorf("invalid channel mapping")
+		}
+
+		writeBitsOrZero(buf, &pos, uint64(v), mappingBits)
+	}
+
+	return buf, nil
+}
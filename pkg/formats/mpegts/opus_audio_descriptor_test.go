@@ -0,0 +1,113 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+)
+
+var opusAudioDescriptorCases = []struct {
+	name string
+	dec  opusAudioDescriptor
+	enc  []byte
+}{
+	{
+		"stereo",
+		opusAudioDescriptor{
+			ChannelCount: 2,
+		},
+		[]byte{0x02},
+	},
+	{
+		"5.1",
+		opusAudioDescriptor{
+			ChannelCount: 6,
+		},
+		[]byte{0x06},
+	},
+	{
+		"dual mono",
+		opusAudioDescriptor{
+			ChannelCount: 2,
+			ChannelMapping: &opus.ChannelMapping{
+				Family:      255,
+				StreamCount: 2,
+				Table:       []uint8{0, 1},
+			},
+		},
+		[]byte{0x00},
+	},
+	{
+		"extended",
+		opusAudioDescriptor{
+			ChannelCount: 3,
+			ChannelMapping: &opus.ChannelMapping{
+				Family:      255,
+				StreamCount: 3,
+				Table:       []uint8{0, 1, 255},
+			},
+		},
+		[]byte{0x80, 0x03, 0xff, 0x81, 0xc0},
+	},
+	{
+		"uncoupled",
+		opusAudioDescriptor{
+			ChannelCount: 3,
+			ChannelMapping: &opus.ChannelMapping{
+				Family:      255,
+				StreamCount: 3,
+				Table:       []uint8{0, 1, 2},
+			},
+		},
+		[]byte{0x83},
+	},
+}
+
+func TestOpusAudioDescriptorUnmarshal(t *testing.T) {
+	for _, ca := range opusAudioDescriptorCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec opusAudioDescriptor
+			err := dec.unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestOpusAudioDescriptorMarshal(t *testing.T) {
+	for _, ca := range opusAudioDescriptorCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestOpusAudioDescriptorMarshalDefaultMapping(t *testing.T) {
+	m, err := opus.DefaultChannelMapping(6)
+	require.NoError(t, err)
+
+	enc, err := opusAudioDescriptor{
+		ChannelCount:   6,
+		ChannelMapping: m,
+	}.marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x06}, enc)
+}
+
+func FuzzOpusAudioDescriptorUnmarshal(f *testing.F) {
+	for _, ca := range opusAudioDescriptorCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var desc opusAudioDescriptor
+		err := desc.unmarshal(b)
+		if err == nil {
+			desc.marshal() //nolint:errcheck
+		}
+	})
+}
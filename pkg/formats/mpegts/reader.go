@@ -12,6 +12,7 @@ import (
 	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg1audio"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
@@ -25,18 +26,36 @@ type ReaderOnDataH26xFunc func(pts int64, dts int64, au [][]byte) error
 // ReaderOnDataMPEGxVideoFunc is the prototype of the callback passed to OnDataMPEGxVideo.
 type ReaderOnDataMPEGxVideoFunc func(pts int64, frame []byte) error
 
+// ReaderOnDataMPEG4VideoFunc is the prototype of the callback passed to OnDataMPEG4Video.
+type ReaderOnDataMPEG4VideoFunc func(pts int64, dts int64, frame []byte) error
+
+// ReaderOnDataMPEG1VideoFunc is the prototype of the callback passed to OnDataMPEG1Video.
+type ReaderOnDataMPEG1VideoFunc func(pts int64, dts int64, frame []byte) error
+
 // ReaderOnDataOpusFunc is the prototype of the callback passed to OnDataOpus.
 type ReaderOnDataOpusFunc func(pts int64, packets [][]byte) error
 
 // ReaderOnDataMPEG4AudioFunc is the prototype of the callback passed to OnDataMPEG4Audio.
 type ReaderOnDataMPEG4AudioFunc func(pts int64, aus [][]byte) error
 
+// ReaderOnDataMPEG4AudioLATMFunc is the prototype of the callback passed to OnDataMPEG4AudioLATM.
+type ReaderOnDataMPEG4AudioLATMFunc func(pts int64, aus [][]byte) error
+
 // ReaderOnDataMPEG1AudioFunc is the prototype of the callback passed to OnDataMPEG1Audio.
 type ReaderOnDataMPEG1AudioFunc func(pts int64, frames [][]byte) error
 
 // ReaderOnDataAC3Func is the prototype of the callback passed to OnDataAC3.
 type ReaderOnDataAC3Func func(pts int64, frame []byte) error
 
+// ReaderOnDataEAC3Func is the prototype of the callback passed to OnDataEAC3.
+type ReaderOnDataEAC3Func func(pts int64, frames [][]byte) error
+
+// ReaderOnDataG711Func is the prototype of the callback passed to OnDataG711.
+type ReaderOnDataG711Func func(pts int64, samples []byte) error
+
+// ReaderOnDataID3Func is the prototype of the callback passed to OnDataID3.
+type ReaderOnDataID3Func func(pts int64, payload []byte) error
+
 func findPMT(dem *astits.Demuxer) (*astits.PMTData, error) {
 	for {
 		data, err := dem.NextData()
@@ -119,6 +138,23 @@ func (r *Reader) OnDataH26x(track *Track, cb ReaderOnDataH26xFunc) {
 	}
 }
 
+// OnDataH266 sets a callback that is called when data from an H266 track is received.
+func (r *Reader) OnDataH266(track *Track, cb ReaderOnDataH26xFunc) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		au, err := h264.AnnexBUnmarshal(data)
+		if err != nil {
+			r.onDecodeError(err)
+			return nil
+		}
+
+		if len(au[0]) >= 2 && h266.NALUType(au[0][1]>>3) == h266.NALUType_AUD_NUT {
+			au = au[1:]
+		}
+
+		return cb(pts, dts, au)
+	}
+}
+
 // OnDataH265 sets a callback that is called when data from an H265 track is received.
 func (r *Reader) OnDataH265(track *Track, cb ReaderOnDataH26xFunc) {
 	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
@@ -154,12 +190,28 @@ func (r *Reader) OnDataH264(track *Track, cb ReaderOnDataH26xFunc) {
 }
 
 // OnDataMPEGxVideo sets a callback that is called when data from an MPEG-1/2/4 Video track is received.
+//
+// Deprecated: replaced by OnDataMPEG4Video and OnDataMPEG1Video.
 func (r *Reader) OnDataMPEGxVideo(track *Track, cb ReaderOnDataMPEGxVideoFunc) {
 	r.onData[track.PID] = func(pts int64, _ int64, data []byte) error {
 		return cb(pts, data)
 	}
 }
 
+// OnDataMPEG4Video sets a callback that is called when data from an MPEG-4 Video track is received.
+func (r *Reader) OnDataMPEG4Video(track *Track, cb ReaderOnDataMPEG4VideoFunc) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		return cb(pts, dts, data)
+	}
+}
+
+// OnDataMPEG1Video sets a callback that is called when data from an MPEG-1/2 Video track is received.
+func (r *Reader) OnDataMPEG1Video(track *Track, cb ReaderOnDataMPEG1VideoFunc) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		return cb(pts, dts, data)
+	}
+}
+
 // OnDataOpus sets a callback that is called when data from an Opus track is received.
 func (r *Reader) OnDataOpus(track *Track, cb ReaderOnDataOpusFunc) {
 	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
@@ -215,6 +267,48 @@ func (r *Reader) OnDataMPEG4Audio(track *Track, cb ReaderOnDataMPEG4AudioFunc) {
 	}
 }
 
+// OnDataMPEG4AudioLATM sets a callback that is called when data from an MPEG-4 Audio LATM track is received.
+// Access units of the first program and layer are returned.
+func (r *Reader) OnDataMPEG4AudioLATM(track *Track, cb ReaderOnDataMPEG4AudioLATMFunc) {
+	streamMuxConfig := &track.Codec.(*CodecMPEG4AudioLATM).Config
+
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		if pts != dts {
+			r.onDecodeError(fmt.Errorf("PTS is not equal to DTS"))
+			return nil
+		}
+
+		var s mpeg4audio.AudioSyncStream
+		err := s.Unmarshal(data)
+		if err != nil {
+			r.onDecodeError(fmt.Errorf("invalid LOAS: %w", err))
+			return nil
+		}
+
+		var aus [][]byte
+
+		for _, el := range s {
+			e := mpeg4audio.AudioMuxElement{
+				MuxConfigPresent: true,
+				StreamMuxConfig:  streamMuxConfig,
+			}
+			err = e.Unmarshal(el)
+			if err != nil {
+				r.onDecodeError(fmt.Errorf("invalid AudioMuxElement: %w", err))
+				return nil
+			}
+
+			streamMuxConfig = e.StreamMuxConfig
+
+			for _, subFrame := range e.Payloads {
+				aus = append(aus, subFrame[0][0])
+			}
+		}
+
+		return cb(pts, aus)
+	}
+}
+
 // OnDataMPEG1Audio sets a callback that is called when data from an MPEG-1 Audio track is received.
 func (r *Reader) OnDataMPEG1Audio(track *Track, cb ReaderOnDataMPEG1AudioFunc) {
 	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
@@ -274,6 +368,57 @@ func (r *Reader) OnDataAC3(track *Track, cb ReaderOnDataAC3Func) {
 	}
 }
 
+// OnDataEAC3 sets a callback that is called when data from an E-AC-3 track is received.
+// Each frame is a syncframe of an independent or dependent substream.
+func (r *Reader) OnDataEAC3(track *Track, cb ReaderOnDataEAC3Func) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		if pts != dts {
+			r.onDecodeError(fmt.Errorf("PTS is not equal to DTS"))
+			return nil
+		}
+
+		var frames [][]byte
+
+		for len(data) > 0 {
+			var bsi ac3.EAC3BSI
+			err := bsi.Unmarshal(data)
+			if err != nil {
+				r.onDecodeError(err)
+				return nil
+			}
+
+			size := bsi.FrameSize()
+
+			var frame []byte
+			frame, data = data[:size], data[size:]
+
+			frames = append(frames, frame)
+		}
+
+		return cb(pts, frames)
+	}
+}
+
+// OnDataG711 sets a callback that is called when data from a G711 track is received.
+func (r *Reader) OnDataG711(track *Track, cb ReaderOnDataG711Func) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		if pts != dts {
+			r.onDecodeError(fmt.Errorf("PTS is not equal to DTS"))
+			return nil
+		}
+
+		return cb(pts, data)
+	}
+}
+
+// OnDataID3 sets a callback that is called when data from an ID3 metadata track is received.
+// The payload contains one or more ID3v2 tags.
+func (r *Reader) OnDataID3(track *Track, cb ReaderOnDataID3Func) {
+	r.onData[track.PID] = func(pts int64, _ int64, data []byte) error {
+		return cb(pts, data)
+	}
+}
+
 // Read reads data.
 func (r *Reader) Read() error {
 	for {
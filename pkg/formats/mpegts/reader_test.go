@@ -11,9 +11,21 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
 
+var testH266SPS = []byte{
+	0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80, 0x00,
+	0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+	0xb9, 0x2c,
+}
+
+var testH266PPS = []byte{
+	0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+	0xc8, 0x86,
+}
+
 var testH265SPS = []byte{
 	0x42, 0x01, 0x01, 0x02, 0x20, 0x00, 0x00, 0x03,
 	0x00, 0xb0, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
@@ -48,6 +60,101 @@ var casesReadWriter = []struct {
 	samples []sample
 	packets []*astits.Packet
 }{
+	{
+		"h266",
+		&Track{
+			PID:   258,
+			Codec: &CodecH266{},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{
+					testH266SPS, // SPS
+					testH266PPS, // PPS
+					{0x00, byte(h266.NALUType_IDR_W_RADL)<<3 | 1},
+				},
+			},
+			{
+				30*90000 + 2*90000,
+				30*90000 + 1*90000,
+				[][]byte{
+					{0x00, byte(h266.NALUType_TRAIL_NUT)<<3 | 1},
+				},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x02, 0xf0, 0x00, 0x33, 0xe1, 0x02,
+					0xf0, 0x00, 0xe3, 0xc3, 0xab, 0x65,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                120,
+					StuffingLength:        113,
+					RandomAccessIndicator: true,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       258,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x00, 0x00,
+					0x00, 0x01, 0x00, 0xa1, 0xa8, 0x00, 0x00, 0x00,
+					0x01, 0x00, 0x79, 0x00, 0x0d, 0x02, 0x53, 0x80,
+					0x00, 0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8,
+					0x02, 0xb9, 0x2c, 0x00, 0x00, 0x00, 0x01, 0x00,
+					0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21, 0xc8,
+					0x86, 0x00, 0x00, 0x00, 0x01, 0x00, 0x39,
+				},
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:         151,
+					StuffingLength: 150,
+				},
+				Header: astits.PacketHeader{
+					ContinuityCounter:         1,
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       258,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0xc0,
+					0x0a, 0x31, 0x00, 0xaf, 0xe4, 0x01, 0x11, 0x00,
+					0xab, 0x24, 0xe1, 0x00, 0x00, 0x00, 0x01, 0x00,
+					0xa1, 0x28, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01,
+				},
+			},
+		},
+	},
 	{
 		"h265",
 		&Track{
@@ -544,11 +651,167 @@ var casesReadWriter = []struct {
 			},
 		},
 	},
+	{
+		"mpeg-4 audio program config element",
+		&Track{
+			PID: 257,
+			Codec: &CodecMPEG4Audio{
+				Config: mpeg4audio.AudioSpecificConfig{
+					Type:         2,
+					SampleRate:   48000,
+					ChannelCount: 6,
+					ProgramConfigElement: &mpeg4audio.ProgramConfigElement{
+						ObjectType: 1,
+						SampleRate: 48000,
+						FrontChannelElements: []*mpeg4audio.ProgramConfigElementChannel{
+							{},
+							{IsCPE: true},
+						},
+						BackChannelElements: []*mpeg4audio.ProgramConfigElementChannel{
+							{IsCPE: true, TagSelect: 1},
+						},
+						LFEChannelElements: []uint8{0},
+					},
+				},
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{0xa0, 0x99, 0x00, 0xa0, 0x00, 0x21, 0x10, 0x00, 0x03}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x0f, 0xe1, 0x01,
+					0xf0, 0x00, 0xec, 0xe2, 0xb0, 0x94,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                153,
+					StuffingLength:        146,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xc0, 0x00, 0x18, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0xff, 0xf1,
+					0x4c, 0x00, 0x02, 0x1f, 0xfc, 0xa0, 0x99, 0x00,
+					0xa0, 0x00, 0x21, 0x10, 0x00, 0x03,
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio latm",
+		&Track{
+			PID: 257,
+			Codec: &CodecMPEG4AudioLATM{
+				Config: mpeg4audio.StreamMuxConfig{
+					Programs: []*mpeg4audio.StreamMuxConfigProgram{{
+						Layers: []*mpeg4audio.StreamMuxConfigLayer{{
+							AudioSpecificConfig: &mpeg4audio.AudioSpecificConfig{
+								Type:         2,
+								SampleRate:   48000,
+								ChannelCount: 2,
+							},
+							LatmBufferFullness: 255,
+						}},
+					}},
+				},
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{3}, {2}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x11, 0xe1, 0x01,
+					0xf0, 0x00, 0x9c, 0x37, 0xf5, 0x07,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                147,
+					StuffingLength:        140,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xc0, 0x00, 0x1e, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x56, 0xe0,
+					0x08, 0x20, 0x00, 0x11, 0x90, 0x1f, 0xe0, 0x08,
+					0x18, 0x56, 0xe0, 0x08, 0x20, 0x00, 0x11, 0x90,
+					0x1f, 0xe0, 0x08, 0x10,
+				},
+			},
+		},
+	},
 	{
 		"mpeg-1 audio",
 		&Track{
-			PID:   257,
-			Codec: &CodecMPEG1Audio{},
+			PID: 257,
+			Codec: &CodecMPEG1Audio{
+				SampleRate: 44100,
+			},
 		},
 		[]sample{
 			{
@@ -854,64 +1117,697 @@ var casesReadWriter = []struct {
 			},
 		},
 	},
-}
-
-func TestReader(t *testing.T) {
-	for _, ca := range casesReadWriter {
-		t.Run(ca.name, func(t *testing.T) {
-			var buf bytes.Buffer
-			mux := astits.NewMuxer(context.Background(), &buf)
-
-			for _, packet := range ca.packets {
-				_, err := mux.WritePacket(packet)
-				require.NoError(t, err)
-			}
-
-			r, err := NewReader(&buf)
-			require.NoError(t, err)
-			require.Equal(t, ca.track, r.Tracks()[0])
-
-			i := 0
-
-			switch ca.track.Codec.(type) {
-			case *CodecH265:
-				r.OnDataH265(ca.track, func(pts int64, dts int64, au [][]byte) error {
-					require.Equal(t, ca.samples[i].pts, pts)
-					require.Equal(t, ca.samples[i].dts, dts)
-					require.Equal(t, ca.samples[i].data, au)
-					i++
-					return nil
-				})
-
-			case *CodecH264:
-				r.OnDataH264(ca.track, func(pts int64, dts int64, au [][]byte) error {
-					require.Equal(t, ca.samples[i].pts, pts)
-					require.Equal(t, ca.samples[i].dts, dts)
-					require.Equal(t, ca.samples[i].data, au)
-					i++
-					return nil
-				})
-
-			case *CodecMPEG4Video:
-				r.OnDataMPEGxVideo(ca.track, func(pts int64, frame []byte) error {
-					require.Equal(t, ca.samples[i].pts, pts)
-					require.Equal(t, ca.samples[i].data[0], frame)
-					i++
-					return nil
-				})
-
-			case *CodecMPEG1Video:
-				r.OnDataMPEGxVideo(ca.track, func(pts int64, frame []byte) error {
-					require.Equal(t, ca.samples[i].pts, pts)
-					require.Equal(t, ca.samples[i].data[0], frame)
-					i++
-					return nil
-				})
-
-			case *CodecOpus:
-				r.OnDataOpus(ca.track, func(pts int64, packets [][]byte) error {
-					require.Equal(t, ca.samples[i].pts, pts)
-					require.Equal(t, ca.samples[i].data, packets)
+	{
+		"ac-3 dvb",
+		&Track{
+			PID: 257,
+			Codec: &CodecAC3{
+				SampleRate:   48000,
+				ChannelCount: 1,
+				DVB:          true,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{
+					0x0b, 0x77, 0x47, 0x11, 0x0c, 0x40, 0x2f, 0x84,
+					0x2b, 0xc1, 0x07, 0x7a, 0xb0, 0xfa, 0xbb, 0xea,
+					0xef, 0x9f, 0x57, 0x7c, 0xf9, 0xf3, 0xf7, 0xcf,
+					0x9f, 0x3e, 0x32, 0xfe, 0xd5, 0xc1, 0x50, 0xde,
+					0xc5, 0x1e, 0x73, 0xd2, 0x6c, 0xa6, 0x94, 0x46,
+					0x4e, 0x92, 0x8c, 0x0f, 0xb9, 0xcf, 0xad, 0x07,
+					0x54, 0x4a, 0x2e, 0xf3, 0x7d, 0x07, 0x2e, 0xa4,
+					0x2f, 0xba, 0xbf, 0x39, 0xb5, 0xc9, 0x92, 0xa6,
+					0xe1, 0xb4, 0x70, 0xc5, 0xc4, 0xb5, 0xe6, 0x5d,
+					0x0f, 0xa8, 0x71, 0xa4, 0xcc, 0xc5, 0xbc, 0x75,
+					0x67, 0x92, 0x52, 0x4f, 0x7e, 0x62, 0x1c, 0xa9,
+					0xd9, 0xb5, 0x19, 0x6a, 0xd7, 0xb0, 0x44, 0x92,
+					0x30, 0x3b, 0xf7, 0x61, 0xd6, 0x49, 0x96, 0x66,
+					0x98, 0x28, 0x1a, 0x95, 0xa9, 0x42, 0xad, 0xb7,
+					0x50, 0x90, 0xad, 0x1c, 0x34, 0x80, 0xe2, 0xef,
+					0xcd, 0x41, 0x0b, 0xf0, 0x9d, 0x57, 0x62, 0x78,
+					0xfd, 0xc6, 0xc2, 0x19, 0x9e, 0x26, 0x31, 0xca,
+					0x1e, 0x75, 0xb1, 0x7a, 0x8e, 0xb5, 0x51, 0x3a,
+					0xfe, 0xe4, 0xf1, 0x0b, 0x4f, 0x14, 0x90, 0xdb,
+					0x9f, 0x44, 0x50, 0xbb, 0xef, 0x74, 0x00, 0x8c,
+					0x1f, 0x97, 0xa1, 0xa2, 0xfa, 0x72, 0x16, 0x47,
+					0xc6, 0xc0, 0xe5, 0xfe, 0x67, 0x03, 0x9c, 0xfe,
+					0x62, 0x01, 0xa1, 0x00, 0x5d, 0xff, 0xa5, 0x03,
+					0x59, 0xfa, 0xa8, 0x25, 0x5f, 0x6b, 0x83, 0x51,
+					0xf2, 0xc0, 0x44, 0xff, 0x2d, 0x05, 0x4b, 0xee,
+					0xe0, 0x54, 0x9e, 0xae, 0x86, 0x45, 0xf3, 0xbd,
+					0x0e, 0x42, 0xf2, 0xbf, 0x0f, 0x7f, 0xc6, 0x09,
+					0x07, 0xdc, 0x22, 0x11, 0x77, 0xbe, 0x31, 0x27,
+					0x5b, 0xa4, 0x13, 0x47, 0x07, 0x32, 0x9f, 0x1f,
+					0xcb, 0xb0, 0xdf, 0x3e, 0x7d, 0x0d, 0xf3, 0xe7,
+					0xcf, 0x9f, 0x3e, 0xae, 0xf9, 0xf3, 0xe7, 0xcf,
+					0x9f, 0x3e, 0x85, 0x5d, 0xf3, 0xe7, 0xcf, 0x9f,
+					0x3e, 0x7c, 0xf9, 0xf3, 0xe7, 0xcf, 0x9f, 0x3f,
+					0x53, 0x5d, 0xf3, 0xe7, 0xcf, 0x9f, 0x3e, 0x7c,
+					0xf9, 0xf3, 0xe7, 0xcf, 0x9f, 0x3e, 0x7c, 0xf9,
+					0xf3, 0xe7, 0xcf, 0x9f, 0x3e, 0x7c, 0xf9, 0xf3,
+					0xe7, 0xcf, 0x9f, 0x3e, 0x00, 0x46, 0x28, 0x26,
+					0x20, 0x4a, 0x5a, 0xc0, 0x8a, 0xc5, 0xae, 0xa0,
+					0x55, 0x78, 0x82, 0x7a, 0x38, 0x10, 0x09, 0xc9,
+					0xb8, 0x0c, 0xfa, 0x5b, 0xc9, 0xd2, 0xec, 0x44,
+					0x25, 0xf8, 0x20, 0xf2, 0xc8, 0x8a, 0xe9, 0x40,
+					0x18, 0x06, 0xc6, 0x2b, 0xc8, 0xed, 0x8f, 0x33,
+					0x09, 0x92, 0x28, 0x1e, 0xc4, 0x24, 0xd8, 0x33,
+					0xa5, 0x00, 0xf5, 0xea, 0x18, 0xfa, 0x90, 0x97,
+					0x97, 0xe8, 0x39, 0x6a, 0xcf, 0xf1, 0xdd, 0xff,
+					0x9e, 0x8e, 0x04, 0x02, 0xae, 0x65, 0x87, 0x5c,
+					0x4e, 0x72, 0xfd, 0x3c, 0x01, 0x86, 0xfe, 0x56,
+					0x59, 0x74, 0x44, 0x3a, 0x40, 0x00, 0xec, 0xfc,
+				}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x15, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01,
+					0xf0, 0x03, 0x6a, 0x01, 0x0f, 0x0c, 0x58, 0xf3,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 159)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                7,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xbd, 0x01, 0x88, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x0b, 0x77,
+					0x47, 0x11, 0x0c, 0x40, 0x2f, 0x84, 0x2b, 0xc1,
+					0x07, 0x7a, 0xb0, 0xfa, 0xbb, 0xea, 0xef, 0x9f,
+					0x57, 0x7c, 0xf9, 0xf3, 0xf7, 0xcf, 0x9f, 0x3e,
+					0x32, 0xfe, 0xd5, 0xc1, 0x50, 0xde, 0xc5, 0x1e,
+					0x73, 0xd2, 0x6c, 0xa6, 0x94, 0x46, 0x4e, 0x92,
+					0x8c, 0x0f, 0xb9, 0xcf, 0xad, 0x07, 0x54, 0x4a,
+					0x2e, 0xf3, 0x7d, 0x07, 0x2e, 0xa4, 0x2f, 0xba,
+					0xbf, 0x39, 0xb5, 0xc9, 0x92, 0xa6, 0xe1, 0xb4,
+					0x70, 0xc5, 0xc4, 0xb5, 0xe6, 0x5d, 0x0f, 0xa8,
+					0x71, 0xa4, 0xcc, 0xc5, 0xbc, 0x75, 0x67, 0x92,
+					0x52, 0x4f, 0x7e, 0x62, 0x1c, 0xa9, 0xd9, 0xb5,
+					0x19, 0x6a, 0xd7, 0xb0, 0x44, 0x92, 0x30, 0x3b,
+					0xf7, 0x61, 0xd6, 0x49, 0x96, 0x66, 0x98, 0x28,
+					0x1a, 0x95, 0xa9, 0x42, 0xad, 0xb7, 0x50, 0x90,
+					0xad, 0x1c, 0x34, 0x80, 0xe2, 0xef, 0xcd, 0x41,
+					0x0b, 0xf0, 0x9d, 0x57, 0x62, 0x78, 0xfd, 0xc6,
+					0xc2, 0x19, 0x9e, 0x26, 0x31, 0xca, 0x1e, 0x75,
+					0xb1, 0x7a, 0x8e, 0xb5, 0x51, 0x3a, 0xfe, 0xe4,
+					0xf1, 0x0b, 0x4f, 0x14, 0x90, 0xdb, 0x9f, 0x44,
+					0x50, 0xbb, 0xef, 0x74, 0x00, 0x8c, 0x1f, 0x97,
+				},
+			},
+			{ // PES
+				Header: astits.PacketHeader{
+					ContinuityCounter:         1,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: false,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0xa1, 0xa2, 0xfa, 0x72, 0x16, 0x47, 0xc6, 0xc0,
+					0xe5, 0xfe, 0x67, 0x03, 0x9c, 0xfe, 0x62, 0x01,
+					0xa1, 0x00, 0x5d, 0xff, 0xa5, 0x03, 0x59, 0xfa,
+					0xa8, 0x25, 0x5f, 0x6b, 0x83, 0x51, 0xf2, 0xc0,
+					0x44, 0xff, 0x2d, 0x05, 0x4b, 0xee, 0xe0, 0x54,
+					0x9e, 0xae, 0x86, 0x45, 0xf3, 0xbd, 0x0e, 0x42,
+					0xf2, 0xbf, 0x0f, 0x7f, 0xc6, 0x09, 0x07, 0xdc,
+					0x22, 0x11, 0x77, 0xbe, 0x31, 0x27, 0x5b, 0xa4,
+					0x13, 0x47, 0x07, 0x32, 0x9f, 0x1f, 0xcb, 0xb0,
+					0xdf, 0x3e, 0x7d, 0x0d, 0xf3, 0xe7, 0xcf, 0x9f,
+					0x3e, 0xae, 0xf9, 0xf3, 0xe7, 0xcf, 0x9f, 0x3e,
+					0x85, 0x5d, 0xf3, 0xe7, 0xcf, 0x9f, 0x3e, 0x7c,
+					0xf9, 0xf3, 0xe7, 0xcf, 0x9f, 0x3f, 0x53, 0x5d,
+					0xf3, 0xe7, 0xcf, 0x9f, 0x3e, 0x7c, 0xf9, 0xf3,
+					0xe7, 0xcf, 0x9f, 0x3e, 0x7c, 0xf9, 0xf3, 0xe7,
+					0xcf, 0x9f, 0x3e, 0x7c, 0xf9, 0xf3, 0xe7, 0xcf,
+					0x9f, 0x3e, 0x00, 0x46, 0x28, 0x26, 0x20, 0x4a,
+					0x5a, 0xc0, 0x8a, 0xc5, 0xae, 0xa0, 0x55, 0x78,
+					0x82, 0x7a, 0x38, 0x10, 0x09, 0xc9, 0xb8, 0x0c,
+					0xfa, 0x5b, 0xc9, 0xd2, 0xec, 0x44, 0x25, 0xf8,
+					0x20, 0xf2, 0xc8, 0x8a, 0xe9, 0x40, 0x18, 0x06,
+					0xc6, 0x2b, 0xc8, 0xed, 0x8f, 0x33, 0x09, 0x92,
+					0x28, 0x1e, 0xc4, 0x24, 0xd8, 0x33, 0xa5, 0x00,
+				},
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:         145,
+					StuffingLength: 144,
+				},
+				Header: astits.PacketHeader{
+					ContinuityCounter:         2,
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: false,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0xf5, 0xea, 0x18, 0xfa, 0x90, 0x97, 0x97, 0xe8,
+					0x39, 0x6a, 0xcf, 0xf1, 0xdd, 0xff, 0x9e, 0x8e,
+					0x04, 0x02, 0xae, 0x65, 0x87, 0x5c, 0x4e, 0x72,
+					0xfd, 0x3c, 0x01, 0x86, 0xfe, 0x56, 0x59, 0x74,
+					0x44, 0x3a, 0x40, 0x00, 0xec, 0xfc,
+				},
+			},
+		},
+	},
+	{
+		"e-ac-3",
+		&Track{
+			PID: 257,
+			Codec: &CodecEAC3{
+				SampleRate:   48000,
+				ChannelCount: 8,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{
+					append([]byte{
+						0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x87, 0xc8, 0x00,
+					}, bytes.Repeat([]byte{0x00}, 24)...),
+					append([]byte{
+						0x0b, 0x77, 0x40, 0x0f, 0x3c, 0x87, 0xd1, 0xa0,
+					}, bytes.Repeat([]byte{0x00}, 24)...),
+				},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x87, 0xe1, 0x01,
+					0xf0, 0x00, 0xa0, 0x9f, 0xb1, 0x2e,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                105,
+					StuffingLength:        98,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xc0, 0x00, 0x48, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x0b, 0x77,
+					0x00, 0x0f, 0x3f, 0x87, 0xc8, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0b, 0x77,
+					0x40, 0x0f, 0x3c, 0x87, 0xd1, 0xa0, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				},
+			},
+		},
+	},
+	{
+		"e-ac-3 dvb",
+		&Track{
+			PID: 257,
+			Codec: &CodecEAC3{
+				SampleRate:   48000,
+				ChannelCount: 8,
+				DVB:          true,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{
+					append([]byte{
+						0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x87, 0xc8, 0x00,
+					}, bytes.Repeat([]byte{0x00}, 24)...),
+					append([]byte{
+						0x0b, 0x77, 0x40, 0x0f, 0x3c, 0x87, 0xd1, 0xa0,
+					}, bytes.Repeat([]byte{0x00}, 24)...),
+				},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x15, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01,
+					0xf0, 0x03, 0x7a, 0x01, 0x00, 0x29, 0x9d, 0x86,
+					0xb5,
+				}, bytes.Repeat([]byte{0xff}, 159)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                105,
+					StuffingLength:        98,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xbd, 0x00, 0x48, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x0b, 0x77,
+					0x00, 0x0f, 0x3f, 0x87, 0xc8, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0b, 0x77,
+					0x40, 0x0f, 0x3c, 0x87, 0xd1, 0xa0, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				},
+			},
+		},
+	},
+	{
+		"g711",
+		&Track{
+			PID: 257,
+			Codec: &CodecG711{
+				MULaw:        true,
+				SampleRate:   8000,
+				ChannelCount: 1,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{1, 2, 3, 4, 5, 6, 7, 8}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x1d, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01,
+					0xf0, 0x0b, 0x05, 0x09, 0x55, 0x4c, 0x41, 0x57,
+					0x00, 0x00, 0x1f, 0x40, 0x01, 0x84, 0x08, 0x20,
+					0xae,
+				}, bytes.Repeat([]byte{0xff}, 151)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                161,
+					StuffingLength:        154,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xbd, 0x00, 0x10, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x01, 0x02,
+					0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+				},
+			},
+		},
+	},
+	{
+		"id3",
+		&Track{
+			PID:   257,
+			Codec: &CodecMetadataID3{},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{
+					0x49, 0x44, 0x33, 0x04, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x3f, 0x50, 0x52, 0x49, 0x56, 0x00, 0x00,
+					0x00, 0x35, 0x00, 0x00, 0x63, 0x6f, 0x6d, 0x2e,
+					0x61, 0x70, 0x70, 0x6c, 0x65, 0x2e, 0x73, 0x74,
+					0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2e,
+					0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+					0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+					0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0d, 0xbb,
+					0xa0,
+				}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x21, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xff, 0xff, 0xf0, 0x00, 0x15, 0xe1, 0x01,
+					0xf0, 0x0f, 0x26, 0x0d, 0xff, 0xff, 0x49, 0x44,
+					0x33, 0x20, 0xff, 0x49, 0x44, 0x33, 0x20, 0x00,
+					0x0f, 0x4f, 0xf7, 0x4a, 0xd9,
+				}, bytes.Repeat([]byte{0xff}, 147)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:         96,
+					StuffingLength: 95,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0x01, 0xbd, 0x00, 0x51, 0x84, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1,
+				}, []byte{
+					0x49, 0x44, 0x33, 0x04, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x3f, 0x50, 0x52, 0x49, 0x56, 0x00, 0x00,
+					0x00, 0x35, 0x00, 0x00, 0x63, 0x6f, 0x6d, 0x2e,
+					0x61, 0x70, 0x70, 0x6c, 0x65, 0x2e, 0x73, 0x74,
+					0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2e,
+					0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+					0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+					0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0d, 0xbb,
+					0xa0,
+				}...),
+			},
+		},
+	},
+}
+
+var casesReadWriterMPEGxVideoDTS = []struct {
+	name    string
+	track   *Track
+	samples []sample
+	packets []*astits.Packet
+}{
+	{
+		"mpeg-4 video",
+		&Track{
+			PID:   257,
+			Codec: &CodecMPEG4Video{},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30*90000 - 3600,
+				[][]byte{{
+					0x00, 0x00, 0x01, 0xb3, 0x00, 0x00, 0x01, 0xb6,
+					0x10,
+				}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x10, 0xe1, 0x01,
+					0xf0, 0x00, 0xd5, 0x3a, 0x92, 0x8a,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                155,
+					StuffingLength:        148,
+					RandomAccessIndicator: true,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2687400},
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0xc0,
+					0x0a, 0x31, 0x00, 0xa5, 0x65, 0xc1, 0x11, 0x00,
+					0xa5, 0x49, 0xa1, 0x00, 0x00, 0x01, 0xb3, 0x00,
+					0x00, 0x01, 0xb6, 0x10,
+				},
+			},
+		},
+	},
+	{
+		"mpeg-1 video",
+		&Track{
+			PID:   257,
+			Codec: &CodecMPEG1Video{},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30*90000 - 3600,
+				[][]byte{{
+					0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x40,
+					0x00, 0x00, 0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8,
+				}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x02, 0xe1, 0x01,
+					0xf0, 0x00, 0xc4, 0xf2, 0x53, 0x9c,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                148,
+					StuffingLength:        141,
+					RandomAccessIndicator: true,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2687400},
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0xc0,
+					0x0a, 0x31, 0x00, 0xa5, 0x65, 0xc1, 0x11, 0x00,
+					0xa5, 0x49, 0xa1, 0x00, 0x00, 0x01, 0xb8, 0x00,
+					0x08, 0x00, 0x40, 0x00, 0x00, 0x01, 0x00, 0x00,
+					0x8f, 0xff, 0xf8,
+				},
+			},
+		},
+	},
+}
+
+func TestReader(t *testing.T) {
+	for _, ca := range casesReadWriter {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mux := astits.NewMuxer(context.Background(), &buf)
+
+			for _, packet := range ca.packets {
+				_, err := mux.WritePacket(packet)
+				require.NoError(t, err)
+			}
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.track, r.Tracks()[0])
+
+			i := 0
+
+			switch ca.track.Codec.(type) {
+			case *CodecH266:
+				r.OnDataH266(ca.track, func(pts int64, dts int64, au [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].dts, dts)
+					require.Equal(t, ca.samples[i].data, au)
+					i++
+					return nil
+				})
+
+			case *CodecH265:
+				r.OnDataH265(ca.track, func(pts int64, dts int64, au [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].dts, dts)
+					require.Equal(t, ca.samples[i].data, au)
+					i++
+					return nil
+				})
+
+			case *CodecH264:
+				r.OnDataH264(ca.track, func(pts int64, dts int64, au [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].dts, dts)
+					require.Equal(t, ca.samples[i].data, au)
+					i++
+					return nil
+				})
+
+			case *CodecMPEG4Video:
+				r.OnDataMPEGxVideo(ca.track, func(pts int64, frame []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], frame)
+					i++
+					return nil
+				})
+
+			case *CodecMPEG1Video:
+				r.OnDataMPEGxVideo(ca.track, func(pts int64, frame []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], frame)
+					i++
+					return nil
+				})
+
+			case *CodecOpus:
+				r.OnDataOpus(ca.track, func(pts int64, packets [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data, packets)
 					i++
 					return nil
 				})
@@ -924,6 +1820,14 @@ func TestReader(t *testing.T) {
 					return nil
 				})
 
+			case *CodecMPEG4AudioLATM:
+				r.OnDataMPEG4AudioLATM(ca.track, func(pts int64, aus [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data, aus)
+					i++
+					return nil
+				})
+
 			case *CodecMPEG1Audio:
 				r.OnDataMPEG1Audio(ca.track, func(pts int64, frames [][]byte) error {
 					require.Equal(t, ca.samples[i].pts, pts)
@@ -940,6 +1844,30 @@ func TestReader(t *testing.T) {
 					return nil
 				})
 
+			case *CodecEAC3:
+				r.OnDataEAC3(ca.track, func(pts int64, frames [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data, frames)
+					i++
+					return nil
+				})
+
+			case *CodecG711:
+				r.OnDataG711(ca.track, func(pts int64, samples []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], samples)
+					i++
+					return nil
+				})
+
+			case *CodecMetadataID3:
+				r.OnDataID3(ca.track, func(pts int64, payload []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], payload)
+					i++
+					return nil
+				})
+
 			default:
 				t.Errorf("unexpected")
 			}
@@ -957,6 +1885,116 @@ func TestReader(t *testing.T) {
 	}
 }
 
+func TestReaderMPEGxVideoDTS(t *testing.T) {
+	for _, ca := range casesReadWriterMPEGxVideoDTS {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mux := astits.NewMuxer(context.Background(), &buf)
+
+			for _, packet := range ca.packets {
+				_, err := mux.WritePacket(packet)
+				require.NoError(t, err)
+			}
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.track, r.Tracks()[0])
+
+			i := 0
+
+			cb := func(pts int64, dts int64, frame []byte) error {
+				require.Equal(t, ca.samples[i].pts, pts)
+				require.Equal(t, ca.samples[i].dts, dts)
+				require.Equal(t, ca.samples[i].data[0], frame)
+				i++
+				return nil
+			}
+
+			if _, ok := ca.track.Codec.(*CodecMPEG4Video); ok {
+				r.OnDataMPEG4Video(ca.track, cb)
+			} else {
+				r.OnDataMPEG1Video(ca.track, cb)
+			}
+
+			for {
+				err := r.Read()
+				if errors.Is(err, astits.ErrNoMorePackets) {
+					break
+				}
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, len(ca.samples), i)
+		})
+	}
+}
+
+func TestReaderMPEG1AudioSampleRate(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		data       [][]byte
+		sampleRate int
+	}{
+		{
+			"mid-frame start",
+			[][]byte{
+				{1, 2, 3, 4, 0xff, 0xf3, 0x40, 0xc4, 0, 0, 0, 0},
+			},
+			22050,
+		},
+		{
+			"header in second pes",
+			[][]byte{
+				{1, 2, 3, 4},
+				{0xff, 0xfb, 0x10, 0xc4, 0, 0, 0, 0},
+			},
+			44100,
+		},
+		{
+			"no header",
+			[][]byte{
+				{1, 2, 3, 4},
+			},
+			0,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mux := astits.NewMuxer(context.Background(), &buf)
+
+			err := mux.AddElementaryStream(astits.PMTElementaryStream{
+				ElementaryPID: 123,
+				StreamType:    astits.StreamTypeMPEG1Audio,
+			})
+			require.NoError(t, err)
+
+			mux.SetPCRPID(123)
+
+			for _, data := range ca.data {
+				_, err = mux.WriteData(&astits.MuxerData{
+					PID: 123,
+					PES: &astits.PESData{
+						Header: &astits.PESHeader{
+							OptionalHeader: &astits.PESOptionalHeader{
+								MarkerBits:      2,
+								PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+								PTS:             &astits.ClockReference{Base: 90000},
+							},
+							StreamID: streamIDAudio,
+						},
+						Data: data,
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, &CodecMPEG1Audio{SampleRate: ca.sampleRate}, r.Tracks()[0].Codec)
+		})
+	}
+}
+
 func TestReaderDecodeErrors(t *testing.T) {
 	for _, ca := range []string{
 		"missing pts",
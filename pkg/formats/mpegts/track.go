@@ -1,10 +1,12 @@
 package mpegts
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/asticode/go-astits"
 	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg1audio"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
 
@@ -12,6 +14,8 @@ const (
 	h264Identifier = 'H'<<24 | 'D'<<16 | 'M'<<8 | 'V'
 	h265Identifier = 'H'<<24 | 'E'<<16 | 'V'<<8 | 'C'
 	opusIdentifier = 'O'<<24 | 'p'<<16 | 'u'<<8 | 's'
+	ulawIdentifier = 'U'<<24 | 'L'<<16 | 'A'<<8 | 'W'
+	alawIdentifier = 'A'<<24 | 'L'<<16 | 'A'<<8 | 'W'
 )
 
 func findMPEG4AudioConfig(dem *astits.Demuxer, pid uint16) (*mpeg4audio.Config, error) {
@@ -33,13 +37,50 @@ func findMPEG4AudioConfig(dem *astits.Demuxer, pid uint16) (*mpeg4audio.Config,
 
 		pkt := adtsPkts[0]
 		return &mpeg4audio.Config{
-			Type:         pkt.Type,
-			SampleRate:   pkt.SampleRate,
-			ChannelCount: pkt.ChannelCount,
+			Type:                 pkt.Type,
+			SampleRate:           pkt.SampleRate,
+			ChannelCount:         pkt.ChannelCount,
+			ProgramConfigElement: pkt.ProgramConfigElement,
 		}, nil
 	}
 }
 
+func findMPEG4AudioLATMConfig(dem *astits.Demuxer, pid uint16) (*mpeg4audio.StreamMuxConfig, error) {
+	for {
+		data, err := dem.NextData()
+		if err != nil {
+			return nil, err
+		}
+
+		if data.PES == nil || data.PID != pid {
+			continue
+		}
+
+		var s mpeg4audio.AudioSyncStream
+		err = s.Unmarshal(data.PES.Data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode LOAS: %w", err)
+		}
+
+		for _, el := range s {
+			// skip elements that don't contain a StreamMuxConfig
+			if len(el) == 0 || (el[0]&0x80) != 0 {
+				continue
+			}
+
+			e := mpeg4audio.AudioMuxElement{
+				MuxConfigPresent: true,
+			}
+			err = e.Unmarshal(el)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode AudioMuxElement: %w", err)
+			}
+
+			return e.StreamMuxConfig, nil
+		}
+	}
+}
+
 func findAC3Parameters(dem *astits.Demuxer, pid uint16) (int, int, error) {
 	for {
 		data, err := dem.NextData()
@@ -67,6 +108,100 @@ func findAC3Parameters(dem *astits.Demuxer, pid uint16) (int, int, error) {
 	}
 }
 
+// findMPEG1AudioSampleRate returns the sample rate of the first frame header.
+// Streams may start in the middle of a frame, therefore the header is searched
+// in every PES. If no header is found, the sample rate is left unknown (zero).
+func findMPEG1AudioSampleRate(dem *astits.Demuxer, pid uint16) (int, error) {
+	for {
+		data, err := dem.NextData()
+		if err != nil {
+			if errors.Is(err, astits.ErrNoMorePackets) {
+				return 0, nil
+			}
+			return 0, err
+		}
+
+		if data.PES == nil || data.PID != pid {
+			continue
+		}
+
+		buf := data.PES.Data
+
+		for i := 0; i < (len(buf) - 1); i++ {
+			if buf[i] != 0xFF || (buf[i+1]&0xE0) != 0xE0 {
+				continue
+			}
+
+			var h mpeg1audio.FrameHeader
+			err = h.Unmarshal(buf[i:])
+			if err == nil {
+				return h.SampleRate, nil
+			}
+		}
+	}
+}
+
+func findEAC3Parameters(dem *astits.Demuxer, pid uint16) (int, int, error) {
+	for {
+		data, err := dem.NextData()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if data.PES == nil || data.PID != pid {
+			continue
+		}
+
+		buf := data.PES.Data
+		sampleRate := 0
+		var chanmap uint16
+
+		// merge the channels of the first independent substream
+		// with the ones of its dependent substreams.
+		for len(buf) > 0 {
+			var bsi ac3.EAC3BSI
+			err = bsi.Unmarshal(buf)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid E-AC-3 frame: %w", err)
+			}
+
+			if bsi.Strmtyp != ac3.EAC3StreamTypeDependent {
+				if sampleRate != 0 {
+					break
+				}
+				sampleRate = bsi.SampleRate()
+			}
+
+			chanmap |= bsi.ChannelMap()
+			buf = buf[bsi.FrameSize():]
+		}
+
+		if sampleRate == 0 {
+			return 0, 0, fmt.Errorf("independent substream not found")
+		}
+
+		return sampleRate, ac3.ChanmapChannelCount(chanmap), nil
+	}
+}
+
+func findAC3Descriptor(descriptors []*astits.Descriptor) bool {
+	for _, sd := range descriptors {
+		if sd.AC3 != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func findEnhancedAC3Descriptor(descriptors []*astits.Descriptor) bool {
+	for _, sd := range descriptors {
+		if sd.EnhancedAC3 != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func findOpusRegistration(descriptors []*astits.Descriptor) bool {
 	for _, sd := range descriptors {
 		if sd.Registration != nil {
@@ -78,14 +213,19 @@ func findOpusRegistration(descriptors []*astits.Descriptor) bool {
 	return false
 }
 
-func findOpusChannelCount(descriptors []*astits.Descriptor) int {
+func findOpusAudioDescriptor(descriptors []*astits.Descriptor) *opusAudioDescriptor {
 	for _, sd := range descriptors {
-		if sd.Extension != nil && sd.Extension.Tag == 0x80 &&
-			sd.Extension.Unknown != nil && len(*sd.Extension.Unknown) >= 1 {
-			return int((*sd.Extension.Unknown)[0])
+		if sd.Extension != nil && sd.Extension.Tag == opusAudioDescriptorTag &&
+			sd.Extension.Unknown != nil {
+			var desc opusAudioDescriptor
+			err := desc.unmarshal(*sd.Extension.Unknown)
+			if err != nil {
+				return nil
+			}
+			return &desc
 		}
 	}
-	return 0
+	return nil
 }
 
 func findOpusCodec(descriptors []*astits.Descriptor) *CodecOpus {
@@ -93,16 +233,43 @@ func findOpusCodec(descriptors []*astits.Descriptor) *CodecOpus {
 		return nil
 	}
 
-	channelCount := findOpusChannelCount(descriptors)
-	if channelCount <= 0 {
+	desc := findOpusAudioDescriptor(descriptors)
+	if desc == nil || desc.ChannelCount <= 0 {
 		return nil
 	}
 
 	return &CodecOpus{
-		ChannelCount: channelCount,
+		ChannelCount:   desc.ChannelCount,
+		ChannelMapping: desc.ChannelMapping,
 	}
 }
 
+func findG711Codec(descriptors []*astits.Descriptor) *CodecG711 {
+	for _, sd := range descriptors {
+		if sd.Registration != nil &&
+			(sd.Registration.FormatIdentifier == ulawIdentifier ||
+				sd.Registration.FormatIdentifier == alawIdentifier) {
+			info := sd.Registration.AdditionalIdentificationInfo
+			if len(info) != 5 {
+				return nil
+			}
+
+			sampleRate := int(uint32(info[0])<<24 | uint32(info[1])<<16 | uint32(info[2])<<8 | uint32(info[3]))
+			channelCount := int(info[4])
+			if sampleRate <= 0 || channelCount <= 0 {
+				return nil
+			}
+
+			return &CodecG711{
+				MULaw:        sd.Registration.FormatIdentifier == ulawIdentifier,
+				SampleRate:   sampleRate,
+				ChannelCount: channelCount,
+			}
+		}
+	}
+	return nil
+}
+
 // Track is a MPEG-TS track.
 type Track struct {
 	PID   uint16
@@ -120,6 +287,9 @@ func (t *Track) unmarshal(dem *astits.Demuxer, es *astits.PMTElementaryStream) e
 	t.PID = es.ElementaryPID
 
 	switch es.StreamType {
+	case streamTypeH266Video:
+		t.Codec = &CodecH266{}
+
 	case astits.StreamTypeH265Video:
 		t.Codec = &CodecH265{}
 
@@ -142,8 +312,25 @@ func (t *Track) unmarshal(dem *astits.Demuxer, es *astits.PMTElementaryStream) e
 			Config: *conf,
 		}
 
-	case astits.StreamTypeMPEG1Audio:
-		t.Codec = &CodecMPEG1Audio{}
+	case astits.StreamTypeAACLATMAudio:
+		conf, err := findMPEG4AudioLATMConfig(dem, es.ElementaryPID)
+		if err != nil {
+			return err
+		}
+
+		t.Codec = &CodecMPEG4AudioLATM{
+			Config: *conf,
+		}
+
+	case astits.StreamTypeMPEG1Audio, astits.StreamTypeMPEG2Audio:
+		sampleRate, err := findMPEG1AudioSampleRate(dem, es.ElementaryPID)
+		if err != nil {
+			return err
+		}
+
+		t.Codec = &CodecMPEG1Audio{
+			SampleRate: sampleRate,
+		}
 
 	case astits.StreamTypeAC3Audio:
 		sampleRate, channelCount, err := findAC3Parameters(dem, es.ElementaryPID)
@@ -156,7 +343,53 @@ func (t *Track) unmarshal(dem *astits.Demuxer, es *astits.PMTElementaryStream) e
 			ChannelCount: channelCount,
 		}
 
+	case astits.StreamTypeEAC3Audio:
+		sampleRate, channelCount, err := findEAC3Parameters(dem, es.ElementaryPID)
+		if err != nil {
+			return err
+		}
+
+		t.Codec = &CodecEAC3{
+			SampleRate:   sampleRate,
+			ChannelCount: channelCount,
+		}
+
 	case astits.StreamTypePrivateData:
+		// DVB signals AC-3 and E-AC-3 with an AC-3_descriptor or an enhanced_AC-3_descriptor
+		switch {
+		case findAC3Descriptor(es.ElementaryStreamDescriptors):
+			sampleRate, channelCount, err := findAC3Parameters(dem, es.ElementaryPID)
+			if err != nil {
+				return err
+			}
+
+			t.Codec = &CodecAC3{
+				SampleRate:   sampleRate,
+				ChannelCount: channelCount,
+				DVB:          true,
+			}
+			return nil
+
+		case findEnhancedAC3Descriptor(es.ElementaryStreamDescriptors):
+			sampleRate, channelCount, err := findEAC3Parameters(dem, es.ElementaryPID)
+			if err != nil {
+				return err
+			}
+
+			t.Codec = &CodecEAC3{
+				SampleRate:   sampleRate,
+				ChannelCount: channelCount,
+				DVB:          true,
+			}
+			return nil
+		}
+
+		g711Codec := findG711Codec(es.ElementaryStreamDescriptors)
+		if g711Codec != nil {
+			t.Codec = g711Codec
+			return nil
+		}
+
 		codec := findOpusCodec(es.ElementaryStreamDescriptors)
 		if codec != nil {
 			t.Codec = codec
@@ -164,6 +397,13 @@ func (t *Track) unmarshal(dem *astits.Demuxer, es *astits.PMTElementaryStream) e
 			t.Codec = &CodecUnsupported{}
 		}
 
+	case astits.StreamTypeMetadata:
+		if findID3MetadataDescriptor(es.ElementaryStreamDescriptors) {
+			t.Codec = &CodecMetadataID3{}
+		} else {
+			t.Codec = &CodecUnsupported{}
+		}
+
 	default:
 		t.Codec = &CodecUnsupported{}
 	}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg1audio"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg1video"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4video"
 )
@@ -19,6 +21,8 @@ const (
 	streamIDVideo = 224
 	streamIDAudio = 192
 
+	streamIDPrivateStream1 = 189
+
 	// PCR is needed to read H265 tracks with VLC+VDPAU hardware encoder
 	// (and is probably needed by other combinations too)
 	dtsPCRDiff = (90000 / 10)
@@ -46,12 +50,30 @@ func mpeg1AudioMarshalSize(frames [][]byte) int {
 	return n
 }
 
+// pickLeadingTrack returns the track that carries the PCR,
+// that is the first video track or, if there's none, the first audio track.
+func pickLeadingTrack(tracks []*Track) *Track {
+	for _, track := range tracks {
+		if track.Codec.IsVideo() {
+			return track
+		}
+	}
+
+	for _, track := range tracks {
+		if _, ok := track.Codec.(*CodecMetadataID3); !ok {
+			return track
+		}
+	}
+
+	return nil
+}
+
 // Writer is a MPEG-TS writer.
 type Writer struct {
-	nextPID            uint16
-	mux                *astits.Muxer
-	pcrCounter         int
-	leadingTrackChosen bool
+	nextPID         uint16
+	mux             *astits.Muxer
+	pcrCounter      int
+	hasLeadingTrack bool
 }
 
 // NewWriter allocates a Writer.
@@ -80,6 +102,14 @@ func NewWriter(
 		}
 	}
 
+	leadingTrack := pickLeadingTrack(tracks)
+
+	if leadingTrack != nil {
+		leadingTrack.isLeading = true
+		w.hasLeadingTrack = true
+		w.mux.SetPCRPID(leadingTrack.PID)
+	}
+
 	// WriteTables() is not necessary
 	// since it's called automatically when WriteData() is called with
 	// * PID == PCRPID
@@ -105,6 +135,32 @@ func (w *Writer) WriteH26x(
 	return w.WriteH264(track, pts, dts, randomAccess, au)
 }
 
+// WriteH266 writes a H266 access unit.
+func (w *Writer) WriteH266(
+	track *Track,
+	pts int64,
+	dts int64,
+	randomAccess bool,
+	au [][]byte,
+) error {
+	// prepend an AUD. This is required by video.js, iOS, QuickTime
+	if len(au[0]) < 2 || h266.NALUType(au[0][1]>>3) != h266.NALUType_AUD_NUT {
+		aud := []byte{0, byte(h266.NALUType_AUD_NUT)<<3 | 1, 0x28}
+		if randomAccess {
+			aud[2] |= 0x80 // aud_irap_or_gdr_flag
+		}
+
+		au = append([][]byte{aud}, au...)
+	}
+
+	enc, err := h264.AnnexBMarshal(au)
+	if err != nil {
+		return err
+	}
+
+	return w.writeVideo(track, pts, dts, randomAccess, enc)
+}
+
 // WriteH265 writes a H265 access unit.
 func (w *Writer) WriteH265(
 	track *Track,
@@ -152,25 +208,51 @@ func (w *Writer) WriteH264(
 }
 
 // WriteMPEG4Video writes a MPEG-4 Video frame.
+//
+// Deprecated: replaced by WriteMPEG4Video2.
 func (w *Writer) WriteMPEG4Video(
 	track *Track,
 	pts int64,
 	frame []byte,
 ) error {
-	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, byte(mpeg4video.GroupOfVOPStartCode)})
+	return w.WriteMPEG4Video2(track, pts, pts, frame)
+}
+
+// WriteMPEG4Video2 writes a MPEG-4 Video frame.
+func (w *Writer) WriteMPEG4Video2(
+	track *Track,
+	pts int64,
+	dts int64,
+	frame []byte,
+) error {
+	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, byte(mpeg4video.GroupOfVOPStartCode)}) ||
+		mpeg4video.IsRandomAccess(frame)
 
-	return w.writeVideo(track, pts, pts, randomAccess, frame)
+	return w.writeVideo(track, pts, dts, randomAccess, frame)
 }
 
 // WriteMPEG1Video writes a MPEG-1/2 Video frame.
+//
+// Deprecated: replaced by WriteMPEG1Video2.
 func (w *Writer) WriteMPEG1Video(
 	track *Track,
 	pts int64,
 	frame []byte,
 ) error {
-	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, 0xB8})
+	return w.WriteMPEG1Video2(track, pts, pts, frame)
+}
 
-	return w.writeVideo(track, pts, pts, randomAccess, frame)
+// WriteMPEG1Video2 writes a MPEG-1/2 Video frame.
+func (w *Writer) WriteMPEG1Video2(
+	track *Track,
+	pts int64,
+	dts int64,
+	frame []byte,
+) error {
+	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, byte(mpeg1video.GroupOfPicturesStartCode)}) ||
+		mpeg1video.IsRandomAccess(frame)
+
+	return w.writeVideo(track, pts, dts, randomAccess, frame)
 }
 
 // WriteOpus writes Opus packets.
@@ -209,10 +291,11 @@ func (w *Writer) WriteMPEG4Audio(
 
 	for i, au := range aus {
 		pkts[i] = &mpeg4audio.ADTSPacket{
-			Type:         aacCodec.Config.Type,
-			SampleRate:   aacCodec.SampleRate,
-			ChannelCount: aacCodec.Config.ChannelCount,
-			AU:           au,
+			Type:                 aacCodec.Config.Type,
+			SampleRate:           aacCodec.SampleRate,
+			ChannelCount:         aacCodec.Config.ChannelCount,
+			ProgramConfigElement: aacCodec.Config.ProgramConfigElement,
+			AU:                   au,
 		}
 	}
 
@@ -224,7 +307,54 @@ func (w *Writer) WriteMPEG4Audio(
 	return w.writeAudio(track, pts, enc)
 }
 
-// WriteMPEG1Audio writes MPEG-1 Audio packets.
+// WriteMPEG4AudioLATM writes MPEG-4 Audio LATM access units.
+// The StreamMuxConfig is sent with every AudioMuxElement.
+func (w *Writer) WriteMPEG4AudioLATM(
+	track *Track,
+	pts int64,
+	aus [][]byte,
+) error {
+	conf := &track.Codec.(*CodecMPEG4AudioLATM).Config
+
+	if len(conf.Programs) != 1 || len(conf.Programs[0].Layers) != 1 {
+		return fmt.Errorf("StreamMuxConfig with multiple programs or layers is not supported")
+	}
+
+	subFrameCount := int(conf.NumSubFrames) + 1
+	if (len(aus) % subFrameCount) != 0 {
+		return fmt.Errorf("access unit count is not a multiple of sub frame count")
+	}
+
+	s := make(mpeg4audio.AudioSyncStream, len(aus)/subFrameCount)
+
+	for i := range s {
+		payloads := make([][][][]byte, subFrameCount)
+		for j := range payloads {
+			payloads[j] = [][][]byte{{aus[i*subFrameCount+j]}}
+		}
+
+		e := mpeg4audio.AudioMuxElement{
+			MuxConfigPresent: true,
+			StreamMuxConfig:  conf,
+			Payloads:         payloads,
+		}
+
+		var err error
+		s[i], err = e.Marshal()
+		if err != nil {
+			return err
+		}
+	}
+
+	enc, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteMPEG1Audio writes MPEG-1/2 Audio packets.
 func (w *Writer) WriteMPEG1Audio(
 	track *Track,
 	pts int64,
@@ -237,10 +367,6 @@ func (w *Writer) WriteMPEG1Audio(
 			return err
 		}
 
-		if h.MPEG2 {
-			return fmt.Errorf("only MPEG-1 audio is supported")
-		}
-
 		track.mp3Checked = true
 	}
 
@@ -262,6 +388,66 @@ func (w *Writer) WriteAC3(
 	return w.writeAudio(track, pts, frame)
 }
 
+// WriteEAC3 writes E-AC-3 syncframes.
+// Dependent substreams must follow the independent substream they refer to.
+func (w *Writer) WriteEAC3(
+	track *Track,
+	pts int64,
+	frames [][]byte,
+) error {
+	n := 0
+	for _, frame := range frames {
+		n += len(frame)
+	}
+
+	enc := make([]byte, n)
+	n = 0
+	for _, frame := range frames {
+		n += copy(enc[n:], frame)
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteG711 writes G711 samples.
+func (w *Writer) WriteG711(
+	track *Track,
+	pts int64,
+	samples []byte,
+) error {
+	return w.writeAudio(track, pts, samples)
+}
+
+// WriteID3 writes ID3 metadata.
+// The payload contains one or more ID3v2 tags.
+// The writer must contain at least an audio or video track, that carries the PCR.
+func (w *Writer) WriteID3(
+	track *Track,
+	pts int64,
+	payload []byte,
+) error {
+	if !w.hasLeadingTrack {
+		return fmt.Errorf("ID3 tracks require at least one audio or video track")
+	}
+
+	_, err := w.mux.WriteData(&astits.MuxerData{
+		PID: track.PID,
+		PES: &astits.PESData{
+			Header: &astits.PESHeader{
+				OptionalHeader: &astits.PESOptionalHeader{
+					MarkerBits:             2,
+					DataAlignmentIndicator: true,
+					PTSDTSIndicator:        astits.PTSDTSIndicatorOnlyPTS,
+					PTS:                    &astits.ClockReference{Base: pts},
+				},
+				StreamID: streamIDPrivateStream1,
+			},
+			Data: payload,
+		},
+	})
+	return err
+}
+
 func (w *Writer) writeVideo(
 	track *Track,
 	pts int64,
@@ -269,12 +455,6 @@ func (w *Writer) writeVideo(
 	randomAccess bool,
 	data []byte,
 ) error {
-	if !w.leadingTrackChosen {
-		w.leadingTrackChosen = true
-		track.isLeading = true
-		w.mux.SetPCRPID(track.PID)
-	}
-
 	var af *astits.PacketAdaptationField
 
 	if randomAccess {
@@ -321,13 +501,28 @@ func (w *Writer) writeVideo(
 	return err
 }
 
-func (w *Writer) writeAudio(track *Track, pts int64, data []byte) error {
-	if !w.leadingTrackChosen {
-		w.leadingTrackChosen = true
-		track.isLeading = true
-		w.mux.SetPCRPID(track.PID)
+// DVB requires AC-3 and E-AC-3 to be carried by private_stream_1 PES packets.
+// G711 is carried as private data too.
+func audioStreamID(codec Codec) uint8 {
+	switch codec := codec.(type) {
+	case *CodecG711:
+		return streamIDPrivateStream1
+
+	case *CodecAC3:
+		if codec.DVB {
+			return streamIDPrivateStream1
+		}
+
+	case *CodecEAC3:
+		if codec.DVB {
+			return streamIDPrivateStream1
+		}
 	}
 
+	return streamIDAudio
+}
+
+func (w *Writer) writeAudio(track *Track, pts int64, data []byte) error {
 	af := &astits.PacketAdaptationField{
 		RandomAccessIndicator: true,
 	}
@@ -351,7 +546,7 @@ func (w *Writer) writeAudio(track *Track, pts int64, data []byte) error {
 					PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
 					PTS:             &astits.ClockReference{Base: pts},
 				},
-				StreamID: streamIDAudio,
+				StreamID: audioStreamID(track.Codec),
 			},
 			Data: data,
 		},
@@ -12,6 +12,8 @@ import (
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
 
 func h265RandomAccessPresent(au [][]byte) bool {
@@ -33,6 +35,10 @@ func TestWriter(t *testing.T) {
 
 			for _, sample := range ca.samples {
 				switch ca.track.Codec.(type) {
+				case *CodecH266:
+					err := w.WriteH266(ca.track, sample.pts, sample.dts, h266.IsRandomAccess(sample.data), sample.data)
+					require.NoError(t, err)
+
 				case *CodecH265:
 					err := w.WriteH26x(ca.track, sample.pts, sample.dts, h265RandomAccessPresent(sample.data), sample.data)
 					require.NoError(t, err)
@@ -57,6 +63,10 @@ func TestWriter(t *testing.T) {
 					err := w.WriteMPEG4Audio(ca.track, sample.pts, sample.data)
 					require.NoError(t, err)
 
+				case *CodecMPEG4AudioLATM:
+					err := w.WriteMPEG4AudioLATM(ca.track, sample.pts, sample.data)
+					require.NoError(t, err)
+
 				case *CodecMPEG1Audio:
 					err := w.WriteMPEG1Audio(ca.track, sample.pts, sample.data)
 					require.NoError(t, err)
@@ -65,6 +75,20 @@ func TestWriter(t *testing.T) {
 					err := w.WriteAC3(ca.track, sample.pts, sample.data[0])
 					require.NoError(t, err)
 
+				case *CodecEAC3:
+					err := w.WriteEAC3(ca.track, sample.pts, sample.data)
+					require.NoError(t, err)
+
+				case *CodecG711:
+					err := w.WriteG711(ca.track, sample.pts, sample.data[0])
+					require.NoError(t, err)
+
+				case *CodecMetadataID3:
+					// ID3 tracks cannot be written without an audio or video track
+					err := w.WriteID3(ca.track, sample.pts, sample.data[0])
+					require.EqualError(t, err, "ID3 tracks require at least one audio or video track")
+					return
+
 				default:
 					t.Errorf("unexpected")
 				}
@@ -98,6 +122,39 @@ func TestWriter(t *testing.T) {
 	}
 }
 
+func TestWriterMPEGxVideoDTS(t *testing.T) {
+	for _, ca := range casesReadWriterMPEGxVideoDTS {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, []*Track{ca.track})
+
+			for _, sample := range ca.samples {
+				var err error
+				if _, ok := ca.track.Codec.(*CodecMPEG4Video); ok {
+					err = w.WriteMPEG4Video2(ca.track, sample.pts, sample.dts, sample.data[0])
+				} else {
+					err = w.WriteMPEG1Video2(ca.track, sample.pts, sample.dts, sample.data[0])
+				}
+				require.NoError(t, err)
+			}
+
+			dem := astits.NewDemuxer(
+				context.Background(),
+				&buf,
+				astits.DemuxerOptPacketSize(188))
+
+			for _, packet := range ca.packets {
+				pkt, err := dem.NextPacket()
+				require.NoError(t, err)
+				require.Equal(t, packet, pkt)
+			}
+
+			_, err := dem.NextPacket()
+			require.Equal(t, astits.ErrNoMorePackets, err)
+		})
+	}
+}
+
 func TestWriterAutomaticPID(t *testing.T) {
 	track := &Track{
 		Codec: &CodecH265{},
@@ -107,3 +164,149 @@ func TestWriterAutomaticPID(t *testing.T) {
 	NewWriter(&buf, []*Track{track})
 	require.NotEqual(t, 0, track.PID)
 }
+
+func TestWriterPCRPID(t *testing.T) {
+	audioTrack := &Track{
+		Codec: &CodecMPEG4Audio{
+			Config: mpeg4audio.AudioSpecificConfig{
+				Type:         2,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+		},
+	}
+
+	videoTrack := &Track{
+		Codec: &CodecH264{},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []*Track{audioTrack, videoTrack})
+
+	err := w.WriteMPEG4Audio(audioTrack, 90000, [][]byte{{1, 2, 3, 4}})
+	require.NoError(t, err)
+
+	err = w.WriteH264(videoTrack, 90000, 90000, true, [][]byte{{5, 1}})
+	require.NoError(t, err)
+
+	dem := astits.NewDemuxer(
+		context.Background(),
+		&buf,
+		astits.DemuxerOptPacketSize(188))
+
+	pmtCount := 0
+
+	for {
+		data, err := dem.NextData()
+		if errors.Is(err, astits.ErrNoMorePackets) {
+			break
+		}
+		require.NoError(t, err)
+
+		if data.PMT != nil {
+			require.Equal(t, videoTrack.PID, data.PMT.PCRPID)
+			pmtCount++
+		}
+	}
+
+	require.Equal(t, 2, pmtCount)
+}
+
+func TestWriterMPEG1AudioStreamType(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		sampleRate int
+		frame      []byte
+		streamType astits.StreamType
+	}{
+		{
+			"mpeg-1",
+			44100,
+			append([]byte{0xff, 0xfb, 0x10, 0xc4}, make([]byte, 100)...),
+			astits.StreamTypeMPEG1Audio,
+		},
+		{
+			"mpeg-2",
+			22050,
+			append([]byte{0xff, 0xf3, 0x40, 0xc4}, make([]byte, 100)...),
+			astits.StreamTypeMPEG2Audio,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			track := &Track{
+				Codec: &CodecMPEG1Audio{
+					SampleRate: ca.sampleRate,
+				},
+			}
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf, []*Track{track})
+
+			err := w.WriteMPEG1Audio(track, 90000, [][]byte{ca.frame})
+			require.NoError(t, err)
+
+			dem := astits.NewDemuxer(
+				context.Background(),
+				bytes.NewReader(buf.Bytes()),
+				astits.DemuxerOptPacketSize(188))
+
+			pmt, err := findPMT(dem)
+			require.NoError(t, err)
+			require.Equal(t, ca.streamType, pmt.ElementaryStreams[0].StreamType)
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, &CodecMPEG1Audio{SampleRate: ca.sampleRate}, r.Tracks()[0].Codec)
+		})
+	}
+}
+
+func TestWriterID3(t *testing.T) {
+	audioTrack := &Track{
+		Codec: &CodecMPEG4Audio{
+			Config: mpeg4audio.AudioSpecificConfig{
+				Type:         2,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+		},
+	}
+
+	id3Track := &Track{
+		Codec: &CodecMetadataID3{},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []*Track{id3Track, audioTrack})
+
+	err := w.WriteID3(id3Track, 90000, []byte{
+		0x49, 0x44, 0x33, 0x04, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG4Audio(audioTrack, 90000, [][]byte{{1, 2, 3, 4}})
+	require.NoError(t, err)
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, []*Track{
+		{
+			PID:   id3Track.PID,
+			Codec: &CodecMetadataID3{},
+		},
+		{
+			PID:   audioTrack.PID,
+			Codec: audioTrack.Codec,
+		},
+	}, r.Tracks())
+
+	dem := astits.NewDemuxer(
+		context.Background(),
+		bytes.NewReader(buf.Bytes()),
+		astits.DemuxerOptPacketSize(188))
+
+	pmt, err := findPMT(dem)
+	require.NoError(t, err)
+	require.Equal(t, audioTrack.PID, pmt.PCRPID)
+}
@@ -570,8 +570,8 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x03, 0x20,
-			0x00, 0x00, 0x02, 0x58, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x07, 0x80,
+			0x00, 0x00, 0x04, 0x38, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x24, 0x65, 0x64, 0x74, 0x73, 0x00, 0x00,
 			0x00, 0x1c, 0x65, 0x6c, 0x73, 0x74, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
@@ -601,8 +601,8 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0xb7, 0x6d, 0x70, 0x34, 0x76, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03,
-			0x20, 0x02, 0x58, 0x00, 0x48, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x07,
+			0x80, 0x04, 0x38, 0x00, 0x48, 0x00, 0x00, 0x00,
 			0x48, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -647,7 +647,7 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00,
-			0x00, 0x00, 0x03, 0x20, 0x00, 0x00, 0x02, 0x58,
+			0x00, 0x00, 0x07, 0x80, 0x00, 0x00, 0x04, 0x38,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x24, 0x65, 0x64,
 			0x74, 0x73, 0x00, 0x00, 0x00, 0x1c, 0x65, 0x6c,
 			0x73, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -678,7 +678,7 @@ var casesPresentation = []struct {
 			0x70, 0x34, 0x76, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x03, 0x20, 0x02, 0x58, 0x00,
+			0x00, 0x00, 0x00, 0x07, 0x80, 0x04, 0x38, 0x00,
 			0x48, 0x00, 0x00, 0x00, 0x48, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -4,19 +4,28 @@ import (
 	"fmt"
 
 	"github.com/abema/go-mp4"
+	"github.com/bluenviron/mediacommon/pkg/codecs/amr"
 	"github.com/bluenviron/mediacommon/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/pkg/codecs/flac"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h266"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg1video"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4video"
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
 	"github.com/bluenviron/mediacommon/pkg/formats/fmp4"
+	"github.com/bluenviron/mediacommon/pkg/formats/internal/mp4boxes"
 )
 
 // Specification: ISO 14496-1, Table 5
 const (
-	objectTypeIndicationVisualISO14496part2    = 0x20
-	objectTypeIndicationAudioISO14496part3     = 0x40
-	objectTypeIndicationVisualISO1318part2Main = 0x61
-	objectTypeIndicationAudioISO11172part3     = 0x6B
-	objectTypeIndicationVisualISO10918part1    = 0x6C
+	objectTypeIndicationVisualISO14496part2     = 0x20
+	objectTypeIndicationAudioISO14496part3      = 0x40
+	objectTypeIndicationVisualISO13818part2Main = 0x61
+	objectTypeIndicationAudioISO13818part3      = 0x69
+	objectTypeIndicationVisualISO11172part2     = 0x6A
+	objectTypeIndicationAudioISO11172part3      = 0x6B
+	objectTypeIndicationVisualISO10918part1     = 0x6C
 )
 
 // Specification: ISO 14496-1, Table 6
@@ -25,6 +34,22 @@ const (
 	streamTypeAudioStream  = 0x05
 )
 
+// MPEG-2 low sampling frequency streams are signaled with the ISO 13818-3 object type.
+func mpeg1AudioObjectTypeIndication(sampleRate int) uint8 {
+	if sampleRate < 32000 {
+		return objectTypeIndicationAudioISO13818part3
+	}
+	return objectTypeIndicationAudioISO11172part3
+}
+
+// MPEG-1 streams, that do not contain a sequence extension, are signaled with the ISO 11172-2 object type.
+func mpeg1VideoObjectTypeIndication(conf *mpeg1video.Config) uint8 {
+	if conf.IsMPEG2() {
+		return objectTypeIndicationVisualISO13818part2Main
+	}
+	return objectTypeIndicationVisualISO11172part2
+}
+
 func boolToUint8(v bool) uint8 {
 	if v {
 		return 1
@@ -77,6 +102,8 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		|    |    |    |    |    |    |av1C|
 		|    |    |    |    |    |vp09| (VP9)
 		|    |    |    |    |    |    |vpcC|
+		|    |    |    |    |    |vvi1| (H266)
+		|    |    |    |    |    |    |vvcC|
 		|    |    |    |    |    |hev1| (H265)
 		|    |    |    |    |    |    |hvcC|
 		|    |    |    |    |    |avc1| (H264)
@@ -85,6 +112,11 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		|    |    |    |    |    |    |esds|
 		|    |    |    |    |    |Opus| (Opus)
 		|    |    |    |    |    |    |dOps|
+		|    |    |    |    |    |fLaC| (FLAC)
+		|    |    |    |    |    |    |dfLa|
+		|    |    |    |    |    |samr| (AMR-NB)
+		|    |    |    |    |    |sawb| (AMR-WB)
+		|    |    |    |    |    |    |damr|
 		|    |    |    |    |    |mp4a| (MPEG-4/1 audio)
 		|    |    |    |    |    |    |esds|
 		|    |    |    |    |    |ac-3| (AC-3)
@@ -105,8 +137,11 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 	}
 
 	var av1SequenceHeader *av1.SequenceHeader
+	var h266SPS *h266.SPS
 	var h265SPS *h265.SPS
 	var h264SPS *h264.SPS
+	var mpeg1VideoConfig *mpeg1video.Config
+	var flacStreamInfo []byte
 
 	var width int
 	var height int
@@ -130,6 +165,20 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		width = codec.Width
 		height = codec.Height
 
+	case *fmp4.CodecH266:
+		if len(codec.SPS) == 0 || len(codec.PPS) == 0 {
+			return nil, fmt.Errorf("H266 parameters not provided")
+		}
+
+		h266SPS = &h266.SPS{}
+		err = h266SPS.Unmarshal(codec.SPS)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse H266 SPS: %w", err)
+		}
+
+		width = h266SPS.Width()
+		height = h266SPS.Height()
+
 	case *fmp4.CodecH265:
 		if len(codec.VPS) == 0 || len(codec.SPS) == 0 || len(codec.PPS) == 0 {
 			return nil, fmt.Errorf("H265 parameters not provided")
@@ -163,18 +212,28 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 			return nil, fmt.Errorf("MPEG-4 Video config not provided")
 		}
 
-		// TODO: parse config and use real values
-		width = 800
-		height = 600
+		var conf mpeg4video.Config
+		err = conf.Unmarshal(codec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse MPEG-4 Video config: %w", err)
+		}
+
+		width = conf.VideoObjectLayer.Width
+		height = conf.VideoObjectLayer.Height
 
 	case *fmp4.CodecMPEG1Video:
 		if len(codec.Config) == 0 {
 			return nil, fmt.Errorf("MPEG-1/2 Video config not provided")
 		}
 
-		// TODO: parse config and use real values
-		width = 800
-		height = 600
+		mpeg1VideoConfig = &mpeg1video.Config{}
+		err = mpeg1VideoConfig.Unmarshal(codec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse MPEG-1/2 Video config: %w", err)
+		}
+
+		width = mpeg1VideoConfig.Width()
+		height = mpeg1VideoConfig.Height()
 
 	case *fmp4.CodecMJPEG:
 		if codec.Width == 0 {
@@ -183,6 +242,18 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 
 		width = codec.Width
 		height = codec.Height
+
+	case *fmp4.CodecFLAC:
+		flacStreamInfo, err = codec.StreamInfo.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode FLAC STREAMINFO: %w", err)
+		}
+
+	case *fmp4.CodecEAC3:
+		err = codec.Validate()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	sampleDuration := uint32(0)
@@ -412,6 +483,92 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 			return nil, err
 		}
 
+	case *fmp4.CodecH266:
+		_, err = w.writeBoxStart(&mp4.VisualSampleEntry{ // <vvi1>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: mp4boxes.BoxTypeVvi1(),
+				},
+				DataReferenceIndex: 1,
+			},
+			Width:           uint16(width),
+			Height:          uint16(height),
+			Horizresolution: 4718592,
+			Vertresolution:  4718592,
+			FrameCount:      1,
+			Depth:           24,
+			PreDefined3:     -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var naluArrays []mp4boxes.VvcNaluArray
+
+		if len(codec.VPS) != 0 {
+			naluArrays = append(naluArrays, mp4boxes.VvcNaluArray{
+				NaluType: byte(h266.NALUType_VPS_NUT),
+				NumNalus: 1,
+				Nalus: []mp4.HEVCNalu{{
+					Length:  uint16(len(codec.VPS)),
+					NALUnit: codec.VPS,
+				}},
+			})
+		}
+
+		naluArrays = append(naluArrays, mp4boxes.VvcNaluArray{
+			NaluType: byte(h266.NALUType_SPS_NUT),
+			NumNalus: 1,
+			Nalus: []mp4.HEVCNalu{{
+				Length:  uint16(len(codec.SPS)),
+				NALUnit: codec.SPS,
+			}},
+		}, mp4boxes.VvcNaluArray{
+			NaluType: byte(h266.NALUType_PPS_NUT),
+			NumNalus: 1,
+			Nalus: []mp4.HEVCNalu{{
+				Length:  uint16(len(codec.PPS)),
+				NALUnit: codec.PPS,
+			}},
+		})
+
+		vvcc := &mp4boxes.VvcC{
+			Reserved:           31,
+			LengthSizeMinusOne: 3,
+			NumOfNaluArrays:    uint8(len(naluArrays)),
+			NaluArrays:         naluArrays,
+		}
+
+		if ptl := h266SPS.ProfileTierLevel; ptl != nil {
+			vvcc.PtlPresentFlag = true
+			vvcc.NumSublayers = h266SPS.MaxSublayersMinus1 + 1
+			vvcc.ChromaFormatIdc = h266SPS.ChromaFormatIdc
+			vvcc.BitDepthMinus8 = uint8(h266SPS.BitDepthMinus8)
+			vvcc.Reserved2 = 31
+			vvcc.NumBytesConstraintInfo = uint8(len(ptl.GeneralConstraintsInfo))
+			vvcc.GeneralProfileIdc = ptl.GeneralProfileIdc
+			vvcc.GeneralTierFlag = ptl.GeneralTierFlag != 0
+			vvcc.GeneralLevelIdc = ptl.GeneralLevelIdc
+			vvcc.GeneralConstraintInfo = ptl.GeneralConstraintsInfo
+
+			for i := len(ptl.PtlSublayerLevelPresentFlag) - 1; i >= 0; i-- {
+				if ptl.PtlSublayerLevelPresentFlag[i] {
+					vvcc.SublayerLevelPresentFlags |= 1 << (7 - (len(ptl.PtlSublayerLevelPresentFlag) - 1 - i))
+					vvcc.SublayerLevelIdc = append(vvcc.SublayerLevelIdc, ptl.SublayerLevelIdc[i])
+				}
+			}
+
+			vvcc.PtlNumSubProfiles = uint8(len(ptl.GeneralSubProfileIdc))
+			vvcc.GeneralSubProfileIdc = ptl.GeneralSubProfileIdc
+			vvcc.MaxPictureWidth = uint16(h266SPS.PicWidthMaxInLumaSamples)
+			vvcc.MaxPictureHeight = uint16(h266SPS.PicHeightMaxInLumaSamples)
+		}
+
+		_, err = w.writeBox(vvcc) // <vvcC/>
+		if err != nil {
+			return nil, err
+		}
+
 	case *fmp4.CodecH265:
 		_, err = w.writeBoxStart(&mp4.VisualSampleEntry{ // <hev1>
 			SampleEntry: mp4.SampleEntry{
@@ -620,7 +777,7 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 					Tag:  mp4.DecoderConfigDescrTag,
 					Size: 18 + uint32(len(codec.Config)),
 					DecoderConfigDescriptor: &mp4.DecoderConfigDescriptor{
-						ObjectTypeIndication: objectTypeIndicationVisualISO1318part2Main,
+						ObjectTypeIndication: mpeg1VideoObjectTypeIndication(mpeg1VideoConfig),
 						StreamType:           streamTypeVisualStream,
 						Reserved:             true,
 						MaxBitrate:           1000000,
@@ -695,6 +852,12 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		}
 
 	case *fmp4.CodecOpus:
+		var channelMapping *opus.ChannelMapping
+		channelMapping, err = codec.ChannelMappingOrDefault()
+		if err != nil {
+			return nil, fmt.Errorf("invalid Opus channel mapping: %w", err)
+		}
+
 		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <Opus>
 			SampleEntry: mp4.SampleEntry{
 				AnyTypeBox: mp4.AnyTypeBox{
@@ -711,9 +874,83 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		}
 
 		_, err = w.writeBox(&mp4.DOps{ // <dOps/>
-			OutputChannelCount: uint8(codec.ChannelCount),
-			PreSkip:            312,
-			InputSampleRate:    48000,
+			OutputChannelCount:   uint8(codec.ChannelCount),
+			PreSkip:              codec.PreSkipOrDefault(),
+			InputSampleRate:      codec.InputSampleRateOrDefault(),
+			OutputGain:           codec.OutputGain,
+			ChannelMappingFamily: channelMapping.Family,
+			StreamCount:          channelMapping.StreamCount,
+			CoupledCount:         channelMapping.CoupledCount,
+			ChannelMapping:       channelMapping.Table,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case *fmp4.CodecFLAC:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <fLaC>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: mp4boxes.BoxTypeFLaC(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.StreamInfo.ChannelCount),
+			SampleSize:   uint16(codec.StreamInfo.BitsPerSample),
+			SampleRate: func() uint32 {
+				// sample rates that do not fit into 16 bits are set to zero
+				if codec.StreamInfo.SampleRate > 65535 {
+					return 0
+				}
+				return uint32(codec.StreamInfo.SampleRate * 65536)
+			}(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = w.writeBox(&mp4boxes.DfLa{ // <dfLa/>
+			MetadataBlocks: []mp4boxes.DfLaMetadataBlock{{
+				LastMetadataBlockFlag: true,
+				BlockType:             uint8(flac.MetadataBlockTypeStreamInfo),
+				Length:                uint32(len(flacStreamInfo)),
+				BlockData:             flacStreamInfo,
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case *fmp4.CodecAMR:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <samr> or <sawb>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: func() mp4.BoxType {
+						if codec.Wideband {
+							return mp4boxes.BoxTypeSawb()
+						}
+						return mp4boxes.BoxTypeSamr()
+					}(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: 2,
+			SampleSize:   16,
+			SampleRate: func() uint32 {
+				if codec.Wideband {
+					return amr.SampleRateWideband * 65536
+				}
+				return amr.SampleRateNarrowband * 65536
+			}(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = w.writeBox(&mp4boxes.Damr{ // <damr/>
+			ModeSet:          codec.ModeSet,
+			ModeChangePeriod: codec.ModeChangePeriod,
+			FramesPerSample:  codec.FramesPerSample,
 		})
 		if err != nil {
 			return nil, err
@@ -802,7 +1039,7 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 					Tag:  mp4.DecoderConfigDescrTag,
 					Size: 13,
 					DecoderConfigDescriptor: &mp4.DecoderConfigDescriptor{
-						ObjectTypeIndication: objectTypeIndicationAudioISO11172part3,
+						ObjectTypeIndication: mpeg1AudioObjectTypeIndication(codec.SampleRate),
 						StreamType:           streamTypeAudioStream,
 						Reserved:             true,
 						MaxBitrate:           128825,
@@ -853,6 +1090,66 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 			return nil, err
 		}
 
+	case *fmp4.CodecEAC3:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ec-3>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: mp4boxes.BoxTypeEC3(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		substreams := make([]mp4boxes.Dec3Substream, len(codec.Substreams))
+		for i, sub := range codec.Substreams {
+			substreams[i] = mp4boxes.Dec3Substream{
+				Fscod:     sub.Fscod,
+				Bsid:      sub.Bsid,
+				Asvc:      boolToUint8(sub.Asvc),
+				Bsmod:     sub.Bsmod,
+				Acmod:     sub.Acmod,
+				LfeOn:     boolToUint8(sub.LfeOn),
+				NumDepSub: sub.NumDepSub,
+				ChanLoc:   sub.ChanLoc,
+			}
+		}
+
+		_, err = w.writeBox(&mp4boxes.Dec3{ // <dec3/>
+			DataRate:   codec.DataRate,
+			NumIndSub:  uint8(len(codec.Substreams) - 1),
+			Substreams: substreams,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case *fmp4.CodecG711:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <alaw> or <ulaw>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: func() mp4.BoxType {
+						if codec.MULaw {
+							return mp4boxes.BoxTypeUlaw()
+						}
+						return mp4boxes.BoxTypeAlaw()
+					}(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
+		})
+		if err != nil {
+			return nil, err
+		}
+
 	case *fmp4.CodecLPCM:
 		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ipcm>
 			SampleEntry: mp4.SampleEntry{